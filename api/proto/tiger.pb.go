@@ -14,6 +14,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -29,6 +30,19 @@ type GetTigersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// page_size is the maximum number of tigers returned. Default 50, maximum 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page. Empty for the first page.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// name_prefix returns only tigers whose name starts with it.
+	NamePrefix string `protobuf:"bytes,3,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// last_seen_after returns only tigers last seen at or after it.
+	LastSeenAfter *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen_after,json=lastSeenAfter,proto3" json:"last_seen_after,omitempty"`
+	// last_seen_before returns only tigers last seen before it.
+	LastSeenBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen_before,json=lastSeenBefore,proto3" json:"last_seen_before,omitempty"`
+	// bounding_box returns only tigers last seen inside it.
+	BoundingBox *BoundingBox `protobuf:"bytes,6,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
 }
 
 func (x *GetTigersRequest) Reset() {
@@ -63,12 +77,56 @@ func (*GetTigersRequest) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GetTigersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetTigersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetTigersRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *GetTigersRequest) GetLastSeenAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAfter
+	}
+	return nil
+}
+
+func (x *GetTigersRequest) GetLastSeenBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenBefore
+	}
+	return nil
+}
+
+func (x *GetTigersRequest) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
 type GetTigersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Data []*Tiger `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// next_page_token is empty if there is no more page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
 func (x *GetTigersResponse) Reset() {
@@ -110,20 +168,26 @@ func (x *GetTigersResponse) GetData() []*Tiger {
 	return nil
 }
 
-type CreateTigerRequest struct {
+func (x *GetTigersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type BoundingBox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name              string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	DateOfBirth       *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	LastSeenTimestamp *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=last_seen_timestamp,json=lastSeenTimestamp,proto3" json:"last_seen_timestamp,omitempty"`
-	LastSeenLatitude  *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=last_seen_latitude,json=lastSeenLatitude,proto3" json:"last_seen_latitude,omitempty"`
-	LastSeenLongitude *wrapperspb.DoubleValue `protobuf:"bytes,5,opt,name=last_seen_longitude,json=lastSeenLongitude,proto3" json:"last_seen_longitude,omitempty"`
+	MinLatitude  float64 `protobuf:"fixed64,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude float64 `protobuf:"fixed64,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude  float64 `protobuf:"fixed64,3,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude float64 `protobuf:"fixed64,4,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
 }
 
-func (x *CreateTigerRequest) Reset() {
-	*x = CreateTigerRequest{}
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -131,13 +195,13 @@ func (x *CreateTigerRequest) Reset() {
 	}
 }
 
-func (x *CreateTigerRequest) String() string {
+func (x *BoundingBox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTigerRequest) ProtoMessage() {}
+func (*BoundingBox) ProtoMessage() {}
 
-func (x *CreateTigerRequest) ProtoReflect() protoreflect.Message {
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -149,56 +213,57 @@ func (x *CreateTigerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTigerRequest.ProtoReflect.Descriptor instead.
-func (*CreateTigerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateTigerRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreateTigerRequest) GetDateOfBirth() *timestamppb.Timestamp {
+func (x *BoundingBox) GetMinLatitude() float64 {
 	if x != nil {
-		return x.DateOfBirth
+		return x.MinLatitude
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateTigerRequest) GetLastSeenTimestamp() *timestamppb.Timestamp {
+func (x *BoundingBox) GetMinLongitude() float64 {
 	if x != nil {
-		return x.LastSeenTimestamp
+		return x.MinLongitude
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateTigerRequest) GetLastSeenLatitude() *wrapperspb.DoubleValue {
+func (x *BoundingBox) GetMaxLatitude() float64 {
 	if x != nil {
-		return x.LastSeenLatitude
+		return x.MaxLatitude
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateTigerRequest) GetLastSeenLongitude() *wrapperspb.DoubleValue {
+func (x *BoundingBox) GetMaxLongitude() float64 {
 	if x != nil {
-		return x.LastSeenLongitude
+		return x.MaxLongitude
 	}
-	return nil
+	return 0
 }
 
-type CreateTigerResponse struct {
+type SearchTigersNearbyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// lat and lng are the searched point, both are required.
+	Lat *wrapperspb.DoubleValue `protobuf:"bytes,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng *wrapperspb.DoubleValue `protobuf:"bytes,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	// radius_km must be greater than 0 and at most 500.
+	RadiusKm float64 `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	// since returns only tigers last seen at or after it.
+	Since *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	// limit is the maximum number of tigers returned. Default 50, maximum 100.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *CreateTigerResponse) Reset() {
-	*x = CreateTigerResponse{}
+func (x *SearchTigersNearbyRequest) Reset() {
+	*x = SearchTigersNearbyRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -206,13 +271,13 @@ func (x *CreateTigerResponse) Reset() {
 	}
 }
 
-func (x *CreateTigerResponse) String() string {
+func (x *SearchTigersNearbyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTigerResponse) ProtoMessage() {}
+func (*SearchTigersNearbyRequest) ProtoMessage() {}
 
-func (x *CreateTigerResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchTigersNearbyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -224,28 +289,56 @@ func (x *CreateTigerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTigerResponse.ProtoReflect.Descriptor instead.
-func (*CreateTigerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTigersNearbyRequest.ProtoReflect.Descriptor instead.
+func (*SearchTigersNearbyRequest) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateTigerResponse) GetMessage() string {
+func (x *SearchTigersNearbyRequest) GetLat() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.Message
+		return x.Lat
 	}
-	return ""
+	return nil
 }
 
-type GetSightingsRequest struct {
+func (x *SearchTigersNearbyRequest) GetLng() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Lng
+	}
+	return nil
+}
+
+func (x *SearchTigersNearbyRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *SearchTigersNearbyRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *SearchTigersNearbyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchTigersNearbyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data []*NearbyTiger `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *GetSightingsRequest) Reset() {
-	*x = GetSightingsRequest{}
+func (x *SearchTigersNearbyResponse) Reset() {
+	*x = SearchTigersNearbyResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -253,13 +346,13 @@ func (x *GetSightingsRequest) Reset() {
 	}
 }
 
-func (x *GetSightingsRequest) String() string {
+func (x *SearchTigersNearbyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSightingsRequest) ProtoMessage() {}
+func (*SearchTigersNearbyResponse) ProtoMessage() {}
 
-func (x *GetSightingsRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchTigersNearbyResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -271,28 +364,30 @@ func (x *GetSightingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSightingsRequest.ProtoReflect.Descriptor instead.
-func (*GetSightingsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchTigersNearbyResponse.ProtoReflect.Descriptor instead.
+func (*SearchTigersNearbyResponse) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetSightingsRequest) GetId() int32 {
+func (x *SearchTigersNearbyResponse) GetData() []*NearbyTiger {
 	if x != nil {
-		return x.Id
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-type GetSightingsResponse struct {
+type NearbyTiger struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []*Sighting `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Tiger *Tiger `protobuf:"bytes,1,opt,name=tiger,proto3" json:"tiger,omitempty"`
+	// distance_km is the distance between the searched point and the last seen location of the tiger.
+	DistanceKm float64 `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
 }
 
-func (x *GetSightingsResponse) Reset() {
-	*x = GetSightingsResponse{}
+func (x *NearbyTiger) Reset() {
+	*x = NearbyTiger{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -300,13 +395,13 @@ func (x *GetSightingsResponse) Reset() {
 	}
 }
 
-func (x *GetSightingsResponse) String() string {
+func (x *NearbyTiger) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSightingsResponse) ProtoMessage() {}
+func (*NearbyTiger) ProtoMessage() {}
 
-func (x *GetSightingsResponse) ProtoReflect() protoreflect.Message {
+func (x *NearbyTiger) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -318,32 +413,41 @@ func (x *GetSightingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSightingsResponse.ProtoReflect.Descriptor instead.
-func (*GetSightingsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NearbyTiger.ProtoReflect.Descriptor instead.
+func (*NearbyTiger) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetSightingsResponse) GetData() []*Sighting {
+func (x *NearbyTiger) GetTiger() *Tiger {
 	if x != nil {
-		return x.Data
+		return x.Tiger
 	}
 	return nil
 }
 
-type CreateSightingRequest struct {
+func (x *NearbyTiger) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type CreateTigerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	SeenAt    *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
-	Latitude  *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	ImageData string                  `protobuf:"bytes,5,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	Name              string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth       *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	LastSeenTimestamp *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=last_seen_timestamp,json=lastSeenTimestamp,proto3" json:"last_seen_timestamp,omitempty"`
+	LastSeenLatitude  *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=last_seen_latitude,json=lastSeenLatitude,proto3" json:"last_seen_latitude,omitempty"`
+	LastSeenLongitude *wrapperspb.DoubleValue `protobuf:"bytes,5,opt,name=last_seen_longitude,json=lastSeenLongitude,proto3" json:"last_seen_longitude,omitempty"`
+	// max_sighting_distance overrides the default maximum distance in meters between two sightings of the tiger.
+	MaxSightingDistance *wrapperspb.DoubleValue `protobuf:"bytes,6,opt,name=max_sighting_distance,json=maxSightingDistance,proto3" json:"max_sighting_distance,omitempty"`
 }
 
-func (x *CreateSightingRequest) Reset() {
-	*x = CreateSightingRequest{}
+func (x *CreateTigerRequest) Reset() {
+	*x = CreateTigerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -351,13 +455,13 @@ func (x *CreateSightingRequest) Reset() {
 	}
 }
 
-func (x *CreateSightingRequest) String() string {
+func (x *CreateTigerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSightingRequest) ProtoMessage() {}
+func (*CreateTigerRequest) ProtoMessage() {}
 
-func (x *CreateSightingRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateTigerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,47 +473,54 @@ func (x *CreateSightingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSightingRequest.ProtoReflect.Descriptor instead.
-func (*CreateSightingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTigerRequest.ProtoReflect.Descriptor instead.
+func (*CreateTigerRequest) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateSightingRequest) GetId() int32 {
+func (x *CreateTigerRequest) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateSightingRequest) GetSeenAt() *timestamppb.Timestamp {
+func (x *CreateTigerRequest) GetDateOfBirth() *timestamppb.Timestamp {
 	if x != nil {
-		return x.SeenAt
+		return x.DateOfBirth
 	}
 	return nil
 }
 
-func (x *CreateSightingRequest) GetLatitude() *wrapperspb.DoubleValue {
+func (x *CreateTigerRequest) GetLastSeenTimestamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Latitude
+		return x.LastSeenTimestamp
 	}
 	return nil
 }
 
-func (x *CreateSightingRequest) GetLongitude() *wrapperspb.DoubleValue {
+func (x *CreateTigerRequest) GetLastSeenLatitude() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.Longitude
+		return x.LastSeenLatitude
 	}
 	return nil
 }
 
-func (x *CreateSightingRequest) GetImageData() string {
+func (x *CreateTigerRequest) GetLastSeenLongitude() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.ImageData
+		return x.LastSeenLongitude
 	}
-	return ""
+	return nil
 }
 
-type CreateSightingResponse struct {
+func (x *CreateTigerRequest) GetMaxSightingDistance() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.MaxSightingDistance
+	}
+	return nil
+}
+
+type CreateTigerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -417,8 +528,8 @@ type CreateSightingResponse struct {
 	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *CreateSightingResponse) Reset() {
-	*x = CreateSightingResponse{}
+func (x *CreateTigerResponse) Reset() {
+	*x = CreateTigerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -426,13 +537,13 @@ func (x *CreateSightingResponse) Reset() {
 	}
 }
 
-func (x *CreateSightingResponse) String() string {
+func (x *CreateTigerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSightingResponse) ProtoMessage() {}
+func (*CreateTigerResponse) ProtoMessage() {}
 
-func (x *CreateSightingResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateTigerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -444,35 +555,28 @@ func (x *CreateSightingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSightingResponse.ProtoReflect.Descriptor instead.
-func (*CreateSightingResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateTigerResponse.ProtoReflect.Descriptor instead.
+func (*CreateTigerResponse) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateSightingResponse) GetMessage() string {
+func (x *CreateTigerResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type Tiger struct {
+type GetTigerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name              string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	DateOfBirth       *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	LastSeenTimestamp *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=last_seen_timestamp,json=lastSeenTimestamp,proto3" json:"last_seen_timestamp,omitempty"`
-	LastSeenLatitude  *wrapperspb.DoubleValue `protobuf:"bytes,5,opt,name=last_seen_latitude,json=lastSeenLatitude,proto3" json:"last_seen_latitude,omitempty"`
-	LastSeenLongitude *wrapperspb.DoubleValue `protobuf:"bytes,6,opt,name=last_seen_longitude,json=lastSeenLongitude,proto3" json:"last_seen_longitude,omitempty"`
-	CreatedAt         *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         *timestamppb.Timestamp  `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *Tiger) Reset() {
-	*x = Tiger{}
+func (x *GetTigerRequest) Reset() {
+	*x = GetTigerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tiger_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -480,13 +584,13 @@ func (x *Tiger) Reset() {
 	}
 }
 
-func (x *Tiger) String() string {
+func (x *GetTigerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tiger) ProtoMessage() {}
+func (*GetTigerRequest) ProtoMessage() {}
 
-func (x *Tiger) ProtoReflect() protoreflect.Message {
+func (x *GetTigerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tiger_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -498,96 +602,1648 @@ func (x *Tiger) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tiger.ProtoReflect.Descriptor instead.
-func (*Tiger) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTigerRequest.ProtoReflect.Descriptor instead.
+func (*GetTigerRequest) Descriptor() ([]byte, []int) {
 	return file_tiger_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Tiger) GetId() int32 {
+func (x *GetTigerRequest) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Tiger) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
+type GetTigerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *Tiger) GetDateOfBirth() *timestamppb.Timestamp {
-	if x != nil {
-		return x.DateOfBirth
-	}
-	return nil
+	Data *Tiger `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *Tiger) GetLastSeenTimestamp() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastSeenTimestamp
+func (x *GetTigerResponse) Reset() {
+	*x = GetTigerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *Tiger) GetLastSeenLatitude() *wrapperspb.DoubleValue {
-	if x != nil {
-		return x.LastSeenLatitude
-	}
-	return nil
+func (x *GetTigerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Tiger) GetLastSeenLongitude() *wrapperspb.DoubleValue {
-	if x != nil {
-		return x.LastSeenLongitude
+func (*GetTigerResponse) ProtoMessage() {}
+
+func (x *GetTigerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTigerResponse.ProtoReflect.Descriptor instead.
+func (*GetTigerResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTigerResponse) GetData() *Tiger {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateTigerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tiger *Tiger `protobuf:"bytes,2,opt,name=tiger,proto3" json:"tiger,omitempty"`
+	// update_mask lists the fields of tiger to update. Empty means name, and date_of_birth if it is set.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+}
+
+func (x *UpdateTigerRequest) Reset() {
+	*x = UpdateTigerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTigerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTigerRequest) ProtoMessage() {}
+
+func (x *UpdateTigerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTigerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTigerRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateTigerRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTigerRequest) GetTiger() *Tiger {
+	if x != nil {
+		return x.Tiger
+	}
+	return nil
+}
+
+func (x *UpdateTigerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTigerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *Tiger `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UpdateTigerResponse) Reset() {
+	*x = UpdateTigerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTigerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTigerResponse) ProtoMessage() {}
+
+func (x *UpdateTigerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTigerResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTigerResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateTigerResponse) GetData() *Tiger {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteTigerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteTigerRequest) Reset() {
+	*x = DeleteTigerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTigerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTigerRequest) ProtoMessage() {}
+
+func (x *DeleteTigerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTigerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTigerRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteTigerRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTigerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteTigerResponse) Reset() {
+	*x = DeleteTigerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTigerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTigerResponse) ProtoMessage() {}
+
+func (x *DeleteTigerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTigerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTigerResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteTigerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetSightingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// page_size is the maximum number of sightings returned. Default 50, maximum 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page. Empty for the first page.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// seen_from returns only sightings seen at or after it.
+	SeenFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=seen_from,json=seenFrom,proto3" json:"seen_from,omitempty"`
+	// seen_to returns only sightings seen before it.
+	SeenTo *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=seen_to,json=seenTo,proto3" json:"seen_to,omitempty"`
+	// include_image_data sets image_data to the medium rendition of each image, read for every sighting.
+	// It is empty by default, use image_url or medium_url to get the image instead.
+	IncludeImageData bool `protobuf:"varint,7,opt,name=include_image_data,json=includeImageData,proto3" json:"include_image_data,omitempty"`
+}
+
+func (x *GetSightingsRequest) Reset() {
+	*x = GetSightingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSightingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSightingsRequest) ProtoMessage() {}
+
+func (x *GetSightingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSightingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSightingsRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSightingsRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetSightingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetSightingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetSightingsRequest) GetSeenFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenFrom
+	}
+	return nil
+}
+
+func (x *GetSightingsRequest) GetSeenTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenTo
+	}
+	return nil
+}
+
+func (x *GetSightingsRequest) GetIncludeImageData() bool {
+	if x != nil {
+		return x.IncludeImageData
+	}
+	return false
+}
+
+type GetSightingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*Sighting `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	// next_page_token is empty if there is no more page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetSightingsResponse) Reset() {
+	*x = GetSightingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSightingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSightingsResponse) ProtoMessage() {}
+
+func (x *GetSightingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSightingsResponse.ProtoReflect.Descriptor instead.
+func (*GetSightingsResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSightingsResponse) GetData() []*Sighting {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetSightingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateSightingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// seen_at, and latitude with longitude, are read from the EXIF of a jpeg image_data if omitted.
+	// Otherwise the sighting needs review if they are too far from the EXIF.
+	SeenAt    *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
+	Latitude  *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// image_data is a jpeg, png, gif or webp image as base64, optionally as a data URI.
+	// The EXIF of a jpeg image is not kept, except its orientation. Its renditions are turned upright.
+	ImageData string `protobuf:"bytes,5,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	// reporter_name is the name of whoever reports the sighting.
+	ReporterName string `protobuf:"bytes,7,opt,name=reporter_name,json=reporterName,proto3" json:"reporter_name,omitempty"`
+	// reporter_email is notified whenever the tiger is sighted again. Empty means no notification.
+	ReporterEmail string `protobuf:"bytes,8,opt,name=reporter_email,json=reporterEmail,proto3" json:"reporter_email,omitempty"`
+}
+
+func (x *CreateSightingRequest) Reset() {
+	*x = CreateSightingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSightingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSightingRequest) ProtoMessage() {}
+
+func (x *CreateSightingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSightingRequest.ProtoReflect.Descriptor instead.
+func (*CreateSightingRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateSightingRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreateSightingRequest) GetSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenAt
+	}
+	return nil
+}
+
+func (x *CreateSightingRequest) GetLatitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Latitude
+	}
+	return nil
+}
+
+func (x *CreateSightingRequest) GetLongitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Longitude
+	}
+	return nil
+}
+
+func (x *CreateSightingRequest) GetImageData() string {
+	if x != nil {
+		return x.ImageData
+	}
+	return ""
+}
+
+func (x *CreateSightingRequest) GetReporterName() string {
+	if x != nil {
+		return x.ReporterName
+	}
+	return ""
+}
+
+func (x *CreateSightingRequest) GetReporterEmail() string {
+	if x != nil {
+		return x.ReporterEmail
+	}
+	return ""
+}
+
+type CreateSightingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// needs_review is true if the sighting was accepted although it breaks a rule, e.g. it is too far
+	// or its image is a near-duplicate of the image of another sighting.
+	NeedsReview bool `protobuf:"varint,2,opt,name=needs_review,json=needsReview,proto3" json:"needs_review,omitempty"`
+}
+
+func (x *CreateSightingResponse) Reset() {
+	*x = CreateSightingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSightingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSightingResponse) ProtoMessage() {}
+
+func (x *CreateSightingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSightingResponse.ProtoReflect.Descriptor instead.
+func (*CreateSightingResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateSightingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateSightingResponse) GetNeedsReview() bool {
+	if x != nil {
+		return x.NeedsReview
+	}
+	return false
+}
+
+type FindSimilarImagesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// image_data is a jpeg, png, gif or webp image as base64, optionally as a data URI.
+	ImageData string `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	// max_distance is the number of bits, out of 64, by which the perceptual hashes of the images may differ, at most 7.
+	// Empty means the distance of near-duplicates detected by CreateSighting.
+	MaxDistance *wrapperspb.Int32Value `protobuf:"bytes,2,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// limit is the maximum number of sightings returned. Default 50, maximum 100.
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *FindSimilarImagesRequest) Reset() {
+	*x = FindSimilarImagesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindSimilarImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarImagesRequest) ProtoMessage() {}
+
+func (x *FindSimilarImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarImagesRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarImagesRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FindSimilarImagesRequest) GetImageData() string {
+	if x != nil {
+		return x.ImageData
+	}
+	return ""
+}
+
+func (x *FindSimilarImagesRequest) GetMaxDistance() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return nil
+}
+
+func (x *FindSimilarImagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type FindSimilarImagesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*SimilarSighting `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *FindSimilarImagesResponse) Reset() {
+	*x = FindSimilarImagesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindSimilarImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarImagesResponse) ProtoMessage() {}
+
+func (x *FindSimilarImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarImagesResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarImagesResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FindSimilarImagesResponse) GetData() []*SimilarSighting {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SimilarSighting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TigerId int32 `protobuf:"varint,1,opt,name=tiger_id,json=tigerId,proto3" json:"tiger_id,omitempty"`
+	// sighting leaves image_data empty. Use image_url to get the image.
+	Sighting *Sighting `protobuf:"bytes,2,opt,name=sighting,proto3" json:"sighting,omitempty"`
+	// distance is the number of bits by which the perceptual hashes of the images differ, 0 for the same picture.
+	Distance int32 `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"`
+}
+
+func (x *SimilarSighting) Reset() {
+	*x = SimilarSighting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SimilarSighting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarSighting) ProtoMessage() {}
+
+func (x *SimilarSighting) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarSighting.ProtoReflect.Descriptor instead.
+func (*SimilarSighting) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SimilarSighting) GetTigerId() int32 {
+	if x != nil {
+		return x.TigerId
+	}
+	return 0
+}
+
+func (x *SimilarSighting) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *SimilarSighting) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type Tiger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth       *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	LastSeenTimestamp *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=last_seen_timestamp,json=lastSeenTimestamp,proto3" json:"last_seen_timestamp,omitempty"`
+	LastSeenLatitude  *wrapperspb.DoubleValue `protobuf:"bytes,5,opt,name=last_seen_latitude,json=lastSeenLatitude,proto3" json:"last_seen_latitude,omitempty"`
+	LastSeenLongitude *wrapperspb.DoubleValue `protobuf:"bytes,6,opt,name=last_seen_longitude,json=lastSeenLongitude,proto3" json:"last_seen_longitude,omitempty"`
+	CreatedAt         *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp  `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// max_sighting_distance overrides the default maximum distance in meters between two sightings of the tiger.
+	// Empty means the default is used.
+	MaxSightingDistance *wrapperspb.DoubleValue `protobuf:"bytes,9,opt,name=max_sighting_distance,json=maxSightingDistance,proto3" json:"max_sighting_distance,omitempty"`
+}
+
+func (x *Tiger) Reset() {
+	*x = Tiger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tiger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tiger) ProtoMessage() {}
+
+func (x *Tiger) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tiger.ProtoReflect.Descriptor instead.
+func (*Tiger) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Tiger) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tiger) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tiger) GetDateOfBirth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *Tiger) GetLastSeenTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenTimestamp
+	}
+	return nil
+}
+
+func (x *Tiger) GetLastSeenLatitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.LastSeenLatitude
+	}
+	return nil
+}
+
+func (x *Tiger) GetLastSeenLongitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.LastSeenLongitude
+	}
+	return nil
+}
+
+func (x *Tiger) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Tiger) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Tiger) GetMaxSightingDistance() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.MaxSightingDistance
+	}
+	return nil
+}
+
+type Sighting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SeenAt    *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
+	Latitude  *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// image_data is the medium rendition of the image as a base64 data URI, only set if include_image_data is asked.
+	ImageData string `protobuf:"bytes,5,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	// image_url is the path of the original image, as it was sent.
+	ImageUrl string `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// needs_review is true if the sighting was accepted although it breaks a rule, e.g. it is too far.
+	NeedsReview bool `protobuf:"varint,7,opt,name=needs_review,json=needsReview,proto3" json:"needs_review,omitempty"`
+	// speed_kmh is the speed implied by the distance and time from the previous sighting.
+	// Empty means the tiger moved at the time of the previous sighting.
+	SpeedKmh *wrapperspb.DoubleValue `protobuf:"bytes,8,opt,name=speed_kmh,json=speedKmh,proto3" json:"speed_kmh,omitempty"`
+	// reporter_name is the name of whoever reported the sighting.
+	ReporterName string `protobuf:"bytes,9,opt,name=reporter_name,json=reporterName,proto3" json:"reporter_name,omitempty"`
+	// thumbnail_url is the path of the thumbnail rendition of the image, to list sightings.
+	ThumbnailUrl string `protobuf:"bytes,10,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	// medium_url is the path of the medium rendition of the image.
+	MediumUrl string `protobuf:"bytes,11,opt,name=medium_url,json=mediumUrl,proto3" json:"medium_url,omitempty"`
+	// large_url is the path of the large rendition of the image, to view the sighting.
+	LargeUrl string `protobuf:"bytes,12,opt,name=large_url,json=largeUrl,proto3" json:"large_url,omitempty"`
+}
+
+func (x *Sighting) Reset() {
+	*x = Sighting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sighting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sighting) ProtoMessage() {}
+
+func (x *Sighting) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sighting.ProtoReflect.Descriptor instead.
+func (*Sighting) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Sighting) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Sighting) GetSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SeenAt
+	}
+	return nil
+}
+
+func (x *Sighting) GetLatitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Latitude
+	}
+	return nil
+}
+
+func (x *Sighting) GetLongitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Longitude
+	}
+	return nil
+}
+
+func (x *Sighting) GetImageData() string {
+	if x != nil {
+		return x.ImageData
+	}
+	return ""
+}
+
+func (x *Sighting) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Sighting) GetNeedsReview() bool {
+	if x != nil {
+		return x.NeedsReview
+	}
+	return false
+}
+
+func (x *Sighting) GetSpeedKmh() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.SpeedKmh
+	}
+	return nil
+}
+
+func (x *Sighting) GetReporterName() string {
+	if x != nil {
+		return x.ReporterName
+	}
+	return ""
+}
+
+func (x *Sighting) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Sighting) GetMediumUrl() string {
+	if x != nil {
+		return x.MediumUrl
+	}
+	return ""
+}
+
+func (x *Sighting) GetLargeUrl() string {
+	if x != nil {
+		return x.LargeUrl
+	}
+	return ""
+}
+
+type WatchSightingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tiger_ids streams only sightings of the tigers. Empty means every tiger.
+	TigerIds []int32 `protobuf:"varint,1,rep,packed,name=tiger_ids,json=tigerIds,proto3" json:"tiger_ids,omitempty"`
+	// bounding_box streams only sightings inside it. Empty means everywhere.
+	BoundingBox *BoundingBox `protobuf:"bytes,2,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+}
+
+func (x *WatchSightingsRequest) Reset() {
+	*x = WatchSightingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSightingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSightingsRequest) ProtoMessage() {}
+
+func (x *WatchSightingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSightingsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSightingsRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchSightingsRequest) GetTigerIds() []int32 {
+	if x != nil {
+		return x.TigerIds
+	}
+	return nil
+}
+
+func (x *WatchSightingsRequest) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+type WatchSightingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*WatchSightingsResponse_Sighting
+	//	*WatchSightingsResponse_Heartbeat
+	Event isWatchSightingsResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *WatchSightingsResponse) Reset() {
+	*x = WatchSightingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSightingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSightingsResponse) ProtoMessage() {}
+
+func (x *WatchSightingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSightingsResponse.ProtoReflect.Descriptor instead.
+func (*WatchSightingsResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{24}
+}
+
+func (m *WatchSightingsResponse) GetEvent() isWatchSightingsResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *WatchSightingsResponse) GetSighting() *WatchedSighting {
+	if x, ok := x.GetEvent().(*WatchSightingsResponse_Sighting); ok {
+		return x.Sighting
+	}
+	return nil
+}
+
+func (x *WatchSightingsResponse) GetHeartbeat() *timestamppb.Timestamp {
+	if x, ok := x.GetEvent().(*WatchSightingsResponse_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+type isWatchSightingsResponse_Event interface {
+	isWatchSightingsResponse_Event()
+}
+
+type WatchSightingsResponse_Sighting struct {
+	Sighting *WatchedSighting `protobuf:"bytes,1,opt,name=sighting,proto3,oneof"`
+}
+
+type WatchSightingsResponse_Heartbeat struct {
+	// heartbeat is the time the server sent it. It keeps idle connections open.
+	Heartbeat *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*WatchSightingsResponse_Sighting) isWatchSightingsResponse_Event() {}
+
+func (*WatchSightingsResponse_Heartbeat) isWatchSightingsResponse_Event() {}
+
+type WatchedSighting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TigerId   int32  `protobuf:"varint,1,opt,name=tiger_id,json=tigerId,proto3" json:"tiger_id,omitempty"`
+	TigerName string `protobuf:"bytes,2,opt,name=tiger_name,json=tigerName,proto3" json:"tiger_name,omitempty"`
+	// sighting leaves image_data empty. Use image_url to get the image.
+	Sighting *Sighting `protobuf:"bytes,3,opt,name=sighting,proto3" json:"sighting,omitempty"`
+}
+
+func (x *WatchedSighting) Reset() {
+	*x = WatchedSighting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchedSighting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchedSighting) ProtoMessage() {}
+
+func (x *WatchedSighting) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchedSighting.ProtoReflect.Descriptor instead.
+func (*WatchedSighting) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchedSighting) GetTigerId() int32 {
+	if x != nil {
+		return x.TigerId
+	}
+	return 0
+}
+
+func (x *WatchedSighting) GetTigerName() string {
+	if x != nil {
+		return x.TigerName
+	}
+	return ""
+}
+
+func (x *WatchedSighting) GetSighting() *Sighting {
+	if x != nil {
+		return x.Sighting
+	}
+	return nil
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url receives a signed POST for every new sighting matching the filters. It must be http or https.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// tiger_id delivers only sightings of the tiger. Empty means every tiger.
+	TigerId *wrapperspb.Int32Value `protobuf:"bytes,2,opt,name=tiger_id,json=tigerId,proto3" json:"tiger_id,omitempty"`
+	// bounding_box delivers only sightings inside it. Empty means everywhere.
+	BoundingBox *BoundingBox `protobuf:"bytes,3,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+}
+
+func (x *CreateWebhookSubscriptionRequest) Reset() {
+	*x = CreateWebhookSubscriptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetTigerId() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.TigerId
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *WebhookSubscription `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// secret is the key of the HMAC-SHA256 signature sent in X-Webhook-Signature header.
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *CreateWebhookSubscriptionResponse) Reset() {
+	*x = CreateWebhookSubscriptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetData() *WebhookSubscription {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type GetWebhookSubscriptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetWebhookSubscriptionsRequest) Reset() {
+	*x = GetWebhookSubscriptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *GetWebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{28}
+}
+
+type GetWebhookSubscriptionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*WebhookSubscription `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *GetWebhookSubscriptionsResponse) Reset() {
+	*x = GetWebhookSubscriptionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *GetWebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetWebhookSubscriptionsResponse) GetData() []*WebhookSubscription {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteWebhookSubscriptionRequest) Reset() {
+	*x = DeleteWebhookSubscriptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Tiger) GetCreatedAt() *timestamppb.Timestamp {
+// Deprecated: Use DeleteWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteWebhookSubscriptionRequest) GetId() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *Tiger) GetUpdatedAt() *timestamppb.Timestamp {
+type DeleteWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteWebhookSubscriptionResponse) Reset() {
+	*x = DeleteWebhookSubscriptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteWebhookSubscriptionResponse) GetMessage() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Message
+	}
+	return ""
+}
+
+type GetWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// page_size is the maximum number of delivery attempts returned. Default 50, maximum 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *GetWebhookDeliveriesRequest) Reset() {
+	*x = GetWebhookDeliveriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetWebhookDeliveriesRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*WebhookDeliveryAttempt `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *GetWebhookDeliveriesResponse) Reset() {
+	*x = GetWebhookDeliveriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *GetWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetWebhookDeliveriesResponse) GetData() []*WebhookDeliveryAttempt {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type Sighting struct {
+type WebhookSubscription struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	SeenAt    *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=seen_at,json=seenAt,proto3" json:"seen_at,omitempty"`
-	Latitude  *wrapperspb.DoubleValue `protobuf:"bytes,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	ImageData string                  `protobuf:"bytes,5,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url         string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	TigerId     *wrapperspb.Int32Value `protobuf:"bytes,3,opt,name=tiger_id,json=tigerId,proto3" json:"tiger_id,omitempty"`
+	BoundingBox *BoundingBox           `protobuf:"bytes,4,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func (x *Sighting) Reset() {
-	*x = Sighting{}
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tiger_proto_msgTypes[9]
+		mi := &file_tiger_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Sighting) String() string {
+func (x *WebhookSubscription) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Sighting) ProtoMessage() {}
+func (*WebhookSubscription) ProtoMessage() {}
 
-func (x *Sighting) ProtoReflect() protoreflect.Message {
-	mi := &file_tiger_proto_msgTypes[9]
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,46 +2254,153 @@ func (x *Sighting) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Sighting.ProtoReflect.Descriptor instead.
-func (*Sighting) Descriptor() ([]byte, []int) {
-	return file_tiger_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *Sighting) GetId() int32 {
+func (x *WebhookSubscription) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Sighting) GetSeenAt() *timestamppb.Timestamp {
+func (x *WebhookSubscription) GetUrl() string {
 	if x != nil {
-		return x.SeenAt
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetTigerId() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.TigerId
 	}
 	return nil
 }
 
-func (x *Sighting) GetLatitude() *wrapperspb.DoubleValue {
+func (x *WebhookSubscription) GetBoundingBox() *BoundingBox {
 	if x != nil {
-		return x.Latitude
+		return x.BoundingBox
 	}
 	return nil
 }
 
-func (x *Sighting) GetLongitude() *wrapperspb.DoubleValue {
+func (x *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Longitude
+		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *Sighting) GetImageData() string {
+type WebhookDeliveryAttempt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// delivery_id is sent in X-Webhook-Id header. It is the same for every attempt of a delivery.
+	DeliveryId string `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	EventType  string `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// attempt counts the attempts of the delivery, starting at 1.
+	Attempt int32 `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// status_code is the HTTP status of the response. 0 means no response was received.
+	StatusCode int32 `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// error is empty if the delivery succeeded.
+	Error      string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs int32                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *WebhookDeliveryAttempt) Reset() {
+	*x = WebhookDeliveryAttempt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tiger_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebhookDeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryAttempt) ProtoMessage() {}
+
+func (x *WebhookDeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_tiger_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_tiger_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WebhookDeliveryAttempt) GetId() int64 {
 	if x != nil {
-		return x.ImageData
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryAttempt) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryAttempt) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryAttempt) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryAttempt) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
+func (x *WebhookDeliveryAttempt) GetDurationMs() int32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryAttempt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 var File_tiger_proto protoreflect.FileDescriptor
 
 var file_tiger_proto_rawDesc = []byte{
@@ -649,140 +2412,469 @@ var file_tiger_proto_rawDesc = []byte{
 	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70,
 	0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
-	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x69,
-	0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x11, 0x47,
-	0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xce, 0x02, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x3e, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x69, 0x72, 0x74,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61,
+	0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x02, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61,
+	0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x42, 0x0a, 0x0f, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12,
+	0x44, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42,
+	0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x22,
+	0x60, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69,
+	0x67, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x9d, 0x01, 0x0a, 0x0b, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f,
+	0x78, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x4c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x69, 0x6e,
+	0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78,
+	0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x22, 0xe0, 0x01, 0x0a, 0x19, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69, 0x67, 0x65,
+	0x72, 0x73, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2e, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12,
+	0x2e, 0x0a, 0x03, 0x6c, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6c, 0x6e, 0x67, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x5f, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4b, 0x6d, 0x12, 0x30, 0x0a, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x47, 0x0a, 0x1a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69,
+	0x67, 0x65, 0x72, 0x73, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x61, 0x72,
+	0x62, 0x79, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x55, 0x0a,
+	0x0b, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x05,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x05, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x6b, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4b, 0x6d, 0x22, 0xa0, 0x03, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x3e, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x69, 0x72, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x42, 0x69, 0x72, 0x74, 0x68, 0x12,
+	0x4a, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x4a, 0x0a, 0x12, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x4c, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x50, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x67,
+	0x68, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x2f, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54,
+	0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x88, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x74,
+	0x69, 0x67, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x05, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x22,
+	0x3a, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x24, 0x0a, 0x12, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x2f, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x8f, 0x02, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x73, 0x65, 0x65, 0x6e, 0x46, 0x72, 0x6f, 0x6d, 0x12,
+	0x33, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65,
+	0x65, 0x6e, 0x54, 0x6f, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x52, 0x0a, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x22, 0x66, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xce, 0x02, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x42, 0x69, 0x72, 0x74, 0x68,
-	0x12, 0x4a, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x6c,
+	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53,
-	0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x4a, 0x0a, 0x12,
-	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
 	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c,
-	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e,
-	0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x4c, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74,
-	0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4c, 0x6f, 0x6e,
-	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x2f, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x69,
-	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3e,
-	0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xf1,
-	0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6e,
-	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x38, 0x0a,
-	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x6c,
-	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
-	0x74, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75,
-	0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x22, 0x32, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68,
-	0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc7, 0x03, 0x0a, 0x05, 0x54, 0x69, 0x67, 0x65, 0x72,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x66, 0x5f,
-	0x62, 0x69, 0x72, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x42,
-	0x69, 0x72, 0x74, 0x68, 0x12, 0x4a, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65,
-	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x4a, 0x04, 0x08, 0x06,
+	0x10, 0x07, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x22, 0x55, 0x0a,
+	0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x65, 0x64, 0x73, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6e, 0x65, 0x65, 0x64, 0x73, 0x52, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x22, 0x8f, 0x01, 0x0a, 0x18, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x69, 0x6d,
+	0x69, 0x6c, 0x61, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x3e, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4a, 0x0a, 0x19, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x69,
+	0x6d, 0x69, 0x6c, 0x61, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x6d,
+	0x69, 0x6c, 0x61, 0x72, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x78, 0x0a, 0x0f, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x53, 0x69, 0x67,
+	0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x69, 0x67, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69, 0x67, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x2e, 0x0a, 0x08, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69,
+	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x99, 0x04, 0x0a,
+	0x05, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x69, 0x72, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c,
-	0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x4a, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x61,
-	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
-	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74,
-	0x53, 0x65, 0x65, 0x6e, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x4c, 0x0a, 0x13,
-	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62,
-	0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65,
-	0x6e, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x22, 0xe4, 0x01, 0x0a, 0x08, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a,
-	0x07, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x65, 0x6e,
-	0x41, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x09,
-	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x64,
+	0x61, 0x74, 0x65, 0x4f, 0x66, 0x42, 0x69, 0x72, 0x74, 0x68, 0x12, 0x4a, 0x0a, 0x13, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x4a, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x65, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x4c, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x6c,
-	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67,
-	0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x32, 0xba, 0x03, 0x0a, 0x14, 0x54, 0x69, 0x67, 0x65,
-	0x72, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x57, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e,
+	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x11, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x50, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67,
+	0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xe5, 0x03, 0x0a, 0x08, 0x53, 0x69, 0x67,
+	0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c,
+	0x6e, 0x65, 0x65, 0x64, 0x73, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x6e, 0x65, 0x65, 0x64, 0x73, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12,
+	0x39, 0x0a, 0x09, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x6b, 0x6d, 0x68, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x08, 0x73, 0x70, 0x65, 0x65, 0x64, 0x4b, 0x6d, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69,
+	0x6c, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x75, 0x6d, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x75, 0x6d,
+	0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x55, 0x72, 0x6c,
+	0x22, 0x6e, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74,
+	0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78,
+	0x22, 0x96, 0x01, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x73,
+	0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x08, 0x73, 0x69, 0x67, 0x68,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7b, 0x0a, 0x0f, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x67, 0x65, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x69,
+	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x22, 0xa6, 0x01, 0x0a, 0x20, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x36, 0x0a,
+	0x08, 0x74, 0x69, 0x67, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42,
+	0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x22,
+	0x6e, 0x0a, 0x21, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22,
+	0x20, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x54, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x32, 0x0a, 0x20, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x21, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4a, 0x0a, 0x1b, 0x47, 0x65,
+	0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x54, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xe4, 0x01, 0x0a,
+	0x13, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x36, 0x0a, 0x08, 0x74, 0x69, 0x67, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x74, 0x69, 0x67, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38,
+	0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x95, 0x02, 0x0a, 0x16, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x32, 0x8a, 0x0d, 0x0a, 0x14,
+	0x54, 0x69, 0x67, 0x65, 0x72, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72,
+	0x73, 0x12, 0x1a, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
 	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65,
-	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x69, 0x67, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09,
-	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x12, 0x60, 0x0a, 0x0b, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x22, 0x09, 0x2f,
-	0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x6e, 0x0a, 0x0c, 0x47,
-	0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x69,
-	0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69,
-	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x67,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e,
-	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f, 0x7b, 0x69,
-	0x64, 0x7d, 0x2f, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x77, 0x0a, 0x0e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e,
-	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
-	0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69,
-	0x67, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e,
-	0x67, 0x3a, 0x01, 0x2a, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x69, 0x62, 0x72, 0x61, 0x68, 0x69, 0x6d, 0x6b, 0x65, 0x72, 0x2f, 0x74, 0x69,
-	0x67, 0x65, 0x72, 0x68, 0x61, 0x6c, 0x6c, 0x2d, 0x6b, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x73, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x74, 0x69, 0x67,
-	0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x12, 0x60, 0x0a,
+	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x74,
+	0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x0e, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12,
+	0x79, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x4e,
+	0x65, 0x61, 0x72, 0x62, 0x79, 0x12, 0x23, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69, 0x67, 0x65, 0x72, 0x73, 0x4e, 0x65, 0x61,
+	0x72, 0x62, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69, 0x67, 0x65,
+	0x72, 0x73, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69,
+	0x67, 0x65, 0x72, 0x3a, 0x6e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x12, 0x59, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x69, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x67, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x32, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x74,
+	0x69, 0x67, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x05, 0x74, 0x69, 0x67, 0x65, 0x72,
+	0x12, 0x62, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x12,
+	0x1c, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x69, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x10, 0x2a, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x6e, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31,
+	0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x69, 0x67, 0x68,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x77, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69,
+	0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1c, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x2f, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x3a, 0x01, 0x2a, 0x12, 0x84, 0x01,
+	0x0a, 0x11, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69,
+	0x6e, 0x67, 0x3a, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x2d, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x73, 0x3a, 0x01, 0x2a, 0x12, 0x71, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67,
+	0x68, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x67, 0x68, 0x74, 0x69, 0x6e, 0x67, 0x3a,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12, 0x8c, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2b, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x3a, 0x01, 0x2a, 0x12, 0x83, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x28, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x74,
+	0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12,
+	0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x8e, 0x01, 0x0a,
+	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e, 0x74, 0x69, 0x67,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x2a, 0x10, 0x2f, 0x76, 0x31,
+	0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x88, 0x01,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
+	0x74, 0x69, 0x67, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f,
+	0x76, 0x31, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x62, 0x72, 0x61, 0x68, 0x69, 0x6d, 0x6b, 0x65,
+	0x72, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x68, 0x61, 0x6c, 0x6c, 0x2d, 0x6b, 0x69, 0x74, 0x74,
+	0x65, 0x6e, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x74, 0x69, 0x67, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x3b, 0x74, 0x69, 0x67, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -797,53 +2889,134 @@ func file_tiger_proto_rawDescGZIP() []byte {
 	return file_tiger_proto_rawDescData
 }
 
-var file_tiger_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_tiger_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_tiger_proto_goTypes = []interface{}{
-	(*GetTigersRequest)(nil),       // 0: tiger.v1.GetTigersRequest
-	(*GetTigersResponse)(nil),      // 1: tiger.v1.GetTigersResponse
-	(*CreateTigerRequest)(nil),     // 2: tiger.v1.CreateTigerRequest
-	(*CreateTigerResponse)(nil),    // 3: tiger.v1.CreateTigerResponse
-	(*GetSightingsRequest)(nil),    // 4: tiger.v1.GetSightingsRequest
-	(*GetSightingsResponse)(nil),   // 5: tiger.v1.GetSightingsResponse
-	(*CreateSightingRequest)(nil),  // 6: tiger.v1.CreateSightingRequest
-	(*CreateSightingResponse)(nil), // 7: tiger.v1.CreateSightingResponse
-	(*Tiger)(nil),                  // 8: tiger.v1.Tiger
-	(*Sighting)(nil),               // 9: tiger.v1.Sighting
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
-	(*wrapperspb.DoubleValue)(nil), // 11: google.protobuf.DoubleValue
+	(*GetTigersRequest)(nil),                  // 0: tiger.v1.GetTigersRequest
+	(*GetTigersResponse)(nil),                 // 1: tiger.v1.GetTigersResponse
+	(*BoundingBox)(nil),                       // 2: tiger.v1.BoundingBox
+	(*SearchTigersNearbyRequest)(nil),         // 3: tiger.v1.SearchTigersNearbyRequest
+	(*SearchTigersNearbyResponse)(nil),        // 4: tiger.v1.SearchTigersNearbyResponse
+	(*NearbyTiger)(nil),                       // 5: tiger.v1.NearbyTiger
+	(*CreateTigerRequest)(nil),                // 6: tiger.v1.CreateTigerRequest
+	(*CreateTigerResponse)(nil),               // 7: tiger.v1.CreateTigerResponse
+	(*GetTigerRequest)(nil),                   // 8: tiger.v1.GetTigerRequest
+	(*GetTigerResponse)(nil),                  // 9: tiger.v1.GetTigerResponse
+	(*UpdateTigerRequest)(nil),                // 10: tiger.v1.UpdateTigerRequest
+	(*UpdateTigerResponse)(nil),               // 11: tiger.v1.UpdateTigerResponse
+	(*DeleteTigerRequest)(nil),                // 12: tiger.v1.DeleteTigerRequest
+	(*DeleteTigerResponse)(nil),               // 13: tiger.v1.DeleteTigerResponse
+	(*GetSightingsRequest)(nil),               // 14: tiger.v1.GetSightingsRequest
+	(*GetSightingsResponse)(nil),              // 15: tiger.v1.GetSightingsResponse
+	(*CreateSightingRequest)(nil),             // 16: tiger.v1.CreateSightingRequest
+	(*CreateSightingResponse)(nil),            // 17: tiger.v1.CreateSightingResponse
+	(*FindSimilarImagesRequest)(nil),          // 18: tiger.v1.FindSimilarImagesRequest
+	(*FindSimilarImagesResponse)(nil),         // 19: tiger.v1.FindSimilarImagesResponse
+	(*SimilarSighting)(nil),                   // 20: tiger.v1.SimilarSighting
+	(*Tiger)(nil),                             // 21: tiger.v1.Tiger
+	(*Sighting)(nil),                          // 22: tiger.v1.Sighting
+	(*WatchSightingsRequest)(nil),             // 23: tiger.v1.WatchSightingsRequest
+	(*WatchSightingsResponse)(nil),            // 24: tiger.v1.WatchSightingsResponse
+	(*WatchedSighting)(nil),                   // 25: tiger.v1.WatchedSighting
+	(*CreateWebhookSubscriptionRequest)(nil),  // 26: tiger.v1.CreateWebhookSubscriptionRequest
+	(*CreateWebhookSubscriptionResponse)(nil), // 27: tiger.v1.CreateWebhookSubscriptionResponse
+	(*GetWebhookSubscriptionsRequest)(nil),    // 28: tiger.v1.GetWebhookSubscriptionsRequest
+	(*GetWebhookSubscriptionsResponse)(nil),   // 29: tiger.v1.GetWebhookSubscriptionsResponse
+	(*DeleteWebhookSubscriptionRequest)(nil),  // 30: tiger.v1.DeleteWebhookSubscriptionRequest
+	(*DeleteWebhookSubscriptionResponse)(nil), // 31: tiger.v1.DeleteWebhookSubscriptionResponse
+	(*GetWebhookDeliveriesRequest)(nil),       // 32: tiger.v1.GetWebhookDeliveriesRequest
+	(*GetWebhookDeliveriesResponse)(nil),      // 33: tiger.v1.GetWebhookDeliveriesResponse
+	(*WebhookSubscription)(nil),               // 34: tiger.v1.WebhookSubscription
+	(*WebhookDeliveryAttempt)(nil),            // 35: tiger.v1.WebhookDeliveryAttempt
+	(*timestamppb.Timestamp)(nil),             // 36: google.protobuf.Timestamp
+	(*wrapperspb.DoubleValue)(nil),            // 37: google.protobuf.DoubleValue
+	(*fieldmaskpb.FieldMask)(nil),             // 38: google.protobuf.FieldMask
+	(*wrapperspb.Int32Value)(nil),             // 39: google.protobuf.Int32Value
 }
 var file_tiger_proto_depIdxs = []int32{
-	8,  // 0: tiger.v1.GetTigersResponse.data:type_name -> tiger.v1.Tiger
-	10, // 1: tiger.v1.CreateTigerRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	10, // 2: tiger.v1.CreateTigerRequest.last_seen_timestamp:type_name -> google.protobuf.Timestamp
-	11, // 3: tiger.v1.CreateTigerRequest.last_seen_latitude:type_name -> google.protobuf.DoubleValue
-	11, // 4: tiger.v1.CreateTigerRequest.last_seen_longitude:type_name -> google.protobuf.DoubleValue
-	9,  // 5: tiger.v1.GetSightingsResponse.data:type_name -> tiger.v1.Sighting
-	10, // 6: tiger.v1.CreateSightingRequest.seen_at:type_name -> google.protobuf.Timestamp
-	11, // 7: tiger.v1.CreateSightingRequest.latitude:type_name -> google.protobuf.DoubleValue
-	11, // 8: tiger.v1.CreateSightingRequest.longitude:type_name -> google.protobuf.DoubleValue
-	10, // 9: tiger.v1.Tiger.date_of_birth:type_name -> google.protobuf.Timestamp
-	10, // 10: tiger.v1.Tiger.last_seen_timestamp:type_name -> google.protobuf.Timestamp
-	11, // 11: tiger.v1.Tiger.last_seen_latitude:type_name -> google.protobuf.DoubleValue
-	11, // 12: tiger.v1.Tiger.last_seen_longitude:type_name -> google.protobuf.DoubleValue
-	10, // 13: tiger.v1.Tiger.created_at:type_name -> google.protobuf.Timestamp
-	10, // 14: tiger.v1.Tiger.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 15: tiger.v1.Sighting.seen_at:type_name -> google.protobuf.Timestamp
-	11, // 16: tiger.v1.Sighting.latitude:type_name -> google.protobuf.DoubleValue
-	11, // 17: tiger.v1.Sighting.longitude:type_name -> google.protobuf.DoubleValue
-	0,  // 18: tiger.v1.TigerSightingService.GetTigers:input_type -> tiger.v1.GetTigersRequest
-	2,  // 19: tiger.v1.TigerSightingService.CreateTiger:input_type -> tiger.v1.CreateTigerRequest
-	4,  // 20: tiger.v1.TigerSightingService.GetSightings:input_type -> tiger.v1.GetSightingsRequest
-	6,  // 21: tiger.v1.TigerSightingService.CreateSighting:input_type -> tiger.v1.CreateSightingRequest
-	1,  // 22: tiger.v1.TigerSightingService.GetTigers:output_type -> tiger.v1.GetTigersResponse
-	3,  // 23: tiger.v1.TigerSightingService.CreateTiger:output_type -> tiger.v1.CreateTigerResponse
-	5,  // 24: tiger.v1.TigerSightingService.GetSightings:output_type -> tiger.v1.GetSightingsResponse
-	7,  // 25: tiger.v1.TigerSightingService.CreateSighting:output_type -> tiger.v1.CreateSightingResponse
-	22, // [22:26] is the sub-list for method output_type
-	18, // [18:22] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	36, // 0: tiger.v1.GetTigersRequest.last_seen_after:type_name -> google.protobuf.Timestamp
+	36, // 1: tiger.v1.GetTigersRequest.last_seen_before:type_name -> google.protobuf.Timestamp
+	2,  // 2: tiger.v1.GetTigersRequest.bounding_box:type_name -> tiger.v1.BoundingBox
+	21, // 3: tiger.v1.GetTigersResponse.data:type_name -> tiger.v1.Tiger
+	37, // 4: tiger.v1.SearchTigersNearbyRequest.lat:type_name -> google.protobuf.DoubleValue
+	37, // 5: tiger.v1.SearchTigersNearbyRequest.lng:type_name -> google.protobuf.DoubleValue
+	36, // 6: tiger.v1.SearchTigersNearbyRequest.since:type_name -> google.protobuf.Timestamp
+	5,  // 7: tiger.v1.SearchTigersNearbyResponse.data:type_name -> tiger.v1.NearbyTiger
+	21, // 8: tiger.v1.NearbyTiger.tiger:type_name -> tiger.v1.Tiger
+	36, // 9: tiger.v1.CreateTigerRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	36, // 10: tiger.v1.CreateTigerRequest.last_seen_timestamp:type_name -> google.protobuf.Timestamp
+	37, // 11: tiger.v1.CreateTigerRequest.last_seen_latitude:type_name -> google.protobuf.DoubleValue
+	37, // 12: tiger.v1.CreateTigerRequest.last_seen_longitude:type_name -> google.protobuf.DoubleValue
+	37, // 13: tiger.v1.CreateTigerRequest.max_sighting_distance:type_name -> google.protobuf.DoubleValue
+	21, // 14: tiger.v1.GetTigerResponse.data:type_name -> tiger.v1.Tiger
+	21, // 15: tiger.v1.UpdateTigerRequest.tiger:type_name -> tiger.v1.Tiger
+	38, // 16: tiger.v1.UpdateTigerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	21, // 17: tiger.v1.UpdateTigerResponse.data:type_name -> tiger.v1.Tiger
+	36, // 18: tiger.v1.GetSightingsRequest.seen_from:type_name -> google.protobuf.Timestamp
+	36, // 19: tiger.v1.GetSightingsRequest.seen_to:type_name -> google.protobuf.Timestamp
+	22, // 20: tiger.v1.GetSightingsResponse.data:type_name -> tiger.v1.Sighting
+	36, // 21: tiger.v1.CreateSightingRequest.seen_at:type_name -> google.protobuf.Timestamp
+	37, // 22: tiger.v1.CreateSightingRequest.latitude:type_name -> google.protobuf.DoubleValue
+	37, // 23: tiger.v1.CreateSightingRequest.longitude:type_name -> google.protobuf.DoubleValue
+	39, // 24: tiger.v1.FindSimilarImagesRequest.max_distance:type_name -> google.protobuf.Int32Value
+	20, // 25: tiger.v1.FindSimilarImagesResponse.data:type_name -> tiger.v1.SimilarSighting
+	22, // 26: tiger.v1.SimilarSighting.sighting:type_name -> tiger.v1.Sighting
+	36, // 27: tiger.v1.Tiger.date_of_birth:type_name -> google.protobuf.Timestamp
+	36, // 28: tiger.v1.Tiger.last_seen_timestamp:type_name -> google.protobuf.Timestamp
+	37, // 29: tiger.v1.Tiger.last_seen_latitude:type_name -> google.protobuf.DoubleValue
+	37, // 30: tiger.v1.Tiger.last_seen_longitude:type_name -> google.protobuf.DoubleValue
+	36, // 31: tiger.v1.Tiger.created_at:type_name -> google.protobuf.Timestamp
+	36, // 32: tiger.v1.Tiger.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 33: tiger.v1.Tiger.max_sighting_distance:type_name -> google.protobuf.DoubleValue
+	36, // 34: tiger.v1.Sighting.seen_at:type_name -> google.protobuf.Timestamp
+	37, // 35: tiger.v1.Sighting.latitude:type_name -> google.protobuf.DoubleValue
+	37, // 36: tiger.v1.Sighting.longitude:type_name -> google.protobuf.DoubleValue
+	37, // 37: tiger.v1.Sighting.speed_kmh:type_name -> google.protobuf.DoubleValue
+	2,  // 38: tiger.v1.WatchSightingsRequest.bounding_box:type_name -> tiger.v1.BoundingBox
+	25, // 39: tiger.v1.WatchSightingsResponse.sighting:type_name -> tiger.v1.WatchedSighting
+	36, // 40: tiger.v1.WatchSightingsResponse.heartbeat:type_name -> google.protobuf.Timestamp
+	22, // 41: tiger.v1.WatchedSighting.sighting:type_name -> tiger.v1.Sighting
+	39, // 42: tiger.v1.CreateWebhookSubscriptionRequest.tiger_id:type_name -> google.protobuf.Int32Value
+	2,  // 43: tiger.v1.CreateWebhookSubscriptionRequest.bounding_box:type_name -> tiger.v1.BoundingBox
+	34, // 44: tiger.v1.CreateWebhookSubscriptionResponse.data:type_name -> tiger.v1.WebhookSubscription
+	34, // 45: tiger.v1.GetWebhookSubscriptionsResponse.data:type_name -> tiger.v1.WebhookSubscription
+	35, // 46: tiger.v1.GetWebhookDeliveriesResponse.data:type_name -> tiger.v1.WebhookDeliveryAttempt
+	39, // 47: tiger.v1.WebhookSubscription.tiger_id:type_name -> google.protobuf.Int32Value
+	2,  // 48: tiger.v1.WebhookSubscription.bounding_box:type_name -> tiger.v1.BoundingBox
+	36, // 49: tiger.v1.WebhookSubscription.created_at:type_name -> google.protobuf.Timestamp
+	36, // 50: tiger.v1.WebhookDeliveryAttempt.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 51: tiger.v1.TigerSightingService.GetTigers:input_type -> tiger.v1.GetTigersRequest
+	6,  // 52: tiger.v1.TigerSightingService.CreateTiger:input_type -> tiger.v1.CreateTigerRequest
+	3,  // 53: tiger.v1.TigerSightingService.SearchTigersNearby:input_type -> tiger.v1.SearchTigersNearbyRequest
+	8,  // 54: tiger.v1.TigerSightingService.GetTiger:input_type -> tiger.v1.GetTigerRequest
+	10, // 55: tiger.v1.TigerSightingService.UpdateTiger:input_type -> tiger.v1.UpdateTigerRequest
+	12, // 56: tiger.v1.TigerSightingService.DeleteTiger:input_type -> tiger.v1.DeleteTigerRequest
+	14, // 57: tiger.v1.TigerSightingService.GetSightings:input_type -> tiger.v1.GetSightingsRequest
+	16, // 58: tiger.v1.TigerSightingService.CreateSighting:input_type -> tiger.v1.CreateSightingRequest
+	18, // 59: tiger.v1.TigerSightingService.FindSimilarImages:input_type -> tiger.v1.FindSimilarImagesRequest
+	23, // 60: tiger.v1.TigerSightingService.WatchSightings:input_type -> tiger.v1.WatchSightingsRequest
+	26, // 61: tiger.v1.TigerSightingService.CreateWebhookSubscription:input_type -> tiger.v1.CreateWebhookSubscriptionRequest
+	28, // 62: tiger.v1.TigerSightingService.GetWebhookSubscriptions:input_type -> tiger.v1.GetWebhookSubscriptionsRequest
+	30, // 63: tiger.v1.TigerSightingService.DeleteWebhookSubscription:input_type -> tiger.v1.DeleteWebhookSubscriptionRequest
+	32, // 64: tiger.v1.TigerSightingService.GetWebhookDeliveries:input_type -> tiger.v1.GetWebhookDeliveriesRequest
+	1,  // 65: tiger.v1.TigerSightingService.GetTigers:output_type -> tiger.v1.GetTigersResponse
+	7,  // 66: tiger.v1.TigerSightingService.CreateTiger:output_type -> tiger.v1.CreateTigerResponse
+	4,  // 67: tiger.v1.TigerSightingService.SearchTigersNearby:output_type -> tiger.v1.SearchTigersNearbyResponse
+	9,  // 68: tiger.v1.TigerSightingService.GetTiger:output_type -> tiger.v1.GetTigerResponse
+	11, // 69: tiger.v1.TigerSightingService.UpdateTiger:output_type -> tiger.v1.UpdateTigerResponse
+	13, // 70: tiger.v1.TigerSightingService.DeleteTiger:output_type -> tiger.v1.DeleteTigerResponse
+	15, // 71: tiger.v1.TigerSightingService.GetSightings:output_type -> tiger.v1.GetSightingsResponse
+	17, // 72: tiger.v1.TigerSightingService.CreateSighting:output_type -> tiger.v1.CreateSightingResponse
+	19, // 73: tiger.v1.TigerSightingService.FindSimilarImages:output_type -> tiger.v1.FindSimilarImagesResponse
+	24, // 74: tiger.v1.TigerSightingService.WatchSightings:output_type -> tiger.v1.WatchSightingsResponse
+	27, // 75: tiger.v1.TigerSightingService.CreateWebhookSubscription:output_type -> tiger.v1.CreateWebhookSubscriptionResponse
+	29, // 76: tiger.v1.TigerSightingService.GetWebhookSubscriptions:output_type -> tiger.v1.GetWebhookSubscriptionsResponse
+	31, // 77: tiger.v1.TigerSightingService.DeleteWebhookSubscription:output_type -> tiger.v1.DeleteWebhookSubscriptionResponse
+	33, // 78: tiger.v1.TigerSightingService.GetWebhookDeliveries:output_type -> tiger.v1.GetWebhookDeliveriesResponse
+	65, // [65:79] is the sub-list for method output_type
+	51, // [51:65] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_tiger_proto_init() }
@@ -864,8 +3037,44 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTigersResponse); i {
+		file_tiger_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTigersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoundingBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTigersNearbyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchTigersNearbyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,7 +3085,19 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NearbyTiger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateTigerRequest); i {
 			case 0:
 				return &v.state
@@ -888,7 +3109,7 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateTigerResponse); i {
 			case 0:
 				return &v.state
@@ -900,7 +3121,79 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTigerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTigerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTigerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTigerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTigerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTigerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSightingsRequest); i {
 			case 0:
 				return &v.state
@@ -912,7 +3205,7 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetSightingsResponse); i {
 			case 0:
 				return &v.state
@@ -924,7 +3217,7 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateSightingRequest); i {
 			case 0:
 				return &v.state
@@ -936,7 +3229,7 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateSightingResponse); i {
 			case 0:
 				return &v.state
@@ -948,7 +3241,43 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindSimilarImagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindSimilarImagesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimilarSighting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Tiger); i {
 			case 0:
 				return &v.state
@@ -960,7 +3289,7 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
-		file_tiger_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_tiger_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Sighting); i {
 			case 0:
 				return &v.state
@@ -972,6 +3301,166 @@ func file_tiger_proto_init() {
 				return nil
 			}
 		}
+		file_tiger_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSightingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSightingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchedSighting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateWebhookSubscriptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateWebhookSubscriptionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWebhookSubscriptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWebhookSubscriptionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWebhookSubscriptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWebhookSubscriptionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWebhookDeliveriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWebhookDeliveriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookSubscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tiger_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookDeliveryAttempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_tiger_proto_msgTypes[24].OneofWrappers = []interface{}{
+		(*WatchSightingsResponse_Sighting)(nil),
+		(*WatchSightingsResponse_Heartbeat)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -979,7 +3468,7 @@ func file_tiger_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tiger_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,10 +27,36 @@ type TigerSightingServiceClient interface {
 	GetTigers(ctx context.Context, in *GetTigersRequest, opts ...grpc.CallOption) (*GetTigersResponse, error)
 	// CreateTiger API create a new tiger in database
 	CreateTiger(ctx context.Context, in *CreateTigerRequest, opts ...grpc.CallOption) (*CreateTigerResponse, error)
+	// SearchTigersNearby API retrieve tigers last seen within radius_km of a point, nearest first
+	SearchTigersNearby(ctx context.Context, in *SearchTigersNearbyRequest, opts ...grpc.CallOption) (*SearchTigersNearbyResponse, error)
+	// GetTiger API retrieve a tiger for given ID from database
+	GetTiger(ctx context.Context, in *GetTigerRequest, opts ...grpc.CallOption) (*GetTigerResponse, error)
+	// UpdateTiger API update name, date of birth and/or max sighting distance of a tiger for given ID in database.
+	// Only fields listed in update_mask are updated. Name and date of birth are updated if update_mask is empty.
+	UpdateTiger(ctx context.Context, in *UpdateTigerRequest, opts ...grpc.CallOption) (*UpdateTigerResponse, error)
+	// DeleteTiger API soft delete a tiger for given ID in database
+	DeleteTiger(ctx context.Context, in *DeleteTigerRequest, opts ...grpc.CallOption) (*DeleteTigerResponse, error)
 	// GetSightings API retrieve sightings data for given tiger ID from database
 	GetSightings(ctx context.Context, in *GetSightingsRequest, opts ...grpc.CallOption) (*GetSightingsResponse, error)
 	// CreateSighting API create a new sighting for given tiger ID in database
 	CreateSighting(ctx context.Context, in *CreateSightingRequest, opts ...grpc.CallOption) (*CreateSightingResponse, error)
+	// FindSimilarImages API retrieve sightings of any tiger whose image is a near-duplicate of an image, most similar first.
+	// POST since the image is sent in the body.
+	FindSimilarImages(ctx context.Context, in *FindSimilarImagesRequest, opts ...grpc.CallOption) (*FindSimilarImagesResponse, error)
+	// WatchSightings API stream new sightings, optionally only of some tigers and/or inside a bounding box.
+	// A heartbeat is sent when the watch starts and whenever no sighting was sent for a while.
+	// REST clients sending "Accept: text/event-stream" receive the stream as Server-Sent Events.
+	WatchSightings(ctx context.Context, in *WatchSightingsRequest, opts ...grpc.CallOption) (TigerSightingService_WatchSightingsClient, error)
+	// CreateWebhookSubscription API subscribe an URL to new sightings, optionally only of a tiger and/or inside a bounding box.
+	// The response contains the secret signing the deliveries. It is never returned again.
+	CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*CreateWebhookSubscriptionResponse, error)
+	// GetWebhookSubscriptions API retrieve webhook subscriptions from database
+	GetWebhookSubscriptions(ctx context.Context, in *GetWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*GetWebhookSubscriptionsResponse, error)
+	// DeleteWebhookSubscription API soft delete a webhook subscription for given ID in database.
+	// Pending deliveries of the subscription are dropped.
+	DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error)
+	// GetWebhookDeliveries API retrieve delivery attempts of a webhook subscription for given ID, latest first
+	GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error)
 }
 
 type tigerSightingServiceClient struct {
@@ -59,6 +85,42 @@ func (c *tigerSightingServiceClient) CreateTiger(ctx context.Context, in *Create
 	return out, nil
 }
 
+func (c *tigerSightingServiceClient) SearchTigersNearby(ctx context.Context, in *SearchTigersNearbyRequest, opts ...grpc.CallOption) (*SearchTigersNearbyResponse, error) {
+	out := new(SearchTigersNearbyResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/SearchTigersNearby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) GetTiger(ctx context.Context, in *GetTigerRequest, opts ...grpc.CallOption) (*GetTigerResponse, error) {
+	out := new(GetTigerResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/GetTiger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) UpdateTiger(ctx context.Context, in *UpdateTigerRequest, opts ...grpc.CallOption) (*UpdateTigerResponse, error) {
+	out := new(UpdateTigerResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/UpdateTiger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) DeleteTiger(ctx context.Context, in *DeleteTigerRequest, opts ...grpc.CallOption) (*DeleteTigerResponse, error) {
+	out := new(DeleteTigerResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/DeleteTiger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tigerSightingServiceClient) GetSightings(ctx context.Context, in *GetSightingsRequest, opts ...grpc.CallOption) (*GetSightingsResponse, error) {
 	out := new(GetSightingsResponse)
 	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/GetSightings", in, out, opts...)
@@ -77,6 +139,83 @@ func (c *tigerSightingServiceClient) CreateSighting(ctx context.Context, in *Cre
 	return out, nil
 }
 
+func (c *tigerSightingServiceClient) FindSimilarImages(ctx context.Context, in *FindSimilarImagesRequest, opts ...grpc.CallOption) (*FindSimilarImagesResponse, error) {
+	out := new(FindSimilarImagesResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/FindSimilarImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) WatchSightings(ctx context.Context, in *WatchSightingsRequest, opts ...grpc.CallOption) (TigerSightingService_WatchSightingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TigerSightingService_ServiceDesc.Streams[0], "/tiger.v1.TigerSightingService/WatchSightings", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tigerSightingServiceWatchSightingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TigerSightingService_WatchSightingsClient interface {
+	Recv() (*WatchSightingsResponse, error)
+	grpc.ClientStream
+}
+
+type tigerSightingServiceWatchSightingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tigerSightingServiceWatchSightingsClient) Recv() (*WatchSightingsResponse, error) {
+	m := new(WatchSightingsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tigerSightingServiceClient) CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*CreateWebhookSubscriptionResponse, error) {
+	out := new(CreateWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/CreateWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) GetWebhookSubscriptions(ctx context.Context, in *GetWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*GetWebhookSubscriptionsResponse, error) {
+	out := new(GetWebhookSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/GetWebhookSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error) {
+	out := new(DeleteWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/DeleteWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tigerSightingServiceClient) GetWebhookDeliveries(ctx context.Context, in *GetWebhookDeliveriesRequest, opts ...grpc.CallOption) (*GetWebhookDeliveriesResponse, error) {
+	out := new(GetWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/tiger.v1.TigerSightingService/GetWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TigerSightingServiceServer is the server API for TigerSightingService service.
 // All implementations should embed UnimplementedTigerSightingServiceServer
 // for forward compatibility
@@ -85,10 +224,36 @@ type TigerSightingServiceServer interface {
 	GetTigers(context.Context, *GetTigersRequest) (*GetTigersResponse, error)
 	// CreateTiger API create a new tiger in database
 	CreateTiger(context.Context, *CreateTigerRequest) (*CreateTigerResponse, error)
+	// SearchTigersNearby API retrieve tigers last seen within radius_km of a point, nearest first
+	SearchTigersNearby(context.Context, *SearchTigersNearbyRequest) (*SearchTigersNearbyResponse, error)
+	// GetTiger API retrieve a tiger for given ID from database
+	GetTiger(context.Context, *GetTigerRequest) (*GetTigerResponse, error)
+	// UpdateTiger API update name, date of birth and/or max sighting distance of a tiger for given ID in database.
+	// Only fields listed in update_mask are updated. Name and date of birth are updated if update_mask is empty.
+	UpdateTiger(context.Context, *UpdateTigerRequest) (*UpdateTigerResponse, error)
+	// DeleteTiger API soft delete a tiger for given ID in database
+	DeleteTiger(context.Context, *DeleteTigerRequest) (*DeleteTigerResponse, error)
 	// GetSightings API retrieve sightings data for given tiger ID from database
 	GetSightings(context.Context, *GetSightingsRequest) (*GetSightingsResponse, error)
 	// CreateSighting API create a new sighting for given tiger ID in database
 	CreateSighting(context.Context, *CreateSightingRequest) (*CreateSightingResponse, error)
+	// FindSimilarImages API retrieve sightings of any tiger whose image is a near-duplicate of an image, most similar first.
+	// POST since the image is sent in the body.
+	FindSimilarImages(context.Context, *FindSimilarImagesRequest) (*FindSimilarImagesResponse, error)
+	// WatchSightings API stream new sightings, optionally only of some tigers and/or inside a bounding box.
+	// A heartbeat is sent when the watch starts and whenever no sighting was sent for a while.
+	// REST clients sending "Accept: text/event-stream" receive the stream as Server-Sent Events.
+	WatchSightings(*WatchSightingsRequest, TigerSightingService_WatchSightingsServer) error
+	// CreateWebhookSubscription API subscribe an URL to new sightings, optionally only of a tiger and/or inside a bounding box.
+	// The response contains the secret signing the deliveries. It is never returned again.
+	CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error)
+	// GetWebhookSubscriptions API retrieve webhook subscriptions from database
+	GetWebhookSubscriptions(context.Context, *GetWebhookSubscriptionsRequest) (*GetWebhookSubscriptionsResponse, error)
+	// DeleteWebhookSubscription API soft delete a webhook subscription for given ID in database.
+	// Pending deliveries of the subscription are dropped.
+	DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error)
+	// GetWebhookDeliveries API retrieve delivery attempts of a webhook subscription for given ID, latest first
+	GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error)
 }
 
 // UnimplementedTigerSightingServiceServer should be embedded to have forward compatible implementations.
@@ -101,12 +266,42 @@ func (UnimplementedTigerSightingServiceServer) GetTigers(context.Context, *GetTi
 func (UnimplementedTigerSightingServiceServer) CreateTiger(context.Context, *CreateTigerRequest) (*CreateTigerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTiger not implemented")
 }
+func (UnimplementedTigerSightingServiceServer) SearchTigersNearby(context.Context, *SearchTigersNearbyRequest) (*SearchTigersNearbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTigersNearby not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) GetTiger(context.Context, *GetTigerRequest) (*GetTigerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTiger not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) UpdateTiger(context.Context, *UpdateTigerRequest) (*UpdateTigerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTiger not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) DeleteTiger(context.Context, *DeleteTigerRequest) (*DeleteTigerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTiger not implemented")
+}
 func (UnimplementedTigerSightingServiceServer) GetSightings(context.Context, *GetSightingsRequest) (*GetSightingsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSightings not implemented")
 }
 func (UnimplementedTigerSightingServiceServer) CreateSighting(context.Context, *CreateSightingRequest) (*CreateSightingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSighting not implemented")
 }
+func (UnimplementedTigerSightingServiceServer) FindSimilarImages(context.Context, *FindSimilarImagesRequest) (*FindSimilarImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilarImages not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) WatchSightings(*WatchSightingsRequest, TigerSightingService_WatchSightingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSightings not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*CreateWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookSubscription not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) GetWebhookSubscriptions(context.Context, *GetWebhookSubscriptionsRequest) (*GetWebhookSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookSubscriptions not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookSubscription not implemented")
+}
+func (UnimplementedTigerSightingServiceServer) GetWebhookDeliveries(context.Context, *GetWebhookDeliveriesRequest) (*GetWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
 
 // UnsafeTigerSightingServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TigerSightingServiceServer will
@@ -155,6 +350,78 @@ func _TigerSightingService_CreateTiger_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TigerSightingService_SearchTigersNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTigersNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).SearchTigersNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/SearchTigersNearby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).SearchTigersNearby(ctx, req.(*SearchTigersNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_GetTiger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTigerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).GetTiger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/GetTiger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).GetTiger(ctx, req.(*GetTigerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_UpdateTiger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTigerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).UpdateTiger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/UpdateTiger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).UpdateTiger(ctx, req.(*UpdateTigerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_DeleteTiger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTigerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).DeleteTiger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/DeleteTiger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).DeleteTiger(ctx, req.(*DeleteTigerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TigerSightingService_GetSightings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSightingsRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +458,117 @@ func _TigerSightingService_CreateSighting_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TigerSightingService_FindSimilarImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).FindSimilarImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/FindSimilarImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).FindSimilarImages(ctx, req.(*FindSimilarImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_WatchSightings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSightingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TigerSightingServiceServer).WatchSightings(m, &tigerSightingServiceWatchSightingsServer{stream})
+}
+
+type TigerSightingService_WatchSightingsServer interface {
+	Send(*WatchSightingsResponse) error
+	grpc.ServerStream
+}
+
+type tigerSightingServiceWatchSightingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tigerSightingServiceWatchSightingsServer) Send(m *WatchSightingsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TigerSightingService_CreateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).CreateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/CreateWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).CreateWebhookSubscription(ctx, req.(*CreateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_GetWebhookSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).GetWebhookSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/GetWebhookSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).GetWebhookSubscriptions(ctx, req.(*GetWebhookSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_DeleteWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).DeleteWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/DeleteWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).DeleteWebhookSubscription(ctx, req.(*DeleteWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TigerSightingService_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TigerSightingServiceServer).GetWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tiger.v1.TigerSightingService/GetWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TigerSightingServiceServer).GetWebhookDeliveries(ctx, req.(*GetWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TigerSightingService_ServiceDesc is the grpc.ServiceDesc for TigerSightingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -206,6 +584,22 @@ var TigerSightingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTiger",
 			Handler:    _TigerSightingService_CreateTiger_Handler,
 		},
+		{
+			MethodName: "SearchTigersNearby",
+			Handler:    _TigerSightingService_SearchTigersNearby_Handler,
+		},
+		{
+			MethodName: "GetTiger",
+			Handler:    _TigerSightingService_GetTiger_Handler,
+		},
+		{
+			MethodName: "UpdateTiger",
+			Handler:    _TigerSightingService_UpdateTiger_Handler,
+		},
+		{
+			MethodName: "DeleteTiger",
+			Handler:    _TigerSightingService_DeleteTiger_Handler,
+		},
 		{
 			MethodName: "GetSightings",
 			Handler:    _TigerSightingService_GetSightings_Handler,
@@ -214,7 +608,33 @@ var TigerSightingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSighting",
 			Handler:    _TigerSightingService_CreateSighting_Handler,
 		},
+		{
+			MethodName: "FindSimilarImages",
+			Handler:    _TigerSightingService_FindSimilarImages_Handler,
+		},
+		{
+			MethodName: "CreateWebhookSubscription",
+			Handler:    _TigerSightingService_CreateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "GetWebhookSubscriptions",
+			Handler:    _TigerSightingService_GetWebhookSubscriptions_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookSubscription",
+			Handler:    _TigerSightingService_DeleteWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "GetWebhookDeliveries",
+			Handler:    _TigerSightingService_GetWebhookDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSightings",
+			Handler:       _TigerSightingService_WatchSightings_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tiger.proto",
 }
@@ -19,6 +19,8 @@ fi
 
 if [ "$APP_NAME" = "tigerhall-kittens-server" ]; then
   ./tigerhall-kittens-server
+elif [ "$APP_NAME" = "tigerhall-kittens-worker" ]; then
+  ./tigerhall-kittens-worker
 else
   echo "unknown APP_NAME to start:" $APP_NAME
 fi
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/common/postgres"
+	sightingv1 "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1"
+)
+
+// migrate-images moves the sighting images stored in database as base64 to the configured image store.
+// It is safe to run again, e.g. after it was interrupted, and while the server is running.
+func main() {
+	batchSize := flag.Int("batch-size", 100, "number of images read from database at once")
+	flag.Parse()
+
+	cfg, cerr := config.NewConfig(".env")
+	if cerr != nil {
+		log.Fatal(cerr)
+	}
+
+	logger := logging.NewLogger().WithFields(logrus.Fields{
+		"service": cfg.ServiceName,
+		"version": 1,
+	})
+	logger.WithField("image_store", cfg.ImageStore.Backend).Info("Moving sighting images to the image store")
+
+	pgpool, perr := postgres.NewPool(&cfg.Postgres)
+	if perr != nil {
+		log.Fatal(perr)
+	}
+	defer pgpool.Close()
+
+	// the images moved before SIGINT or SIGTERM stay moved, run again to move the rest
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	moved, err := sightingv1.MigrateImages(ctx, cfg, pgpool, int32(*batchSize))
+	if err != nil {
+		logging.WithError(err, logger).WithField("moved", moved).Fatal("Moving sighting images failed")
+	}
+	logger.WithField("moved", moved).Info("Sighting images moved")
+}
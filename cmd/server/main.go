@@ -42,7 +42,7 @@ func main() {
 	registerGrpcHandlers(grpcServer.Server, cfg, pgpool, rds, logger)
 
 	restServer := createRestServer(cfg.Port.REST, cfg)
-	registerRestHandlers(context.Background(), restServer.ServeMux, fmt.Sprintf(":%s", cfg.Port.GRPC), logger, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	registerRestHandlers(context.Background(), restServer.ServeMux, cfg, pgpool, logger, grpc.WithTransportCredentials(insecure.NewCredentials()))
 
 	healthcheck.RegisterHealthHandler(grpcServer.Server)
 
@@ -72,7 +72,7 @@ func initLogger(cfg *config.Config) *logrus.Entry {
 
 func createGrpcServer(cfg *config.Config, logger *logrus.Entry) *server.Grpc {
 	if cfg.Env == envDevelopment {
-		return server.NewDevelopmentGrpc(cfg.Port.GRPC, logger)
+		return server.NewDevelopmentGrpc(cfg.Port.GRPC, logger, server.MaxRecvMsgSize(cfg.ImageLimits))
 	}
 	srv, err := server.NewProductionGrpc(cfg, logger)
 	checkError(cfg, err)
@@ -91,11 +91,14 @@ func registerGrpcHandlers(server *grpc.Server, cfg *config.Config, pgpool *pgxpo
 	// end of register all module's gRPC handlers
 }
 
-func registerRestHandlers(ctx context.Context, server *runtime.ServeMux, grpcPort string, logger *logrus.Entry, options ...grpc.DialOption) {
+func registerRestHandlers(ctx context.Context, server *runtime.ServeMux, cfg *config.Config, pgpool *pgxpool.Pool,
+	logger *logrus.Entry, options ...grpc.DialOption) {
 	// start register all module's REST handlers
+	grpcPort := fmt.Sprintf(":%s", cfg.Port.GRPC)
 	options = append(options, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize)))
 	// examplev2.InitRest(ctx, server, grpcPort, options...)
 	sightingv1.InitRest(ctx, server, grpcPort, logger, options...)
+	sightingv1.InitImageRest(server, cfg, pgpool, logger)
 	// end of register all module's REST handlers
 }
 
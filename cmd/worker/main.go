@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/common/postgres"
+	"github.com/ibrahimker/tigerhall-kittens/common/redis"
+	sightingv1 "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+const (
+	metricsShutdownTimeout = 5 * time.Second
+)
+
+func main() {
+	cfg, cerr := config.NewConfig(".env")
+	checkError(cfg, cerr)
+
+	logger := initLogger(cfg)
+	logger.Info("Initiating tigerhall-kittens worker")
+
+	pgpool, perr := postgres.NewPool(&cfg.Postgres)
+	checkError(cfg, perr)
+	rds, rerr := redis.NewClient(&cfg.Redis)
+	checkError(cfg, rerr)
+
+	w := worker.NewWorker(createQueue(cfg, pgpool, rds), logger, &cfg.Worker)
+	registerJobs(w, cfg, pgpool, rds, logger)
+
+	metricsServer := runMetricsServer(cfg.Port.Metrics, logger)
+
+	// the worker stops taking jobs on SIGINT or SIGTERM and waits for the running ones
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	w.Run(ctx)
+	logger.Info("Worker stopped")
+
+	sctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+	_ = metricsServer.Shutdown(sctx)
+}
+
+func initLogger(cfg *config.Config) *logrus.Entry {
+	l := logging.NewLogger()
+	var logLevel logrus.Level
+
+	env := cfg.Env
+	switch env {
+	case "production":
+		logLevel = logrus.InfoLevel
+	default:
+		logLevel = logrus.DebugLevel
+	}
+
+	l.SetLevel(logLevel)
+	return l.WithFields(logrus.Fields{
+		"service": cfg.ServiceName,
+		"version": 1,
+	})
+}
+
+func createQueue(cfg *config.Config, pgpool *pgxpool.Pool, rds *goredis.Client) worker.Queue {
+	if cfg.Worker.Queue == config.QueuePostgres {
+		return worker.NewPostgresQueue(pgpool, cfg.Worker.PollInterval, cfg.Worker.Lease)
+	}
+	return worker.NewRedisQueue(rds, cfg.Worker.PollInterval, cfg.Worker.Lease)
+}
+
+func registerJobs(w *worker.Worker, cfg *config.Config, pgpool *pgxpool.Pool, rds *goredis.Client, logger *logrus.Entry) {
+	// start register all module's jobs
+
+	sightingv1.InitWorker(w, cfg, pgpool, rds, logger)
+	// end of register all module's jobs
+}
+
+func runMetricsServer(port string, logger *logrus.Entry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: fmt.Sprintf(":%s", port), Handler: mux}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.WithError(err, logger).Error("Metrics server stopped")
+		}
+	}()
+	log.Printf("metrics server is running on port %s\n", port)
+	return srv
+}
+
+func checkError(cfg *config.Config, err error) {
+	if err != nil {
+		if cfg.IsDevelopment() {
+			log.Printf("Error %+v", err)
+		} else {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	// EnvDevelopment is the value of ENV for local development.
+	EnvDevelopment = "development"
+	// EnvProduction is the value of ENV for production.
+	EnvProduction = "production"
+)
+
+const (
+	// QueueRedis stores jobs in Redis lists.
+	QueueRedis = "redis"
+	// QueuePostgres stores jobs in a PostgreSQL table claimed with SKIP LOCKED.
+	QueuePostgres = "postgres"
+)
+
+const (
+	// PolicyReject rejects a sighting breaking a rule, e.g. farther than the maximum distance.
+	PolicyReject = "reject"
+	// PolicyReview accepts a sighting breaking a rule but flags it as needs review.
+	PolicyReview = "review"
+)
+
+// MaxDuplicateImageDistance is the maximum of SIGHTING_DUPLICATE_IMAGE_DISTANCE.
+// Perceptual hashes of images are indexed in 8 bands of 8 bits, only hashes differing by at most 7 bits surely share one.
+const MaxDuplicateImageDistance = 7
+
+const (
+	// ImageStoreLocal stores sighting images in a directory of the local filesystem.
+	ImageStoreLocal = "local"
+	// ImageStoreS3 stores sighting images in a bucket of an S3 compatible storage, e.g. AWS S3 or MinIO.
+	ImageStoreS3 = "s3"
+)
+
+const (
+	// ImageFit scales an image down to fit in a rendition, keeping its aspect ratio.
+	ImageFit = "fit"
+	// ImageFill scales an image down to cover a rendition, cropping its center to the aspect ratio of the rendition.
+	ImageFill = "fill"
+)
+
+// Config holds the whole application configuration.
+type Config struct {
+	Env             string `env:"ENV,default=development"`
+	ServiceName     string `env:"SERVICE_NAME,required"`
+	Port            Port
+	Hashid          Hashid
+	Postgres        Postgres
+	Redis           Redis
+	Sighting        Sighting
+	ImageStore      ImageStore
+	ImageLimits     ImageLimits
+	ImageRenditions ImageRenditions
+	S3              S3
+	SMTP            SMTP
+	Notification    Notification
+	Webhook         Webhook
+	Outbox          Outbox
+	Worker          Worker
+}
+
+// Port holds the ports the servers listen on.
+type Port struct {
+	GRPC string `env:"PORT_GRPC,default=8080"`
+	REST string `env:"PORT_REST,default=8081"`
+	// Metrics is the port of the worker serving Prometheus metrics.
+	Metrics string `env:"PORT_METRICS,default=9090"`
+}
+
+// Hashid holds the configuration to encode and decode hash ids.
+type Hashid struct {
+	Salt      string `env:"HASHID_SALT,required"`
+	MinLength int    `env:"HASHID_MIN_LENGTH,default=10"`
+}
+
+// Postgres holds the configuration of PostgreSQL connection pool.
+type Postgres struct {
+	Host            string        `env:"POSTGRES_HOST,required"`
+	Port            string        `env:"POSTGRES_PORT,default=5432"`
+	User            string        `env:"POSTGRES_USER,required"`
+	Password        string        `env:"POSTGRES_PASSWORD,required"`
+	Name            string        `env:"POSTGRES_NAME,required"`
+	MaxOpenConns    int           `env:"POSTGRES_MAX_OPEN_CONNS,default=50"`
+	MaxConnLifetime time.Duration `env:"POSTGRES_MAX_CONN_LIFETIME,default=10m"`
+	MaxIdleLifetime time.Duration `env:"POSTGRES_MAX_IDLE_LIFETIME,default=5m"`
+}
+
+// Redis holds the configuration of Redis connection.
+type Redis struct {
+	Address string `env:"REDIS_ADDRESS,required"`
+	// ToggleTTL is the lifetime of cached toggles, in minutes.
+	ToggleTTL int `env:"TOGGLE_REDIS_TTL,default=5"`
+}
+
+// ImageStore holds the configuration of the storage of sighting images.
+type ImageStore struct {
+	// Backend is either local or s3, see ImageStoreLocal and ImageStoreS3. The s3 backend is configured by S3.
+	Backend string `env:"IMAGE_STORE,default=local"`
+	// Dir is the directory the local backend stores images in. It is created if it doesn't exist.
+	Dir string `env:"IMAGE_STORE_DIR,default=data/images"`
+}
+
+// ImageLimits holds the limits of the sighting images sent by reporters.
+// The dimensions are read from the image header before it is decoded, so that a small file can't exhaust memory.
+type ImageLimits struct {
+	// MaxBytes is the maximum size of an image, once base64 decoded.
+	MaxBytes  int `env:"IMAGE_MAX_BYTES,default=10485760"`
+	MaxWidth  int `env:"IMAGE_MAX_WIDTH,default=8192"`
+	MaxHeight int `env:"IMAGE_MAX_HEIGHT,default=8192"`
+}
+
+// ImageRenditions holds the sizes, in pixels, of the renditions stored next to the original sighting images.
+// Each mode is either fit or fill, see ImageFit and ImageFill. Images are never scaled up.
+type ImageRenditions struct {
+	ThumbnailWidth  int    `env:"IMAGE_THUMBNAIL_WIDTH,default=100"`
+	ThumbnailHeight int    `env:"IMAGE_THUMBNAIL_HEIGHT,default=80"`
+	ThumbnailMode   string `env:"IMAGE_THUMBNAIL_MODE,default=fill"`
+	MediumWidth     int    `env:"IMAGE_MEDIUM_WIDTH,default=250"`
+	MediumHeight    int    `env:"IMAGE_MEDIUM_HEIGHT,default=200"`
+	MediumMode      string `env:"IMAGE_MEDIUM_MODE,default=fit"`
+	LargeWidth      int    `env:"IMAGE_LARGE_WIDTH,default=1024"`
+	LargeHeight     int    `env:"IMAGE_LARGE_HEIGHT,default=768"`
+	LargeMode       string `env:"IMAGE_LARGE_MODE,default=fit"`
+}
+
+// S3 holds the configuration of the S3 compatible storage, used when ImageStore.Backend is s3.
+// Objects are addressed path style, i.e. <Endpoint>/<Bucket>/<key>, which every S3 compatible storage supports.
+type S3 struct {
+	// Endpoint is the URL of the storage, e.g. https://s3.ap-southeast-1.amazonaws.com or http://localhost:9000.
+	Endpoint  string        `env:"S3_ENDPOINT"`
+	Region    string        `env:"S3_REGION,default=us-east-1"`
+	Bucket    string        `env:"S3_BUCKET"`
+	AccessKey string        `env:"S3_ACCESS_KEY"`
+	SecretKey string        `env:"S3_SECRET_KEY"`
+	Timeout   time.Duration `env:"S3_TIMEOUT,default=10s"`
+}
+
+// SMTP holds the configuration of the SMTP server used to send emails.
+// Username and Password are optional, no authentication is done if Username is empty.
+type SMTP struct {
+	Host     string        `env:"SMTP_HOST,default=localhost"`
+	Port     string        `env:"SMTP_PORT,default=1025"`
+	Username string        `env:"SMTP_USERNAME"`
+	Password string        `env:"SMTP_PASSWORD"`
+	From     string        `env:"SMTP_FROM,default=noreply@tigerhall-kittens.local"`
+	Timeout  time.Duration `env:"SMTP_TIMEOUT,default=10s"`
+}
+
+// Notification holds the configuration of the jobs delivering notifications.
+type Notification struct {
+	// Workers is the number of notifications sent concurrently by each worker process.
+	Workers int `env:"NOTIFICATION_WORKERS,default=4"`
+}
+
+// Webhook holds the configuration of the jobs delivering webhook events.
+// Failed deliveries are retried with the backoff of the worker.
+type Webhook struct {
+	// Timeout is how long a delivery waits for the response of the receiver.
+	Timeout time.Duration `env:"WEBHOOK_TIMEOUT,default=10s"`
+	// Workers is the number of webhook events delivered concurrently by each worker process.
+	Workers int `env:"WEBHOOK_WORKERS,default=4"`
+	// AllowPrivateNetworks lets subscriptions target loopback, private and link-local addresses.
+	// It is meant for local development only, since it lets subscribers reach the internal network.
+	AllowPrivateNetworks bool `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS,default=false"`
+}
+
+// Outbox holds the configuration of the relay dispatching outbox events to their handlers.
+type Outbox struct {
+	// PollInterval is how long the relay waits before looking for new events once the outbox is drained.
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL,default=1s"`
+	// BatchSize is the maximum number of events claimed at once.
+	BatchSize int `env:"OUTBOX_BATCH_SIZE,default=100"`
+	// Lease is how long claimed events are hidden from other relays while they are dispatched.
+	Lease time.Duration `env:"OUTBOX_LEASE,default=1m"`
+	// MaxAttempts is the number of dispatches of an event after which it is moved to the dead letter.
+	MaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS,default=10"`
+	// MinBackoff is the delay before retrying an event after its first failure. The delay doubles after each failure
+	// up to MaxBackoff.
+	MinBackoff time.Duration `env:"OUTBOX_MIN_BACKOFF,default=1s"`
+	MaxBackoff time.Duration `env:"OUTBOX_MAX_BACKOFF,default=5m"`
+}
+
+// Worker holds the configuration of the background job worker.
+type Worker struct {
+	// Queue is either redis or postgres, see QueueRedis and QueuePostgres.
+	Queue string `env:"WORKER_QUEUE,default=redis"`
+	// PollInterval is how long the worker waits for a job before checking again whether it must stop.
+	PollInterval time.Duration `env:"WORKER_POLL_INTERVAL,default=1s"`
+	// Lease is how long a taken job is hidden from other workers. A job taken by a worker which stopped
+	// without acknowledging it runs again once its lease expires.
+	// It must exceed the duration of the longest job, otherwise the job may run twice.
+	Lease time.Duration `env:"WORKER_LEASE,default=5m"`
+	// MaxAttempts is the number of runs of a job after which it is moved to the dead letter.
+	MaxAttempts int `env:"WORKER_MAX_ATTEMPTS,default=5"`
+	// MinBackoff is the delay before retrying a job after its first failure. The delay doubles after each failure
+	// up to MaxBackoff.
+	MinBackoff time.Duration `env:"WORKER_MIN_BACKOFF,default=1s"`
+	MaxBackoff time.Duration `env:"WORKER_MAX_BACKOFF,default=10m"`
+	// ShutdownTimeout is how long the worker waits for running jobs to finish once it is asked to stop.
+	ShutdownTimeout time.Duration `env:"WORKER_SHUTDOWN_TIMEOUT,default=30s"`
+}
+
+// Sighting holds the rules applied to new sightings.
+type Sighting struct {
+	// MaxDistance is the default maximum distance, in meters, between a new sighting and the last seen location.
+	// Each tiger may override it.
+	MaxDistance float64 `env:"SIGHTING_MAX_DISTANCE,default=5000"`
+	// DistancePolicy is either reject or review, see PolicyReject and PolicyReview.
+	DistancePolicy string `env:"SIGHTING_DISTANCE_POLICY,default=reject"`
+	// MaxSpeed is the maximum speed, in km/h, implied by the distance and time between a new sighting and the previous one.
+	MaxSpeed float64 `env:"SIGHTING_MAX_SPEED,default=60"`
+	// SpeedPolicy is either reject or review, see PolicyReject and PolicyReview.
+	SpeedPolicy string `env:"SIGHTING_SPEED_POLICY,default=reject"`
+	// ClockSkew is how far in the future the seen time of a new sighting may be, to tolerate clocks of cameras running fast.
+	ClockSkew time.Duration `env:"SIGHTING_CLOCK_SKEW,default=5m"`
+	// WatchHeartbeat is how long a stream of WatchSightings stays idle before a heartbeat is sent,
+	// to keep the connection open through proxies closing idle connections.
+	WatchHeartbeat time.Duration `env:"SIGHTING_WATCH_HEARTBEAT,default=15s"`
+	// ExifTimeTolerance and ExifDistanceTolerance, in meters, are how far the seen time and location of a new sighting
+	// may be from the capture time and GPS location in the EXIF of its image before it needs review.
+	ExifTimeTolerance     time.Duration `env:"SIGHTING_EXIF_TIME_TOLERANCE,default=10m"`
+	ExifDistanceTolerance float64       `env:"SIGHTING_EXIF_DISTANCE_TOLERANCE,default=1000"`
+	// DuplicateImageDistance is the number of bits, out of 64, by which the perceptual hashes of the images of two sightings
+	// may differ for the images to be near-duplicates, from 0 to MaxDuplicateImageDistance.
+	DuplicateImageDistance int `env:"SIGHTING_DUPLICATE_IMAGE_DISTANCE,default=3"`
+	// DuplicateImagePolicy is either reject or review, see PolicyReject and PolicyReview.
+	DuplicateImagePolicy string `env:"SIGHTING_DUPLICATE_IMAGE_POLICY,default=review"`
+}
+
+// NewConfig creates an instance of Config.
+// It reads the given .env file, if it exists, and the environment variables.
+// Environment variables take precedence over values defined in the file.
+// If one or more keys are missing or invalid, it returns Errors describing each of them.
+func NewConfig(path string) (*Config, error) {
+	values, err := godotenv.Read(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	lookup := func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(key); ok {
+			return v, true
+		}
+		v, ok := values[key]
+		return v, ok
+	}
+
+	cfg := &Config{}
+	if errs := decode(cfg, lookup); len(errs) > 0 {
+		return nil, errs
+	}
+	if errs := cfg.validate(); len(errs) > 0 {
+		return nil, errs
+	}
+	return cfg, nil
+}
+
+// IsDevelopment tells whether the application runs in development environment.
+// It is safe to call on a nil Config.
+func (c *Config) IsDevelopment() bool {
+	return c != nil && c.Env == EnvDevelopment
+}
+
+// IsProduction tells whether the application runs in production environment.
+func (c *Config) IsProduction() bool {
+	return c != nil && c.Env == EnvProduction
+}
+
+func (c *Config) validate() Errors {
+	var errs Errors
+	if c.Hashid.MinLength < 0 {
+		errs = append(errs, newFieldError("Hashid.MinLength", "HASHID_MIN_LENGTH", ErrNotPositive))
+	}
+	if c.Postgres.MaxOpenConns <= 0 {
+		errs = append(errs, newFieldError("Postgres.MaxOpenConns", "POSTGRES_MAX_OPEN_CONNS", ErrNotPositive))
+	}
+	if c.Postgres.MaxConnLifetime <= 0 {
+		errs = append(errs, newFieldError("Postgres.MaxConnLifetime", "POSTGRES_MAX_CONN_LIFETIME", ErrNotPositive))
+	}
+	if c.Postgres.MaxIdleLifetime <= 0 {
+		errs = append(errs, newFieldError("Postgres.MaxIdleLifetime", "POSTGRES_MAX_IDLE_LIFETIME", ErrNotPositive))
+	}
+	if c.Redis.ToggleTTL <= 0 {
+		errs = append(errs, newFieldError("Redis.ToggleTTL", "TOGGLE_REDIS_TTL", ErrNotPositive))
+	}
+	if c.Sighting.MaxDistance <= 0 {
+		errs = append(errs, newFieldError("Sighting.MaxDistance", "SIGHTING_MAX_DISTANCE", ErrNotPositive))
+	}
+	if err := validatePolicy(c.Sighting.DistancePolicy); err != nil {
+		errs = append(errs, newFieldError("Sighting.DistancePolicy", "SIGHTING_DISTANCE_POLICY", err))
+	}
+	if c.Sighting.MaxSpeed <= 0 {
+		errs = append(errs, newFieldError("Sighting.MaxSpeed", "SIGHTING_MAX_SPEED", ErrNotPositive))
+	}
+	if err := validatePolicy(c.Sighting.SpeedPolicy); err != nil {
+		errs = append(errs, newFieldError("Sighting.SpeedPolicy", "SIGHTING_SPEED_POLICY", err))
+	}
+	if c.Sighting.ClockSkew <= 0 {
+		errs = append(errs, newFieldError("Sighting.ClockSkew", "SIGHTING_CLOCK_SKEW", ErrNotPositive))
+	}
+	if c.Sighting.WatchHeartbeat <= 0 {
+		errs = append(errs, newFieldError("Sighting.WatchHeartbeat", "SIGHTING_WATCH_HEARTBEAT", ErrNotPositive))
+	}
+	if c.Sighting.ExifTimeTolerance <= 0 {
+		errs = append(errs, newFieldError("Sighting.ExifTimeTolerance", "SIGHTING_EXIF_TIME_TOLERANCE", ErrNotPositive))
+	}
+	if c.Sighting.ExifDistanceTolerance <= 0 {
+		errs = append(errs, newFieldError("Sighting.ExifDistanceTolerance", "SIGHTING_EXIF_DISTANCE_TOLERANCE", ErrNotPositive))
+	}
+	if c.Sighting.DuplicateImageDistance < 0 || c.Sighting.DuplicateImageDistance > MaxDuplicateImageDistance {
+		errs = append(errs, newFieldError("Sighting.DuplicateImageDistance", "SIGHTING_DUPLICATE_IMAGE_DISTANCE",
+			fmt.Errorf("%w: %d is not between 0 and %d", ErrInvalid, c.Sighting.DuplicateImageDistance, MaxDuplicateImageDistance)))
+	}
+	if err := validatePolicy(c.Sighting.DuplicateImagePolicy); err != nil {
+		errs = append(errs, newFieldError("Sighting.DuplicateImagePolicy", "SIGHTING_DUPLICATE_IMAGE_POLICY", err))
+	}
+	errs = append(errs, c.validateImageStore()...)
+	if c.ImageLimits.MaxBytes <= 0 {
+		errs = append(errs, newFieldError("ImageLimits.MaxBytes", "IMAGE_MAX_BYTES", ErrNotPositive))
+	}
+	if c.ImageLimits.MaxWidth <= 0 {
+		errs = append(errs, newFieldError("ImageLimits.MaxWidth", "IMAGE_MAX_WIDTH", ErrNotPositive))
+	}
+	if c.ImageLimits.MaxHeight <= 0 {
+		errs = append(errs, newFieldError("ImageLimits.MaxHeight", "IMAGE_MAX_HEIGHT", ErrNotPositive))
+	}
+	errs = append(errs, c.validateImageRenditions()...)
+	if c.SMTP.Timeout <= 0 {
+		errs = append(errs, newFieldError("SMTP.Timeout", "SMTP_TIMEOUT", ErrNotPositive))
+	}
+	if c.Notification.Workers <= 0 {
+		errs = append(errs, newFieldError("Notification.Workers", "NOTIFICATION_WORKERS", ErrNotPositive))
+	}
+	if c.Webhook.Timeout <= 0 {
+		errs = append(errs, newFieldError("Webhook.Timeout", "WEBHOOK_TIMEOUT", ErrNotPositive))
+	}
+	if c.Webhook.Workers <= 0 {
+		errs = append(errs, newFieldError("Webhook.Workers", "WEBHOOK_WORKERS", ErrNotPositive))
+	}
+	if c.Outbox.PollInterval <= 0 {
+		errs = append(errs, newFieldError("Outbox.PollInterval", "OUTBOX_POLL_INTERVAL", ErrNotPositive))
+	}
+	if c.Outbox.BatchSize <= 0 {
+		errs = append(errs, newFieldError("Outbox.BatchSize", "OUTBOX_BATCH_SIZE", ErrNotPositive))
+	}
+	if c.Outbox.Lease <= 0 {
+		errs = append(errs, newFieldError("Outbox.Lease", "OUTBOX_LEASE", ErrNotPositive))
+	}
+	if c.Outbox.MaxAttempts <= 0 {
+		errs = append(errs, newFieldError("Outbox.MaxAttempts", "OUTBOX_MAX_ATTEMPTS", ErrNotPositive))
+	}
+	if c.Outbox.MinBackoff <= 0 {
+		errs = append(errs, newFieldError("Outbox.MinBackoff", "OUTBOX_MIN_BACKOFF", ErrNotPositive))
+	}
+	if c.Outbox.MaxBackoff < c.Outbox.MinBackoff {
+		errs = append(errs, newFieldError("Outbox.MaxBackoff", "OUTBOX_MAX_BACKOFF",
+			fmt.Errorf("%w: must not be less than OUTBOX_MIN_BACKOFF", ErrInvalid)))
+	}
+	if c.Worker.Queue != QueueRedis && c.Worker.Queue != QueuePostgres {
+		errs = append(errs, newFieldError("Worker.Queue", "WORKER_QUEUE",
+			fmt.Errorf("%w: %q is neither %s nor %s", ErrInvalid, c.Worker.Queue, QueueRedis, QueuePostgres)))
+	}
+	if c.Worker.PollInterval <= 0 {
+		errs = append(errs, newFieldError("Worker.PollInterval", "WORKER_POLL_INTERVAL", ErrNotPositive))
+	}
+	if c.Worker.Lease <= 0 {
+		errs = append(errs, newFieldError("Worker.Lease", "WORKER_LEASE", ErrNotPositive))
+	}
+	if c.Worker.MaxAttempts <= 0 {
+		errs = append(errs, newFieldError("Worker.MaxAttempts", "WORKER_MAX_ATTEMPTS", ErrNotPositive))
+	}
+	if c.Worker.MinBackoff <= 0 {
+		errs = append(errs, newFieldError("Worker.MinBackoff", "WORKER_MIN_BACKOFF", ErrNotPositive))
+	}
+	if c.Worker.MaxBackoff < c.Worker.MinBackoff {
+		errs = append(errs, newFieldError("Worker.MaxBackoff", "WORKER_MAX_BACKOFF",
+			fmt.Errorf("%w: must not be less than WORKER_MIN_BACKOFF", ErrInvalid)))
+	}
+	if c.Worker.ShutdownTimeout <= 0 {
+		errs = append(errs, newFieldError("Worker.ShutdownTimeout", "WORKER_SHUTDOWN_TIMEOUT", ErrNotPositive))
+	}
+	return errs
+}
+
+// validateImageRenditions validates the size and the mode of each rendition of sighting images.
+func (c *Config) validateImageRenditions() Errors {
+	var errs Errors
+	r := c.ImageRenditions
+	for _, rendition := range []struct {
+		name          string
+		key           string
+		width, height int
+		mode          string
+	}{
+		{"Thumbnail", "IMAGE_THUMBNAIL", r.ThumbnailWidth, r.ThumbnailHeight, r.ThumbnailMode},
+		{"Medium", "IMAGE_MEDIUM", r.MediumWidth, r.MediumHeight, r.MediumMode},
+		{"Large", "IMAGE_LARGE", r.LargeWidth, r.LargeHeight, r.LargeMode},
+	} {
+		field := "ImageRenditions." + rendition.name
+		if rendition.width <= 0 {
+			errs = append(errs, newFieldError(field+"Width", rendition.key+"_WIDTH", ErrNotPositive))
+		}
+		if rendition.height <= 0 {
+			errs = append(errs, newFieldError(field+"Height", rendition.key+"_HEIGHT", ErrNotPositive))
+		}
+		if rendition.mode != ImageFit && rendition.mode != ImageFill {
+			errs = append(errs, newFieldError(field+"Mode", rendition.key+"_MODE",
+				fmt.Errorf("%w: %q is neither %s nor %s", ErrInvalid, rendition.mode, ImageFit, ImageFill)))
+		}
+	}
+	return errs
+}
+
+// validateImageStore validates the image store backend and the configuration it needs.
+// The local backend needs nothing but its directory, which has a default.
+func (c *Config) validateImageStore() Errors {
+	var errs Errors
+	switch c.ImageStore.Backend {
+	case ImageStoreLocal:
+	case ImageStoreS3:
+		if u, err := url.Parse(c.S3.Endpoint); c.S3.Endpoint == "" {
+			errs = append(errs, newFieldError("S3.Endpoint", "S3_ENDPOINT", ErrMissing))
+		} else if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, newFieldError("S3.Endpoint", "S3_ENDPOINT",
+				fmt.Errorf("%w: %q is not an http or https URL", ErrInvalid, c.S3.Endpoint)))
+		}
+		if c.S3.Bucket == "" {
+			errs = append(errs, newFieldError("S3.Bucket", "S3_BUCKET", ErrMissing))
+		}
+		if c.S3.AccessKey == "" {
+			errs = append(errs, newFieldError("S3.AccessKey", "S3_ACCESS_KEY", ErrMissing))
+		}
+		if c.S3.SecretKey == "" {
+			errs = append(errs, newFieldError("S3.SecretKey", "S3_SECRET_KEY", ErrMissing))
+		}
+		if c.S3.Timeout <= 0 {
+			errs = append(errs, newFieldError("S3.Timeout", "S3_TIMEOUT", ErrNotPositive))
+		}
+	default:
+		errs = append(errs, newFieldError("ImageStore.Backend", "IMAGE_STORE",
+			fmt.Errorf("%w: %q is neither %s nor %s", ErrInvalid, c.ImageStore.Backend, ImageStoreLocal, ImageStoreS3)))
+	}
+	return errs
+}
+
+func validatePolicy(policy string) error {
+	if policy != PolicyReject && policy != PolicyReview {
+		return fmt.Errorf("%w: %q is neither %s nor %s", ErrInvalid, policy, PolicyReject, PolicyReview)
+	}
+	return nil
+}
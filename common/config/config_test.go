@@ -0,0 +1,410 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+)
+
+const (
+	validEnvFile      = "../../test/fixture/env.valid"
+	incompleteEnvFile = "../../test/fixture/env.incomplete"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Run("successfully load valid env file", func(t *testing.T) {
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, "development", cfg.Env)
+		assert.Equal(t, "tigerhall-kittens-server", cfg.ServiceName)
+		assert.Equal(t, config.Port{GRPC: "8080", REST: "8081", Metrics: "9090"}, cfg.Port)
+		assert.Equal(t, config.Hashid{Salt: "salt-is-garam", MinLength: 10}, cfg.Hashid)
+		assert.Equal(t, config.Postgres{
+			Host:            "localhost",
+			Port:            "5432",
+			User:            "username",
+			Password:        "password",
+			Name:            "guru",
+			MaxOpenConns:    50,
+			MaxConnLifetime: 10 * time.Minute,
+			MaxIdleLifetime: 5 * time.Minute,
+		}, cfg.Postgres)
+		assert.Equal(t, config.Redis{Address: "localhost:6379", ToggleTTL: 5}, cfg.Redis)
+		assert.Equal(t, config.Sighting{
+			MaxDistance:            5000,
+			DistancePolicy:         config.PolicyReject,
+			MaxSpeed:               60,
+			SpeedPolicy:            config.PolicyReject,
+			ClockSkew:              5 * time.Minute,
+			WatchHeartbeat:         15 * time.Second,
+			ExifTimeTolerance:      10 * time.Minute,
+			ExifDistanceTolerance:  1000,
+			DuplicateImageDistance: 3,
+			DuplicateImagePolicy:   config.PolicyReview,
+		}, cfg.Sighting)
+		assert.Equal(t, config.ImageStore{Backend: config.ImageStoreLocal, Dir: "data/images"}, cfg.ImageStore)
+		assert.Equal(t, config.ImageLimits{MaxBytes: 10 << 20, MaxWidth: 8192, MaxHeight: 8192}, cfg.ImageLimits)
+		assert.Equal(t, config.ImageRenditions{
+			ThumbnailWidth:  100,
+			ThumbnailHeight: 80,
+			ThumbnailMode:   config.ImageFill,
+			MediumWidth:     250,
+			MediumHeight:    200,
+			MediumMode:      config.ImageFit,
+			LargeWidth:      1024,
+			LargeHeight:     768,
+			LargeMode:       config.ImageFit,
+		}, cfg.ImageRenditions)
+		assert.Equal(t, config.S3{Region: "us-east-1", Timeout: 10 * time.Second}, cfg.S3)
+		assert.Equal(t, config.SMTP{
+			Host:    "localhost",
+			Port:    "1025",
+			From:    "noreply@tigerhall-kittens.local",
+			Timeout: 10 * time.Second,
+		}, cfg.SMTP)
+		assert.Equal(t, config.Notification{Workers: 4}, cfg.Notification)
+		assert.Equal(t, config.Webhook{Timeout: 10 * time.Second, Workers: 4}, cfg.Webhook)
+		assert.Equal(t, config.Outbox{
+			PollInterval: time.Second,
+			BatchSize:    100,
+			Lease:        time.Minute,
+			MaxAttempts:  10,
+			MinBackoff:   time.Second,
+			MaxBackoff:   5 * time.Minute,
+		}, cfg.Outbox)
+		assert.Equal(t, config.Worker{
+			Queue:           config.QueueRedis,
+			PollInterval:    time.Second,
+			Lease:           5 * time.Minute,
+			MaxAttempts:     5,
+			MinBackoff:      time.Second,
+			MaxBackoff:      10 * time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+		}, cfg.Worker)
+		assert.True(t, cfg.IsDevelopment())
+		assert.False(t, cfg.IsProduction())
+	})
+
+	t.Run("fail load incomplete env file", func(t *testing.T) {
+		cfg, err := config.NewConfig(incompleteEnvFile)
+
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.ElementsMatch(t, []string{
+			"SERVICE_NAME",
+			"HASHID_SALT",
+			"POSTGRES_HOST",
+			"POSTGRES_USER",
+			"POSTGRES_PASSWORD",
+			"POSTGRES_NAME",
+		}, errs.Keys())
+		for _, fe := range errs {
+			assert.True(t, errors.Is(fe, config.ErrMissing))
+		}
+	})
+
+	t.Run("environment variables override env file", func(t *testing.T) {
+		t.Setenv("POSTGRES_HOST", "db.internal")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", cfg.Postgres.Host)
+	})
+
+	t.Run("fail parse invalid duration and integer", func(t *testing.T) {
+		t.Setenv("POSTGRES_MAX_CONN_LIFETIME", "ten minutes")
+		t.Setenv("POSTGRES_MAX_OPEN_CONNS", "fifty")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.ElementsMatch(t, []string{"POSTGRES_MAX_CONN_LIFETIME", "POSTGRES_MAX_OPEN_CONNS"}, errs.Keys())
+		for _, fe := range errs {
+			assert.True(t, errors.Is(fe, config.ErrInvalid))
+		}
+	})
+
+	t.Run("fail validate non positive value", func(t *testing.T) {
+		t.Setenv("TOGGLE_REDIS_TTL", "0")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"TOGGLE_REDIS_TTL"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrNotPositive))
+		assert.Contains(t, err.Error(), "TOGGLE_REDIS_TTL (Redis.ToggleTTL)")
+	})
+
+	t.Run("fail validate sighting rules", func(t *testing.T) {
+		t.Setenv("SIGHTING_MAX_DISTANCE", "-1")
+		t.Setenv("SIGHTING_DISTANCE_POLICY", "ignore")
+		t.Setenv("SIGHTING_MAX_SPEED", "0")
+		t.Setenv("SIGHTING_SPEED_POLICY", "ignore")
+		t.Setenv("SIGHTING_CLOCK_SKEW", "0s")
+		t.Setenv("SIGHTING_WATCH_HEARTBEAT", "0s")
+		t.Setenv("SIGHTING_EXIF_TIME_TOLERANCE", "0s")
+		t.Setenv("SIGHTING_EXIF_DISTANCE_TOLERANCE", "-5")
+		t.Setenv("SIGHTING_DUPLICATE_IMAGE_DISTANCE", "8")
+		t.Setenv("SIGHTING_DUPLICATE_IMAGE_POLICY", "ignore")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"SIGHTING_MAX_DISTANCE", "SIGHTING_DISTANCE_POLICY", "SIGHTING_MAX_SPEED", "SIGHTING_SPEED_POLICY",
+			"SIGHTING_CLOCK_SKEW", "SIGHTING_WATCH_HEARTBEAT", "SIGHTING_EXIF_TIME_TOLERANCE", "SIGHTING_EXIF_DISTANCE_TOLERANCE",
+			"SIGHTING_DUPLICATE_IMAGE_DISTANCE", "SIGHTING_DUPLICATE_IMAGE_POLICY"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[1], config.ErrInvalid))
+		assert.True(t, errors.Is(errs[2], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[3], config.ErrInvalid))
+		assert.True(t, errors.Is(errs[4], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[5], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[6], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[7], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[8], config.ErrInvalid))
+		assert.True(t, errors.Is(errs[9], config.ErrInvalid))
+	})
+
+	t.Run("sighting rules are read from environment variables", func(t *testing.T) {
+		t.Setenv("SIGHTING_MAX_DISTANCE", "12500")
+		t.Setenv("SIGHTING_DISTANCE_POLICY", "review")
+		t.Setenv("SIGHTING_MAX_SPEED", "80")
+		t.Setenv("SIGHTING_SPEED_POLICY", "review")
+		t.Setenv("SIGHTING_CLOCK_SKEW", "1m")
+		t.Setenv("SIGHTING_WATCH_HEARTBEAT", "30s")
+		t.Setenv("SIGHTING_EXIF_TIME_TOLERANCE", "1h")
+		t.Setenv("SIGHTING_EXIF_DISTANCE_TOLERANCE", "250")
+		t.Setenv("SIGHTING_DUPLICATE_IMAGE_DISTANCE", "0")
+		t.Setenv("SIGHTING_DUPLICATE_IMAGE_POLICY", "reject")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, config.Sighting{
+			MaxDistance:            12500,
+			DistancePolicy:         config.PolicyReview,
+			MaxSpeed:               80,
+			SpeedPolicy:            config.PolicyReview,
+			ClockSkew:              time.Minute,
+			WatchHeartbeat:         30 * time.Second,
+			ExifTimeTolerance:      time.Hour,
+			ExifDistanceTolerance:  250,
+			DuplicateImageDistance: 0,
+			DuplicateImagePolicy:   config.PolicyReject,
+		}, cfg.Sighting)
+	})
+
+	t.Run("fail validate image store backend", func(t *testing.T) {
+		t.Setenv("IMAGE_STORE", "ftp")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"IMAGE_STORE"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrInvalid))
+	})
+
+	t.Run("fail validate image limits", func(t *testing.T) {
+		t.Setenv("IMAGE_MAX_BYTES", "0")
+		t.Setenv("IMAGE_MAX_WIDTH", "-1")
+		t.Setenv("IMAGE_MAX_HEIGHT", "0")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"IMAGE_MAX_BYTES", "IMAGE_MAX_WIDTH", "IMAGE_MAX_HEIGHT"}, errs.Keys())
+		for _, e := range errs {
+			assert.True(t, errors.Is(e, config.ErrNotPositive))
+		}
+	})
+
+	t.Run("fail validate image renditions", func(t *testing.T) {
+		t.Setenv("IMAGE_THUMBNAIL_MODE", "stretch")
+		t.Setenv("IMAGE_MEDIUM_WIDTH", "0")
+		t.Setenv("IMAGE_LARGE_HEIGHT", "-1")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"IMAGE_THUMBNAIL_MODE", "IMAGE_MEDIUM_WIDTH", "IMAGE_LARGE_HEIGHT"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrInvalid))
+		assert.True(t, errors.Is(errs[1], config.ErrNotPositive))
+		assert.True(t, errors.Is(errs[2], config.ErrNotPositive))
+	})
+
+	t.Run("fail validate s3 image store", func(t *testing.T) {
+		t.Setenv("IMAGE_STORE", "s3")
+		t.Setenv("S3_ENDPOINT", "minio:9000")
+		t.Setenv("S3_TIMEOUT", "0s")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"S3_ENDPOINT", "S3_BUCKET", "S3_ACCESS_KEY", "S3_SECRET_KEY", "S3_TIMEOUT"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrInvalid))
+		for _, e := range errs[1:4] {
+			assert.True(t, errors.Is(e, config.ErrMissing))
+		}
+		assert.True(t, errors.Is(errs[4], config.ErrNotPositive))
+	})
+
+	t.Run("s3 image store is read from environment variables", func(t *testing.T) {
+		t.Setenv("IMAGE_STORE", "s3")
+		t.Setenv("S3_ENDPOINT", "http://minio:9000")
+		t.Setenv("S3_REGION", "ap-southeast-1")
+		t.Setenv("S3_BUCKET", "sighting-images")
+		t.Setenv("S3_ACCESS_KEY", "access")
+		t.Setenv("S3_SECRET_KEY", "secret")
+		t.Setenv("S3_TIMEOUT", "30s")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, config.ImageStoreS3, cfg.ImageStore.Backend)
+		assert.Equal(t, config.S3{
+			Endpoint:  "http://minio:9000",
+			Region:    "ap-southeast-1",
+			Bucket:    "sighting-images",
+			AccessKey: "access",
+			SecretKey: "secret",
+			Timeout:   30 * time.Second,
+		}, cfg.S3)
+	})
+
+	t.Run("fail validate notification delivery", func(t *testing.T) {
+		t.Setenv("SMTP_TIMEOUT", "0s")
+		t.Setenv("NOTIFICATION_WORKERS", "-1")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"SMTP_TIMEOUT", "NOTIFICATION_WORKERS"}, errs.Keys())
+		for _, e := range errs {
+			assert.True(t, errors.Is(e, config.ErrNotPositive))
+		}
+	})
+
+	t.Run("fail validate webhook delivery", func(t *testing.T) {
+		t.Setenv("WEBHOOK_TIMEOUT", "0s")
+		t.Setenv("WEBHOOK_WORKERS", "0")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"WEBHOOK_TIMEOUT", "WEBHOOK_WORKERS"}, errs.Keys())
+		for _, e := range errs {
+			assert.True(t, errors.Is(e, config.ErrNotPositive))
+		}
+	})
+
+	t.Run("fail validate outbox relay", func(t *testing.T) {
+		t.Setenv("OUTBOX_POLL_INTERVAL", "0s")
+		t.Setenv("OUTBOX_BATCH_SIZE", "0")
+		t.Setenv("OUTBOX_LEASE", "-1s")
+		t.Setenv("OUTBOX_MAX_ATTEMPTS", "0")
+		t.Setenv("OUTBOX_MIN_BACKOFF", "10m")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_LEASE", "OUTBOX_MAX_ATTEMPTS",
+			"OUTBOX_MAX_BACKOFF"}, errs.Keys())
+		for _, e := range errs[:4] {
+			assert.True(t, errors.Is(e, config.ErrNotPositive))
+		}
+		assert.True(t, errors.Is(errs[4], config.ErrInvalid))
+	})
+
+	t.Run("fail validate worker", func(t *testing.T) {
+		t.Setenv("WORKER_QUEUE", "kafka")
+		t.Setenv("WORKER_POLL_INTERVAL", "0s")
+		t.Setenv("WORKER_LEASE", "0s")
+		t.Setenv("WORKER_MAX_ATTEMPTS", "0")
+		t.Setenv("WORKER_MIN_BACKOFF", "1h")
+		t.Setenv("WORKER_SHUTDOWN_TIMEOUT", "-1s")
+
+		_, err := config.NewConfig(validEnvFile)
+
+		var errs config.Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, []string{"WORKER_QUEUE", "WORKER_POLL_INTERVAL", "WORKER_LEASE", "WORKER_MAX_ATTEMPTS",
+			"WORKER_MAX_BACKOFF", "WORKER_SHUTDOWN_TIMEOUT"}, errs.Keys())
+		assert.True(t, errors.Is(errs[0], config.ErrInvalid))
+		assert.True(t, errors.Is(errs[4], config.ErrInvalid))
+	})
+
+	t.Run("postgres worker queue is read from environment variables", func(t *testing.T) {
+		t.Setenv("WORKER_QUEUE", "postgres")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, config.QueuePostgres, cfg.Worker.Queue)
+	})
+
+	t.Run("smtp server is read from environment variables", func(t *testing.T) {
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("SMTP_PORT", "587")
+		t.Setenv("SMTP_USERNAME", "user")
+		t.Setenv("SMTP_PASSWORD", "secret")
+		t.Setenv("SMTP_FROM", "tiger@example.com")
+
+		cfg, err := config.NewConfig(validEnvFile)
+
+		require.NoError(t, err)
+		assert.Equal(t, config.SMTP{
+			Host:     "smtp.example.com",
+			Port:     "587",
+			Username: "user",
+			Password: "secret",
+			From:     "tiger@example.com",
+			Timeout:  10 * time.Second,
+		}, cfg.SMTP)
+	})
+
+	t.Run("missing env file falls back to environment variables", func(t *testing.T) {
+		t.Setenv("SERVICE_NAME", "svc")
+		t.Setenv("HASHID_SALT", "salt")
+		t.Setenv("POSTGRES_HOST", "localhost")
+		t.Setenv("POSTGRES_USER", "user")
+		t.Setenv("POSTGRES_PASSWORD", "password")
+		t.Setenv("POSTGRES_NAME", "tigerhall")
+		t.Setenv("REDIS_ADDRESS", "localhost:6379")
+
+		cfg, err := config.NewConfig("not-exist.env")
+
+		require.NoError(t, err)
+		assert.Equal(t, "svc", cfg.ServiceName)
+		assert.Equal(t, 10*time.Minute, cfg.Postgres.MaxConnLifetime)
+	})
+}
+
+func TestConfig_IsDevelopment(t *testing.T) {
+	t.Run("nil config is not development", func(t *testing.T) {
+		var cfg *config.Config
+		assert.False(t, cfg.IsDevelopment())
+	})
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tagName     = "env"
+	tagRequired = "required"
+	tagDefault  = "default="
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+type lookupFunc func(key string) (string, bool)
+
+// decode fills target, which must be a pointer to struct, from the values returned by lookup.
+// Nested structs without env tag are decoded recursively.
+// It keeps going after the first failure so that every faulty key is reported, unlike envdecode which stops at the first.
+func decode(target interface{}, lookup lookupFunc) Errors {
+	return decodeStruct(reflect.ValueOf(target).Elem(), "", lookup)
+}
+
+func decodeStruct(v reflect.Value, prefix string, lookup lookupFunc) Errors {
+	var errs Errors
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := prefix + field.Name
+
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, decodeStruct(v.Field(i), name+".", lookup)...)
+			}
+			continue
+		}
+
+		key, required, defaultValue, hasDefault := parseTag(tag)
+		value, found := lookup(key)
+		if !found || value == "" {
+			if required {
+				errs = append(errs, newFieldError(name, key, ErrMissing))
+				continue
+			}
+			if !hasDefault {
+				continue
+			}
+			value = defaultValue
+		}
+
+		if err := setValue(v.Field(i), value); err != nil {
+			errs = append(errs, newFieldError(name, key, err))
+		}
+	}
+	return errs
+}
+
+func parseTag(tag string) (key string, required bool, defaultValue string, hasDefault bool) {
+	parts := strings.Split(tag, ",")
+	key = parts[0]
+	for _, p := range parts[1:] {
+		switch {
+		case p == tagRequired:
+			required = true
+		case strings.HasPrefix(p, tagDefault):
+			defaultValue = strings.TrimPrefix(p, tagDefault)
+			hasDefault = true
+		}
+	}
+	return key, required, defaultValue, hasDefault
+}
+
+func setValue(f reflect.Value, value string) error {
+	if f.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a duration", ErrInvalid, value)
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+
+	switch f.Kind() { // nolint:exhaustive
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, f.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w: %q is not an integer", ErrInvalid, value)
+		}
+		f.SetInt(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a number", ErrInvalid, value)
+		}
+		f.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a boolean", ErrInvalid, value)
+		}
+		f.SetBool(b)
+	default:
+		return fmt.Errorf("%w: unsupported type %s", ErrInvalid, f.Type())
+	}
+	return nil
+}
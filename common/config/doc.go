@@ -0,0 +1,3 @@
+// Package config provides typed application configuration.
+// The configuration is read from an optional .env file and from environment variables.
+package config
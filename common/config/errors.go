@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMissing is returned when a required key is not set.
+	ErrMissing = errors.New("is required but not set")
+	// ErrInvalid is returned when a key can't be parsed into its field type.
+	ErrInvalid = errors.New("invalid value")
+	// ErrNotPositive is returned when a numeric key must be greater than zero.
+	ErrNotPositive = errors.New("must be greater than zero")
+)
+
+// FieldError describes a single configuration field that failed to load.
+type FieldError struct {
+	// Field is the path of the field in Config, e.g. Postgres.Host.
+	Field string
+	// Key is the environment variable the field is read from.
+	Key string
+	Err error
+}
+
+func newFieldError(field, key string, err error) *FieldError {
+	return &FieldError{Field: field, Key: key, Err: err}
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Key, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is a list of FieldError returned by NewConfig.
+type Errors []*FieldError
+
+// Error implements error.
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return "config: " + strings.Join(msgs, "; ")
+}
+
+// Keys returns the environment variables of all fields that failed to load.
+func (e Errors) Keys() []string {
+	keys := make([]string, 0, len(e))
+	for _, fe := range e {
+		keys = append(keys, fe.Key)
+	}
+	return keys
+}
@@ -0,0 +1,3 @@
+// Package errors provides typed application errors and their mapping to gRPC status.
+// Handlers may return these errors as is; the gRPC server converts them into the matching status code.
+package errors
@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kind classifies an application error.
+type Kind int
+
+const (
+	// KindUnknown is the kind of errors that are not created by this package.
+	KindUnknown Kind = iota
+	// KindValidation means the request contains invalid fields.
+	KindValidation
+	// KindNotFound means the requested resource doesn't exist.
+	KindNotFound
+	// KindConflict means the request conflicts with a concurrent change and may be retried.
+	KindConflict
+	// KindPrecondition means the system is not in a state required by the request.
+	KindPrecondition
+)
+
+// FieldViolation describes a single invalid field of a request.
+type FieldViolation struct {
+	// Field is the path of the field in the request, e.g. last_seen_latitude.
+	Field string
+	// Description tells why the field is invalid.
+	Description string
+}
+
+// Error is a typed application error.
+type Error struct {
+	Kind       Kind
+	Message    string
+	Violations []FieldViolation
+}
+
+// NewValidationError creates a validation error from the given field violations.
+// It returns nil if there is no violation.
+func NewValidationError(violations ...FieldViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	descs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		descs = append(descs, v.Description)
+	}
+	return &Error{
+		Kind:       KindValidation,
+		Message:    strings.Join(descs, "; "),
+		Violations: violations,
+	}
+}
+
+// NewNotFoundError creates a not found error.
+func NewNotFoundError(format string, args ...interface{}) *Error {
+	return &Error{Kind: KindNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewConflictError creates a conflict error.
+func NewConflictError(format string, args ...interface{}) *Error {
+	return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewPreconditionError creates a failed precondition error.
+func NewPreconditionError(format string, args ...interface{}) *Error {
+	return &Error{Kind: KindPrecondition, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus converts e into gRPC status.
+// Validation errors carry google.rpc.BadRequest details with one field violation per invalid field.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Kind.code(), e.Message)
+	if e.Kind != KindValidation || len(e.Violations) == 0 {
+		return st
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if detailed, err := st.WithDetails(br); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// KindOf returns the kind of the first Error in err's chain, or KindUnknown if there is none.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindUnknown
+}
+
+// ToGRPCError converts err into gRPC status error if err's chain contains an Error.
+// The message of the returned status is err's message, so wrapping context is preserved.
+// Other errors, including errors that already are gRPC status, are returned as is.
+func ToGRPCError(err error) error {
+	var e *Error
+	if err == nil || !errors.As(err, &e) {
+		return err
+	}
+	st := e.GRPCStatus().Proto()
+	st.Message = err.Error()
+	return status.ErrorProto(st)
+}
+
+func (k Kind) code() codes.Code {
+	switch k {
+	case KindValidation:
+		return codes.InvalidArgument
+	case KindNotFound:
+		return codes.NotFound
+	case KindConflict:
+		return codes.Aborted
+	case KindPrecondition:
+		return codes.FailedPrecondition
+	case KindUnknown:
+		return codes.Unknown
+	default:
+		return codes.Unknown
+	}
+}
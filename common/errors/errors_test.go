@@ -0,0 +1,129 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+)
+
+func TestNewValidationError(t *testing.T) {
+	t.Run("return nil without violation", func(t *testing.T) {
+		assert.Nil(t, apperrors.NewValidationError())
+	})
+
+	t.Run("join violation descriptions", func(t *testing.T) {
+		err := apperrors.NewValidationError(
+			apperrors.FieldViolation{Field: "name", Description: "name is empty"},
+			apperrors.FieldViolation{Field: "latitude", Description: "latitude is invalid"},
+		)
+
+		require.Error(t, err)
+		assert.Equal(t, "name is empty; latitude is invalid", err.Error())
+		assert.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+	})
+}
+
+func TestToGRPCError(t *testing.T) {
+	type testCase struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "validation error",
+			err:     apperrors.NewValidationError(apperrors.FieldViolation{Field: "name", Description: "name is empty"}),
+			code:    codes.InvalidArgument,
+			message: "name is empty",
+		},
+		{
+			name:    "not found error",
+			err:     apperrors.NewNotFoundError("tiger not found"),
+			code:    codes.NotFound,
+			message: "tiger not found",
+		},
+		{
+			name:    "conflict error",
+			err:     apperrors.NewConflictError("tiger was updated concurrently"),
+			code:    codes.Aborted,
+			message: "tiger was updated concurrently",
+		},
+		{
+			name:    "precondition error",
+			err:     apperrors.NewPreconditionError("distance exceed %d", 5000),
+			code:    codes.FailedPrecondition,
+			message: "distance exceed 5000",
+		},
+		{
+			name:    "wrapped error keeps outer message",
+			err:     fmt.Errorf("%w: deadlock detected", apperrors.NewConflictError("tiger was updated concurrently")),
+			code:    codes.Aborted,
+			message: "tiger was updated concurrently: deadlock detected",
+		},
+		{
+			name:    "gRPC status error is returned as is",
+			err:     status.Error(codes.PermissionDenied, "denied"),
+			code:    codes.PermissionDenied,
+			message: "denied",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			st, ok := status.FromError(apperrors.ToGRPCError(tc.err))
+
+			require.True(t, ok)
+			assert.Equal(t, tc.code, st.Code())
+			assert.Equal(t, tc.message, st.Message())
+		})
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		assert.Nil(t, apperrors.ToGRPCError(nil))
+	})
+
+	t.Run("unknown error is returned as is", func(t *testing.T) {
+		err := errors.New("boom")
+		assert.Equal(t, err, apperrors.ToGRPCError(err))
+	})
+
+	t.Run("validation error carries bad request details", func(t *testing.T) {
+		err := apperrors.NewValidationError(
+			apperrors.FieldViolation{Field: "name", Description: "name is empty"},
+			apperrors.FieldViolation{Field: "latitude", Description: "latitude is invalid"},
+		)
+
+		st, _ := status.FromError(apperrors.ToGRPCError(err))
+
+		require.Len(t, st.Details(), 1)
+		br, ok := st.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, br.GetFieldViolations(), 2)
+		assert.Equal(t, "name", br.GetFieldViolations()[0].GetField())
+		assert.Equal(t, "latitude is invalid", br.GetFieldViolations()[1].GetDescription())
+	})
+}
+
+func TestKindOf(t *testing.T) {
+	t.Run("unknown error", func(t *testing.T) {
+		assert.Equal(t, apperrors.KindUnknown, apperrors.KindOf(errors.New("boom")))
+	})
+
+	t.Run("wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("get tiger: %w", apperrors.NewNotFoundError("tiger not found"))
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+	})
+}
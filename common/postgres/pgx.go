@@ -11,7 +11,7 @@ import (
 
 // NewPool builds a pool of pgx client.
 func NewPool(cfg *config.Postgres) (*pgxpool.Pool, error) {
-	connCfg := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable pool_max_conns=%s pool_max_conn_lifetime=%s pool_max_conn_idle_time=%s",
+	connCfg := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable pool_max_conns=%d pool_max_conn_lifetime=%s pool_max_conn_idle_time=%s",
 		cfg.Host,
 		cfg.Port,
 		cfg.User,
@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,9 +17,9 @@ func TestNewPool(t *testing.T) {
 		Name:            "rapor-pendidikan",
 		User:            "user",
 		Password:        "password",
-		MaxOpenConns:    "10",
-		MaxConnLifetime: "10m",
-		MaxIdleLifetime: "5m",
+		MaxOpenConns:    10,
+		MaxConnLifetime: 10 * time.Minute,
+		MaxIdleLifetime: 5 * time.Minute,
 	}
 
 	t.Run("fail build sql client", func(t *testing.T) {
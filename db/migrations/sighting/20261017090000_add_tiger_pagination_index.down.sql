@@ -0,0 +1,4 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_tiger_name_prefix;
+    DROP INDEX IF EXISTS sighting.idx_tiger_last_seen_id;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_tiger_last_seen_id ON sighting.tiger("last_seen_timestamp" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tiger_name_prefix ON sighting.tiger("name" text_pattern_ops) WHERE "deleted_at" IS NULL;
+
+COMMIT;
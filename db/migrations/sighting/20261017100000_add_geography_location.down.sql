@@ -0,0 +1,6 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_sighting_location;
+    DROP INDEX IF EXISTS sighting.idx_tiger_last_seen_location;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "location";
+    ALTER TABLE sighting.tiger DROP COLUMN IF EXISTS "last_seen_location";
+COMMIT;
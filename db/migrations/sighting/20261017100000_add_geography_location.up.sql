@@ -0,0 +1,20 @@
+BEGIN;
+
+-- migrations run with search_path set to the module schema, so PostGIS objects are schema-qualified
+CREATE EXTENSION IF NOT EXISTS postgis SCHEMA public;
+
+ALTER TABLE sighting.tiger ADD COLUMN IF NOT EXISTS "last_seen_location" public.geography(Point, 4326);
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "location" public.geography(Point, 4326);
+
+UPDATE sighting.tiger
+SET "last_seen_location" = public.ST_SetSRID(public.ST_MakePoint("last_seen_longitude"::float8, "last_seen_latitude"::float8), 4326)::public.geography
+WHERE "last_seen_location" IS NULL;
+
+UPDATE sighting.sighting
+SET "location" = public.ST_SetSRID(public.ST_MakePoint("longitude"::float8, "latitude"::float8), 4326)::public.geography
+WHERE "location" IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tiger_last_seen_location ON sighting.tiger USING GIST ("last_seen_location") WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_location ON sighting.sighting USING GIST ("location");
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_sighting_needs_review;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "needs_review";
+    ALTER TABLE sighting.tiger DROP COLUMN IF EXISTS "max_sighting_distance";
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- max_sighting_distance overrides the default maximum distance, in meters, between two sightings of the tiger
+ALTER TABLE sighting.tiger ADD COLUMN IF NOT EXISTS "max_sighting_distance" numeric;
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "needs_review" boolean not null default false;
+
+CREATE INDEX IF NOT EXISTS idx_sighting_needs_review ON sighting.sighting("tiger_id") WHERE "needs_review";
+
+COMMIT;
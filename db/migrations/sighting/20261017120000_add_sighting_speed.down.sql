@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "speed";
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- speed is the speed, in km/h, implied by the distance and time from the previous sighting of the tiger
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "speed" numeric;
+
+COMMIT;
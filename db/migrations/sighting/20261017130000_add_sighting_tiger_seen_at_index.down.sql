@@ -0,0 +1,3 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_sighting_tiger_seen_at;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- serves the sightings of a tiger in seen_at order, e.g. to find the sightings adjacent to a backdated one
+CREATE INDEX IF NOT EXISTS idx_sighting_tiger_seen_at ON sighting.sighting("tiger_id", "seen_at", "id") WHERE "deleted_at" IS NULL;
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "reporter_email";
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "reporter_name";
+COMMIT;
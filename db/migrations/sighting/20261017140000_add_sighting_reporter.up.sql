@@ -0,0 +1,8 @@
+BEGIN;
+
+-- reporter_name and reporter_email identify who reported the sighting.
+-- reporter_email is used to notify the reporter when the tiger is sighted again, empty means no notification.
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "reporter_name" varchar NOT NULL DEFAULT '';
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "reporter_email" varchar NOT NULL DEFAULT '';
+
+COMMIT;
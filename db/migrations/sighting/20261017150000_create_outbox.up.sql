@@ -0,0 +1,21 @@
+BEGIN;
+
+-- outbox stores domain events written in the same transaction as the change they describe.
+-- The relay dispatches pending events to their handlers, retrying failures until max attempts, then marks them dead.
+CREATE TABLE IF NOT EXISTS sighting.outbox (
+    "id" BIGSERIAL not null primary key,
+    "event_type" varchar(64) not null,
+    "aggregate_id" int not null,
+    "payload" jsonb not null,
+    "status" varchar(16) not null default 'pending' CHECK ("status" IN ('pending', 'dispatched', 'dead')),
+    "attempts" int not null default 0,
+    "next_attempt_at" timestamp not null default now(),
+    "last_error" text not null default '',
+    "created_at" timestamp not null default now(),
+    "dispatched_at" timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON sighting.outbox("next_attempt_at", "id") WHERE "status" = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_dead ON sighting.outbox("id") WHERE "status" = 'dead';
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS sighting.webhook_delivery_attempt;
+    DROP TABLE IF EXISTS sighting.webhook_subscription;
+COMMIT;
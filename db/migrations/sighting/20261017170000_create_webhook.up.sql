@@ -0,0 +1,37 @@
+BEGIN;
+
+-- webhook_subscription stores the endpoints of partners pushed new sightings.
+-- A sighting is delivered when it matches both the tiger and the bounding box of the subscription, a null filter matching everything.
+-- min_longitude greater than max_longitude means the bounding box crosses the antimeridian.
+CREATE TABLE IF NOT EXISTS sighting.webhook_subscription (
+    "id" SERIAL not null primary key,
+    "url" text not null,
+    "secret" varchar(64) not null,
+    "tiger_id" int references sighting.tiger("id"),
+    "min_latitude" double precision,
+    "min_longitude" double precision,
+    "max_latitude" double precision,
+    "max_longitude" double precision,
+    "created_at" timestamp not null default now(),
+    "deleted_at" timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscription_active ON sighting.webhook_subscription("id") WHERE "deleted_at" IS NULL;
+
+-- webhook_delivery_attempt logs every POST of an event to a subscription, successful or not.
+-- status_code is 0 when no response was received.
+CREATE TABLE IF NOT EXISTS sighting.webhook_delivery_attempt (
+    "id" BIGSERIAL not null primary key,
+    "subscription_id" int not null references sighting.webhook_subscription("id"),
+    "delivery_id" varchar(64) not null,
+    "event_type" varchar(64) not null,
+    "attempt" int not null,
+    "status_code" int not null default 0,
+    "error" text not null default '',
+    "duration_ms" int not null,
+    "created_at" timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempt_subscription ON sighting.webhook_delivery_attempt("subscription_id", "id");
+
+COMMIT;
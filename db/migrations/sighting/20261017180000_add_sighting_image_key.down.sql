@@ -0,0 +1,6 @@
+BEGIN;
+    -- images already moved to the image store are not copied back to image_data
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_data;
+    ALTER TABLE sighting.sighting ALTER COLUMN "image_data" DROP DEFAULT;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "image_key";
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- image_key is the key of the sighting image in the image store, see IMAGE_STORE.
+-- Sightings recorded before have an empty image_key and their image in image_data as base64,
+-- until cmd/migrate-images moves it to the image store and empties image_data.
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "image_key" text not null default '';
+ALTER TABLE sighting.sighting ALTER COLUMN "image_data" SET DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_sighting_image_data ON sighting.sighting("id") WHERE "image_key" = '' AND "image_data" <> '';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_3;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_2;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_1;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_0;
+    ALTER TABLE sighting.sighting DROP COLUMN IF EXISTS "image_hash";
+COMMIT;
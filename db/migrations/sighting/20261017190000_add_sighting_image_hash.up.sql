@@ -0,0 +1,14 @@
+BEGIN;
+
+-- image_hash is the 64 bits perceptual hash (dHash) of the sighting image, to find near-duplicate images.
+-- It is null for sightings recorded before.
+ALTER TABLE sighting.sighting ADD COLUMN IF NOT EXISTS "image_hash" bigint;
+
+-- The hash is indexed in 4 bands of 16 bits. Hashes whose Hamming distance is at most 3 share at least one band,
+-- so near-duplicates are searched among the sightings sharing a band with the searched hash.
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_0 ON sighting.sighting(((image_hash >> 48) & 65535)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_1 ON sighting.sighting(((image_hash >> 32) & 65535)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_2 ON sighting.sighting(((image_hash >> 16) & 65535)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_3 ON sighting.sighting((image_hash & 65535)) WHERE "deleted_at" IS NULL;
+
+COMMIT;
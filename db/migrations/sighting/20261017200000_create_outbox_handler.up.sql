@@ -0,0 +1,12 @@
+BEGIN;
+
+-- outbox_handler records the handlers which handled an outbox event, so that a retried event is only dispatched
+-- to the handlers which failed, and the side effects of the others, e.g. notification jobs, are not repeated.
+CREATE TABLE IF NOT EXISTS sighting.outbox_handler (
+    "event_id" bigint not null REFERENCES sighting.outbox("id") ON DELETE CASCADE,
+    "handler" varchar(64) not null,
+    "handled_at" timestamp not null default now(),
+    PRIMARY KEY ("event_id", "handler")
+);
+
+COMMIT;
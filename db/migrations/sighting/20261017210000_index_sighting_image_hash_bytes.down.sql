@@ -0,0 +1,14 @@
+BEGIN;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_7;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_6;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_5;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_4;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_3;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_2;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_1;
+    DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_byte_0;
+    CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_0 ON sighting.sighting(((image_hash >> 48) & 65535)) WHERE "deleted_at" IS NULL;
+    CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_1 ON sighting.sighting(((image_hash >> 32) & 65535)) WHERE "deleted_at" IS NULL;
+    CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_2 ON sighting.sighting(((image_hash >> 16) & 65535)) WHERE "deleted_at" IS NULL;
+    CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_3 ON sighting.sighting((image_hash & 65535)) WHERE "deleted_at" IS NULL;
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- The hash is indexed in 8 bands of 8 bits instead of 4 bands of 16 bits. Hashes whose Hamming distance is at most 7
+-- share at least one band, so near-duplicates of a re-encoded or rescaled image are searched among the sightings
+-- sharing a band with the searched hash.
+DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_0;
+DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_1;
+DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_2;
+DROP INDEX IF EXISTS sighting.idx_sighting_image_hash_3;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_0 ON sighting.sighting(((image_hash >> 56) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_1 ON sighting.sighting(((image_hash >> 48) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_2 ON sighting.sighting(((image_hash >> 40) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_3 ON sighting.sighting(((image_hash >> 32) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_4 ON sighting.sighting(((image_hash >> 24) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_5 ON sighting.sighting(((image_hash >> 16) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_6 ON sighting.sighting(((image_hash >> 8) & 255)) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS idx_sighting_image_hash_byte_7 ON sighting.sighting((image_hash & 255)) WHERE "deleted_at" IS NULL;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- job stores the background jobs of the worker when WORKER_QUEUE is postgres.
+-- Workers claim pending jobs due to run with FOR UPDATE SKIP LOCKED and postpone run_at by their lease while running them.
+CREATE TABLE IF NOT EXISTS worker.job (
+    "id" BIGSERIAL not null primary key,
+    "job_type" varchar(64) not null,
+    "payload" bytea not null,
+    "status" varchar(16) not null default 'pending' CHECK ("status" IN ('pending', 'done', 'dead')),
+    "attempts" int not null default 0,
+    "run_at" timestamp not null default now(),
+    "last_error" text not null default '',
+    "created_at" timestamp not null default now(),
+    "finished_at" timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_pending ON worker.job("job_type", "run_at", "id") WHERE "status" = 'pending';
+CREATE INDEX IF NOT EXISTS idx_job_dead ON worker.job("job_type", "id") WHERE "status" = 'dead';
+
+COMMIT;
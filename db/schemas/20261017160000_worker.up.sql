@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE SCHEMA worker;
+
+COMMIT;
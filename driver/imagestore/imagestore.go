@@ -0,0 +1,102 @@
+// Package imagestore provides a storage of images by key, in the local filesystem or in an S3 compatible storage
+package imagestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/driver/s3"
+)
+
+var (
+	// ErrNotFound is returned when no image is stored under the key
+	ErrNotFound = errors.New("image not found")
+	// ErrInvalidKey is returned when the key is not a clean relative slash separated path
+	ErrInvalidKey = errors.New("invalid image key")
+)
+
+// Image is an encoded image with its media type, e.g. image/png
+type Image struct {
+	Data        []byte
+	ContentType string
+}
+
+// ImageReader reads an image from the store as it is read, e.g. to serve it with http.ServeContent
+// The caller must close it.
+type ImageReader struct {
+	io.ReadSeekCloser
+	ContentType string
+	Size        int64
+	// ETag is a quoted entity tag which changes whenever the image stored under the key is replaced
+	ETag    string
+	ModTime time.Time
+}
+
+// NewImageReader returns a reader of an image kept in memory, e.g. one which is not in a store
+// The entity tag is the hash of the image.
+func NewImageReader(image *Image) *ImageReader {
+	sum := sha256.Sum256(image.Data)
+	return &ImageReader{
+		ReadSeekCloser: nopCloser{bytes.NewReader(image.Data)},
+		ContentType:    image.ContentType,
+		Size:           int64(len(image.Data)),
+		ETag:           `"` + hex.EncodeToString(sum[:]) + `"`,
+	}
+}
+
+// nopCloser adds a Close doing nothing to a ReadSeeker.
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// ImageStore defines an interface for storing images by key
+// A key is a clean relative slash separated path, e.g. sighting/1/<uuid>.png.
+type ImageStore interface {
+	// Put stores the image under the key, replacing the image stored under it if any
+	Put(ctx context.Context, key string, image *Image) error
+	// Get returns the image stored under the key. It returns ErrNotFound if there is none.
+	Get(ctx context.Context, key string) (*Image, error)
+	// Open returns a reader of the image stored under the key. It returns ErrNotFound if there is none.
+	Open(ctx context.Context, key string) (*ImageReader, error)
+	// Delete removes the image stored under the key. Removing a missing image is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewImageStore initialize the image store of the configured backend
+// The s3 backend is configured by s3Cfg.
+func NewImageStore(cfg *config.ImageStore, s3Cfg *config.S3) ImageStore {
+	if cfg.Backend == config.ImageStoreS3 {
+		return NewS3Store(s3.NewClient(s3Cfg))
+	}
+	return NewLocalStore(cfg.Dir)
+}
+
+// validateKey returns ErrInvalidKey unless the key is a clean relative slash separated path inside the store.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// contentTypeOf returns the media type of an image from the extension of its key.
+func contentTypeOf(key string) string {
+	if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
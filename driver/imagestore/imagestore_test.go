@@ -0,0 +1,229 @@
+package imagestore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/test/fakes3"
+)
+
+type ImageStoreTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+// newStores returns a store of each backend, the s3 one storing images in a fake S3 compatible storage.
+func newStores(t *testing.T) map[string]imagestore.ImageStore {
+	server := fakes3.NewServer(t, "images")
+	return map[string]imagestore.ImageStore{
+		config.ImageStoreLocal: imagestore.NewImageStore(&config.ImageStore{Backend: config.ImageStoreLocal, Dir: t.TempDir()}, nil),
+		config.ImageStoreS3: imagestore.NewImageStore(&config.ImageStore{Backend: config.ImageStoreS3}, &config.S3{
+			Endpoint:  server.URL(),
+			Region:    fakes3.Region,
+			Bucket:    "images",
+			AccessKey: fakes3.AccessKey,
+			SecretKey: fakes3.SecretKey,
+			Timeout:   5 * time.Second,
+		}),
+	}
+}
+
+func TestImageStore(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	png := &imagestore.Image{Data: []byte("\x89PNG"), ContentType: "image/png"}
+
+	testCases := []ImageStoreTestCase{
+		{
+			testcaseName: "successfully put, get and delete image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", png), backend)
+
+					got, err := store.Get(mockCtx, "sighting/1/a.png")
+					require.NoError(t, err, backend)
+					require.Equal(t, png, got, backend)
+
+					require.NoError(t, store.Delete(mockCtx, "sighting/1/a.png"), backend)
+					_, err = store.Get(mockCtx, "sighting/1/a.png")
+					require.True(t, errors.Is(err, imagestore.ErrNotFound), backend)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully replace image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", &imagestore.Image{Data: []byte("old"), ContentType: "image/png"}), backend)
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", png), backend)
+
+					got, err := store.Get(mockCtx, "sighting/1/a.png")
+					require.NoError(t, err, backend)
+					require.Equal(t, png, got, backend)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully open image and read it from any offset",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", &imagestore.Image{Data: []byte("0123456789"), ContentType: "image/png"}), backend)
+
+					reader, err := store.Open(mockCtx, "sighting/1/a.png")
+					require.NoError(t, err, backend)
+					require.Equal(t, "image/png", reader.ContentType, backend)
+					require.Equal(t, int64(10), reader.Size, backend)
+					require.NotEmpty(t, reader.ETag, backend)
+					require.False(t, reader.ModTime.IsZero(), backend)
+
+					data, err := io.ReadAll(reader)
+					require.NoError(t, err, backend)
+					require.Equal(t, "0123456789", string(data), backend)
+
+					offset, err := reader.Seek(-4, io.SeekEnd)
+					require.NoError(t, err, backend)
+					require.Equal(t, int64(6), offset, backend)
+					part := make([]byte, 2)
+					_, err = io.ReadFull(reader, part)
+					require.NoError(t, err, backend)
+					require.Equal(t, "67", string(part), backend)
+
+					_, err = reader.Seek(2, io.SeekStart)
+					require.NoError(t, err, backend)
+					_, err = io.ReadFull(reader, part)
+					require.NoError(t, err, backend)
+					require.Equal(t, "23", string(part), backend)
+					require.NoError(t, reader.Close(), backend)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully change entity tag when image is replaced",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", &imagestore.Image{Data: []byte("old"), ContentType: "image/png"}), backend)
+					before, err := store.Open(mockCtx, "sighting/1/a.png")
+					require.NoError(t, err, backend)
+					require.NoError(t, before.Close(), backend)
+
+					require.NoError(t, store.Put(mockCtx, "sighting/1/a.png", png), backend)
+					after, err := store.Open(mockCtx, "sighting/1/a.png")
+					require.NoError(t, err, backend)
+					require.NoError(t, after.Close(), backend)
+					require.NotEqual(t, before.ETag, after.ETag, backend)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully delete missing image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					require.NoError(t, store.Delete(mockCtx, "sighting/1/a.png"), backend)
+				}
+			},
+		},
+		{
+			testcaseName: "Error image not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					got, err := store.Get(mockCtx, "sighting/1/a.png")
+					require.Nil(t, got, backend)
+					require.True(t, errors.Is(err, imagestore.ErrNotFound), backend)
+
+					reader, err := store.Open(mockCtx, "sighting/1/a.png")
+					require.Nil(t, reader, backend)
+					require.True(t, errors.Is(err, imagestore.ErrNotFound), backend)
+				}
+			},
+		},
+		{
+			testcaseName: "Error invalid key",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				for backend, store := range newStores(t) {
+					for _, key := range []string{"", "/etc/passwd", "../a.png", "sighting/../../a.png", "sighting//a.png", `sighting\a.png`} {
+						require.True(t, errors.Is(store.Put(mockCtx, key, png), imagestore.ErrInvalidKey), backend+" "+key)
+						_, err := store.Get(mockCtx, key)
+						require.True(t, errors.Is(err, imagestore.ErrInvalidKey), backend+" "+key)
+						_, err = store.Open(mockCtx, key)
+						require.True(t, errors.Is(err, imagestore.ErrInvalidKey), backend+" "+key)
+						require.True(t, errors.Is(store.Delete(mockCtx, key), imagestore.ErrInvalidKey), backend+" "+key)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []ImageStoreTestCase{
+		{
+			testcaseName: "successfully store image as a file named by its key",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				dir := t.TempDir()
+
+				err := imagestore.NewLocalStore(dir).Put(mockCtx, "sighting/1/a.jpeg", &imagestore.Image{Data: []byte("jpeg"), ContentType: "image/jpeg"})
+				require.NoError(t, err)
+
+				data, err := os.ReadFile(filepath.Join(dir, "sighting", "1", "a.jpeg"))
+				require.NoError(t, err)
+				require.Equal(t, []byte("jpeg"), data)
+				entries, err := os.ReadDir(filepath.Join(dir, "sighting", "1"))
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+			},
+		},
+		{
+			testcaseName: "successfully get media type from key extension",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				store := imagestore.NewLocalStore(t.TempDir())
+				for key, contentType := range map[string]string{"a.jpeg": "image/jpeg", "a.png": "image/png", "a": "application/octet-stream"} {
+					require.NoError(t, store.Put(mockCtx, key, &imagestore.Image{Data: []byte("image"), ContentType: contentType}))
+
+					got, err := store.Get(mockCtx, key)
+					require.NoError(t, err)
+					require.Equal(t, contentType, got.ContentType)
+				}
+			},
+		},
+		{
+			testcaseName: "Error when create directory",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				dir := t.TempDir()
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "sighting"), nil, 0o644))
+
+				err := imagestore.NewLocalStore(dir).Put(mockCtx, "sighting/1/a.png", &imagestore.Image{Data: []byte("png")})
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,114 @@
+package imagestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore stores images as files of a directory of the local filesystem
+// The media type of an image is given by the extension of its key.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore initialize LocalStore instance
+// The directory is created on the first Put if it doesn't exist.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Put stores the image under the key, replacing the image stored under it if any
+// The image is written to a temporary file renamed once complete, so that a partial image is never read.
+func (l *LocalStore) Put(_ context.Context, key string, image *Image) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(image.Data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(f.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// Get returns the image stored under the key. It returns ErrNotFound if there is none.
+func (l *LocalStore) Get(_ context.Context, key string) (*Image, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Image{Data: data, ContentType: contentTypeOf(key)}, nil
+}
+
+// Open returns a reader of the image stored under the key. It returns ErrNotFound if there is none.
+// The entity tag is made of the modification time and the size of the file, as images are replaced by renaming.
+func (l *LocalStore) Open(_ context.Context, key string) (*ImageReader, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &ImageReader{
+		ReadSeekCloser: f,
+		ContentType:    contentTypeOf(key),
+		Size:           info.Size(),
+		ETag:           fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+		ModTime:        info.ModTime(),
+	}, nil
+}
+
+// Delete removes the image stored under the key. Removing a missing image is not an error.
+func (l *LocalStore) Delete(_ context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the name of the file storing the image of the key.
+func (l *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,126 @@
+package imagestore
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ibrahimker/tigerhall-kittens/driver/s3"
+)
+
+// S3Store stores images as objects of a bucket in an S3 compatible storage
+type S3Store struct {
+	client *s3.Client
+}
+
+// NewS3Store initialize S3Store instance
+func NewS3Store(client *s3.Client) *S3Store {
+	return &S3Store{client: client}
+}
+
+// Put stores the image under the key, replacing the image stored under it if any
+func (s *S3Store) Put(ctx context.Context, key string, image *Image) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.client.PutObject(ctx, key, image.Data, image.ContentType)
+}
+
+// Get returns the image stored under the key. It returns ErrNotFound if there is none.
+func (s *S3Store) Get(ctx context.Context, key string) (*Image, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	object, err := s.client.GetObject(ctx, key)
+	if errors.Is(err, s3.ErrNoSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Image{Data: object.Data, ContentType: object.ContentType}, nil
+}
+
+// Open returns a reader of the image stored under the key. It returns ErrNotFound if there is none.
+// The image is streamed from the storage from the offset of the first read after opening or seeking.
+func (s *S3Store) Open(ctx context.Context, key string) (*ImageReader, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	info, err := s.client.HeadObject(ctx, key)
+	if errors.Is(err, s3.ErrNoSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ImageReader{
+		ReadSeekCloser: &objectReader{ctx: ctx, client: s.client, key: key, size: info.Size},
+		ContentType:    info.ContentType,
+		Size:           info.Size,
+		ETag:           info.ETag,
+		ModTime:        info.LastModified,
+	}, nil
+}
+
+// Delete removes the image stored under the key. Removing a missing image is not an error.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.client.DeleteObject(ctx, key)
+}
+
+// objectReader reads an object of the storage with a ranged request from the current offset.
+// Seeking to another offset closes the request, the next read sends a new one.
+type objectReader struct {
+	// ctx is the context of Open, requests sent by Read end with it
+	ctx    context.Context
+	client *s3.Client
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.client.GetObjectRange(r.ctx, r.key, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("seek to a negative offset")
+	}
+	if offset != r.offset {
+		_ = r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *objectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -15,7 +15,10 @@ var RedisClient *goredis.Client
 // Redis defines an interface for redis repository
 type Redis interface {
 	Del(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
 	Fetch(ctx context.Context, key string, value interface{}, expiration time.Duration, callback func() (interface{}, error)) error
+	Publish(ctx context.Context, channel string, value interface{}) error
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
 }
 
 // RepoProvider holds client for redis
@@ -39,6 +42,12 @@ func (r *RepoProvider) Del(ctx context.Context, keys ...string) error {
 	return err
 }
 
+// Incr increments the integer stored at key by one and returns the new value
+// Missing key is treated as 0.
+func (r *RepoProvider) Incr(ctx context.Context, key string) (int64, error) {
+	return r.rds.Incr(ctx, key).Result()
+}
+
 // Fetch retrieves data from redis
 // If data not found, it repopulate the data into redis
 func (r *RepoProvider) Fetch(ctx context.Context, key string, value interface{}, expiration time.Duration, callback func() (interface{}, error)) error {
@@ -66,6 +75,51 @@ func (r *RepoProvider) Fetch(ctx context.Context, key string, value interface{},
 	return json.Unmarshal(setBytes, value)
 }
 
+// Publish sends the JSON encoded value to every subscriber of the channel
+func (r *RepoProvider) Publish(ctx context.Context, channel string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.rds.Publish(ctx, channel, bytes).Err()
+}
+
+// Subscribe subscribes to the channel and returns the messages published to it from now on
+// The subscription ends when ctx is done, then the returned channel is closed.
+// A lost connection is reopened, messages published meanwhile are missed.
+func (r *RepoProvider) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := r.rds.Subscribe(ctx, channel)
+	// wait for the confirmation, so that no message published after Subscribe returns is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	messages := make(chan []byte)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
+
 func get(ctx context.Context, key string, value interface{}) error {
 	bytes, err := RedisClient.Get(ctx, key).Bytes()
 	if err != nil {
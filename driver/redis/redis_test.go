@@ -8,7 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
 	redismock "github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ibrahimker/tigerhall-kittens/driver/redis"
@@ -72,6 +75,25 @@ func (s *RedisTestSuite) TestDel() {
 	})
 }
 
+func (s *RedisTestSuite) TestIncr() {
+	ctx := context.Background()
+
+	s.Run("Incr returns error", func() {
+		key := "incr-cache-key"
+		s.mock.ExpectIncr(cacheKey(key)).SetErr(errors.New("redis incr error"))
+		_, err := s.client.Incr(ctx, cacheKey(key))
+		s.Error(err)
+	})
+
+	s.Run("Success increment key", func() {
+		key := "incr-cache-key"
+		s.mock.ExpectIncr(cacheKey(key)).SetVal(2)
+		val, err := s.client.Incr(ctx, cacheKey(key))
+		s.NoError(err)
+		s.Equal(int64(2), val)
+	})
+}
+
 func (s *RedisTestSuite) TestFetch() {
 	key := "redis-fetch-key"
 	ctx := context.Background()
@@ -164,3 +186,61 @@ func (s *RedisTestSuite) TestFetch() {
 func cacheKey(key string) string {
 	return fmt.Sprintf("%s:%s", authRedisBaseKey, key)
 }
+
+func (s *RedisTestSuite) TestPublish() {
+	ctx := context.Background()
+	channel := cacheKey("publish-channel")
+
+	s.Run("Publish returns error", func() {
+		s.mock.ExpectPublish(channel, []byte(`{"Val":"amazing-value"}`)).SetErr(errors.New("redis publish error"))
+
+		err := s.client.Publish(ctx, channel, &RedisTestStruct{Val: "amazing-value"})
+
+		s.Error(err)
+	})
+
+	s.Run("Marshal returns error", func() {
+		err := s.client.Publish(ctx, channel, make(chan int))
+
+		s.Error(err)
+	})
+
+	s.Run("Success publish JSON encoded value", func() {
+		s.mock.ExpectPublish(channel, []byte(`{"Val":"amazing-value"}`)).SetVal(1)
+
+		err := s.client.Publish(ctx, channel, &RedisTestStruct{Val: "amazing-value"})
+
+		s.NoError(err)
+		s.NoError(s.mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("Subscribe returns error", func(t *testing.T) {
+		server, _ := miniredis.Run()
+		rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+		server.Close()
+
+		messages, err := redis.NewRedisClient(rds).Subscribe(context.Background(), "channel")
+
+		require.Error(t, err)
+		require.Nil(t, messages)
+	})
+
+	t.Run("Success receive published messages until context is done", func(t *testing.T) {
+		server, _ := miniredis.Run()
+		defer server.Close()
+		rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+		ctx, cancel := context.WithCancel(context.Background())
+		client := redis.NewRedisClient(rds)
+
+		messages, err := client.Subscribe(ctx, "channel")
+		require.NoError(t, err)
+		require.NoError(t, client.Publish(ctx, "channel", &RedisTestStruct{Val: "amazing-value"}))
+		require.Equal(t, []byte(`{"Val":"amazing-value"}`), <-messages)
+
+		cancel()
+		for range messages {
+		}
+	})
+}
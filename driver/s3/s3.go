@@ -0,0 +1,224 @@
+// Package s3 provides a client of the objects of a bucket in an S3 compatible storage, e.g. AWS S3 or MinIO
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+)
+
+// ErrNoSuchKey is returned when the requested object doesn't exist
+var ErrNoSuchKey = errors.New("s3: no such key")
+
+// maxErrorBody is the maximum number of bytes of an error response read to get the error code
+const maxErrorBody = 64 << 10
+
+// Error is an error response of the storage
+type Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return fmt.Sprintf("s3: %s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// Object is the content of an object with its metadata
+type Object struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// ObjectInfo is the metadata of an object
+type ObjectInfo struct {
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Client reads and writes the objects of a bucket
+// Objects are addressed path style, i.e. <endpoint>/<bucket>/<key>.
+type Client struct {
+	endpoint     string
+	bucket       string
+	signer       *Signer
+	httpClient   *http.Client
+	streamClient *http.Client
+}
+
+// NewClient initialize S3 client instance
+// Every request must end before the configured timeout, except reading the body returned by GetObjectRange.
+func NewClient(cfg *config.S3) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = cfg.Timeout
+	return &Client{
+		endpoint:     strings.TrimSuffix(cfg.Endpoint, "/"),
+		bucket:       cfg.Bucket,
+		signer:       NewSigner(cfg.AccessKey, cfg.SecretKey, cfg.Region),
+		httpClient:   &http.Client{Timeout: cfg.Timeout},
+		streamClient: &http.Client{Transport: transport},
+	}
+}
+
+// PutObject stores the data under the key, replacing the object stored under it if any
+func (c *Client) PutObject(ctx context.Context, key string, data []byte, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.do(c.httpClient, req, data)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// GetObject returns the object stored under the key
+// It returns ErrNoSuchKey if there is none.
+func (c *Client) GetObject(ctx context.Context, key string) (*Object, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(c.httpClient, req, nil)
+	if err != nil {
+		return nil, noSuchKey(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Data: data, ContentType: resp.Header.Get("Content-Type"), ETag: resp.Header.Get("ETag")}, nil
+}
+
+// HeadObject returns the metadata of the object stored under the key
+// It returns ErrNoSuchKey if there is none.
+func (c *Client) HeadObject(ctx context.Context, key string) (*ObjectInfo, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(c.httpClient, req, nil)
+	if err != nil {
+		return nil, noSuchKey(err)
+	}
+	_ = resp.Body.Close()
+
+	// a missing or invalid Last-Modified leaves it zero
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &ObjectInfo{
+		Size:         resp.ContentLength,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: lastModified,
+	}, nil
+}
+
+// GetObjectRange returns the content of the object stored under the key from offset to its end
+// The content is streamed from the storage as it is read, the caller must close it.
+// It returns ErrNoSuchKey if there is none.
+func (c *Client) GetObjectRange(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+
+	resp, err := c.do(c.streamClient, req, nil)
+	if err != nil {
+		return nil, noSuchKey(err)
+	}
+	return resp.Body, nil
+}
+
+// DeleteObject removes the object stored under the key
+// Removing a missing object is not an error.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(c.httpClient, req, nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// newRequest creates a request to the object stored under the key.
+func (c *Client) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path += "/" + c.bucket + "/" + key
+	// escape the path the way it is signed, so that the storage computes the same signature
+	u.RawPath = escape(u.Path, false)
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	return http.NewRequestWithContext(ctx, method, u.String(), r)
+}
+
+// do signs and sends the request with the HTTP client. A response with an error status is returned as *Error.
+func (c *Client) do(httpClient *http.Client, req *http.Request, body []byte) (*http.Response, error) {
+	payloadHash := EmptyPayloadHash
+	if body != nil {
+		payloadHash = HashPayload(body)
+	}
+	c.signer.Sign(req, payloadHash, time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	serr := &Error{StatusCode: resp.StatusCode}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if xml.Unmarshal(b, serr) != nil || serr.Code == "" {
+		// some proxies in front of the storage answer without an error body
+		serr.Code = http.StatusText(resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound {
+			serr.Code = "NoSuchKey"
+		}
+	}
+	return nil, serr
+}
+
+// noSuchKey returns ErrNoSuchKey if err is a NoSuchKey error response, err otherwise.
+func noSuchKey(err error) error {
+	var serr *Error
+	if errors.As(err, &serr) && serr.Code == "NoSuchKey" {
+		return ErrNoSuchKey
+	}
+	return err
+}
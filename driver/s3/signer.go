@@ -0,0 +1,158 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// signingAlgorithm is the algorithm of AWS Signature Version 4
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	// signingService is the service name of the signing scope
+	signingService = "s3"
+	// amzDateFormat is the format of X-Amz-Date header
+	amzDateFormat = "20060102T150405Z"
+	// EmptyPayloadHash is the hex encoded SHA256 of an empty body
+	EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// UnsignedPayload replaces the payload hash of a request whose body is not signed
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Signer signs requests to an S3 compatible storage with AWS Signature Version 4
+type Signer struct {
+	accessKey string
+	secretKey string
+	region    string
+}
+
+// NewSigner initialize Signer instance
+func NewSigner(accessKey, secretKey, region string) *Signer {
+	return &Signer{accessKey: accessKey, secretKey: secretKey, region: region}
+}
+
+// Sign sets X-Amz-Date, X-Amz-Content-Sha256 and Authorization headers of the request
+// payloadHash is the hex encoded SHA256 of the body, or UnsignedPayload. The host and every header already set are signed.
+func (s *Signer) Sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	s.sign(req, signingService, payloadHash, now)
+}
+
+// sign sets X-Amz-Date and Authorization headers of the request to the service.
+func (s *Signer) sign(req *http.Request, service, payloadHash string, now time.Time) {
+	now = now.UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+
+	scope := strings.Join([]string{now.Format("20060102"), s.region, service, "aws4_request"}, "/")
+	canonicalHeaders, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{signingAlgorithm, now.Format(amzDateFormat), scope, HashPayload([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	for _, part := range []string{s.region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalHeaders returns the lower case headers to sign, one per line, and their names separated by semicolons.
+// Values are trimmed with their sequential spaces collapsed, repeated and folded values are joined by commas.
+// Authorization and User-Agent are not signed, the latter may be changed by proxies.
+func canonicalHeaders(req *http.Request) (headers string, signedHeaders string) {
+	values := map[string]string{"host": req.Host}
+	if req.Host == "" {
+		values["host"] = req.URL.Host
+	}
+	for name, v := range req.Header {
+		name = strings.ToLower(name)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		var trimmed []string
+		for i := range v {
+			// the lines of a folded value are separated like repeated values
+			for _, line := range strings.Split(v[i], "\n") {
+				trimmed = append(trimmed, strings.Join(strings.Fields(line), " "))
+			}
+		}
+		values[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + values[name] + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+// canonicalURI returns the path of the URL with every segment escaped the way AWS does.
+func canonicalURI(u *url.URL) string {
+	if u.Path == "" {
+		return "/"
+	}
+	return escape(u.Path, false)
+}
+
+// canonicalQuery returns the escaped query parameters of the URL sorted by name then value.
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, v := range values {
+			params = append(params, escape(name, true)+"="+escape(v, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// escape percent-encodes every byte of s except the unreserved characters, and slashes unless encodeSlash is set.
+func escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// HashPayload returns the hex encoded SHA256 of the payload, as expected by Sign.
+func HashPayload(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,102 @@
+// Package smtp provides an email sender using an SMTP server
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	netsmtp "net/smtp"
+	"time"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+)
+
+// Sender defines an interface for sending emails
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// Client sends plain text emails through an SMTP server
+type Client struct {
+	host    string
+	address string
+	from    string
+	auth    netsmtp.Auth
+	timeout time.Duration
+}
+
+// NewClient initialize SMTP client instance
+// PLAIN authentication is used if cfg.Username is set.
+func NewClient(cfg *config.SMTP) *Client {
+	c := &Client{
+		host:    cfg.Host,
+		address: net.JoinHostPort(cfg.Host, cfg.Port),
+		from:    cfg.From,
+		timeout: cfg.Timeout,
+	}
+	if cfg.Username != "" {
+		c.auth = netsmtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return c
+}
+
+// Send sends a plain text email to the given address
+// The whole exchange with the SMTP server must end before the configured timeout.
+func (c *Client) Send(ctx context.Context, to, subject, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := netsmtp.NewClient(conn, c.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if c.auth != nil {
+		if err = client.Auth(c.auth); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(c.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(composeMessage(c.from, to, subject, body)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func composeMessage(from, to, subject, body string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
@@ -0,0 +1,112 @@
+package smtp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/driver/smtp"
+	"github.com/ibrahimker/tigerhall-kittens/test/fakesmtp"
+)
+
+type SMTPTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+func smtpConfig(server *fakesmtp.Server) *config.SMTP {
+	return &config.SMTP{
+		Host:    server.Host(),
+		Port:    server.Port(),
+		From:    "noreply@tigerhall-kittens.local",
+		Timeout: 5 * time.Second,
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []SMTPTestCase{
+		{
+			testcaseName: "successfully send email",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server := fakesmtp.NewServer(t)
+
+				err := smtp.NewClient(smtpConfig(server)).Send(mockCtx, "ranger@example.com", "Tiger spotted", "Tiger-1 was seen again.\n")
+				require.NoError(t, err)
+
+				messages := server.Messages()
+				require.Len(t, messages, 1)
+				require.Equal(t, "noreply@tigerhall-kittens.local", messages[0].From)
+				require.Equal(t, []string{"ranger@example.com"}, messages[0].To)
+				require.Contains(t, messages[0].Data, "To: ranger@example.com\n")
+				require.Contains(t, messages[0].Data, "Subject: Tiger spotted\n")
+				require.Contains(t, messages[0].Data, "Content-Type: text/plain; charset=utf-8\n")
+				require.Contains(t, messages[0].Data, "\n\nTiger-1 was seen again.\n")
+			},
+		},
+		{
+			testcaseName: "successfully send email with non ascii subject",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server := fakesmtp.NewServer(t)
+
+				err := smtp.NewClient(smtpConfig(server)).Send(mockCtx, "ranger@example.com", "Harimau terlihat – lagi", "body")
+				require.NoError(t, err)
+
+				messages := server.Messages()
+				require.Len(t, messages, 1)
+				require.Contains(t, messages[0].Data, "Subject: =?utf-8?q?Harimau_terlihat_=E2=80=93_lagi?=\n")
+			},
+		},
+		{
+			testcaseName: "successfully send email with authentication",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server := fakesmtp.NewServer(t, fakesmtp.WithAuth("user", "secret"))
+				cfg := smtpConfig(server)
+				cfg.Username = "user"
+				cfg.Password = "secret"
+
+				err := smtp.NewClient(cfg).Send(mockCtx, "ranger@example.com", "Tiger spotted", "body")
+				require.NoError(t, err)
+				require.Len(t, server.Messages(), 1)
+			},
+		},
+		{
+			testcaseName: "Error wrong credentials",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server := fakesmtp.NewServer(t, fakesmtp.WithAuth("user", "secret"))
+				cfg := smtpConfig(server)
+				cfg.Username = "user"
+				cfg.Password = "wrong"
+
+				err := smtp.NewClient(cfg).Send(mockCtx, "ranger@example.com", "Tiger spotted", "body")
+				require.Error(t, err)
+				require.Empty(t, server.Messages())
+			},
+		},
+		{
+			testcaseName: "Error server unreachable",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server := fakesmtp.NewServer(t)
+				cfg := smtpConfig(server)
+				server.Close()
+
+				err := smtp.NewClient(cfg).Send(mockCtx, "ranger@example.com", "Tiger spotted", "body")
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
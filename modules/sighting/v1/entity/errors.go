@@ -0,0 +1,19 @@
+package entity
+
+import (
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+)
+
+var (
+	// ErrTigerNotFound is returned when the requested tiger doesn't exist or has been deleted.
+	ErrTigerNotFound = apperrors.NewNotFoundError("tiger not found")
+	// ErrSightingNotFound is returned when the requested sighting doesn't exist, or its tiger has been deleted.
+	ErrSightingNotFound = apperrors.NewNotFoundError("sighting not found")
+	// ErrSightingImageNotFound is returned when the requested image of a sighting doesn't exist.
+	ErrSightingImageNotFound = apperrors.NewNotFoundError("sighting image not found")
+	// ErrConcurrentUpdate is returned when a write loses against a concurrent write on the same tiger.
+	// The operation is safe to retry.
+	ErrConcurrentUpdate = apperrors.NewConflictError("tiger was updated concurrently, please retry")
+	// ErrWebhookSubscriptionNotFound is returned when the requested webhook subscription doesn't exist or has been deleted.
+	ErrWebhookSubscriptionNotFound = apperrors.NewNotFoundError("webhook subscription not found")
+)
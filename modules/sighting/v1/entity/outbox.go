@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+)
+
+// Types of domain events written to the outbox
+const (
+	// EventTigerCreated is written when a tiger is created. Its payload is a TigerEvent.
+	EventTigerCreated = "TigerCreated"
+	// EventTigerUpdated is written when a tiger is updated through UpdateTiger. Its payload is a TigerEvent.
+	EventTigerUpdated = "TigerUpdated"
+	// EventTigerDeleted is written when a tiger is deleted. Its payload is a TigerEvent without name.
+	EventTigerDeleted = "TigerDeleted"
+	// EventSightingRecorded is written when a sighting is created. Its payload is a SightingRecordedEvent.
+	EventSightingRecorded = "SightingRecorded"
+)
+
+// OutboxEvent is a struct to model a domain event waiting in the outbox to be dispatched
+// Payload is the JSON encoded event. Attempts counts the dispatches, including the current one.
+// Handled are the names of the handlers which handled the event in a previous dispatch.
+type OutboxEvent struct {
+	ID          int64
+	Type        string
+	AggregateID int32
+	Payload     []byte
+	Attempts    int32
+	CreatedAt   time.Time
+	Handled     []string
+}
+
+// TigerEvent is a struct to model the payload of EventTigerCreated, EventTigerUpdated and EventTigerDeleted
+type TigerEvent struct {
+	TigerID int32  `json:"tiger_id"`
+	Name    string `json:"name"`
+}
+
+// SightingRecordedEvent is a struct to model the payload of EventSightingRecorded
+// PriorReporters are the emails of everyone who reported the tiger before the sighting.
+type SightingRecordedEvent struct {
+	SightingID     int32     `json:"sighting_id"`
+	TigerID        int32     `json:"tiger_id"`
+	TigerName      string    `json:"tiger_name"`
+	SeenAt         time.Time `json:"seen_at"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	ReporterName   string    `json:"reporter_name"`
+	PriorReporters []string  `json:"prior_reporters"`
+}
@@ -22,19 +22,162 @@ type Tiger struct {
 	LastSeenTimestamp time.Time
 	LastSeenLatitude  float64
 	LastSeenLongitude float64
-	CreatedAt         sql.NullTime
-	UpdatedAt         sql.NullTime
+	// MaxSightingDistance overrides the default maximum distance in meters between two sightings of the tiger
+	MaxSightingDistance sql.NullFloat64
+	CreatedAt           sql.NullTime
+	UpdatedAt           sql.NullTime
+}
+
+// NearbyTiger is a struct to model tiger data with its distance from a searched point
+// Distance is in meters.
+type NearbyTiger struct {
+	Tiger
+	Distance float64
+}
+
+// NearbyQuery is a struct to model a search of tigers around a point
+// Zero Since means the search is not filtered by last seen timestamp.
+type NearbyQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Since     time.Time
+	Limit     int32
 }
 
 // Sighting is a struct to model sighting of tiger data
 // we use float64 in lat/long because we don't need to calculate the distance so precise
 type Sighting struct {
-	ID        int32
-	TigerID   int32
-	SeenAt    time.Time
-	Latitude  float64
-	Longitude float64
+	ID      int32
+	TigerID int32
+	// SeenAt, Latitude and Longitude of a new sighting are read from the EXIF of its image if SeenAt is unset,
+	// respectively if LocationOmitted is true.
+	SeenAt          time.Time
+	Latitude        float64
+	Longitude       float64
+	LocationOmitted bool
+	// ImageData is the image as a base64 data URI. It is not stored in database, the image store keeps it under ImageKey.
+	// Sightings recorded before the image store still have their image in database with an empty ImageKey.
 	ImageData string
+	ImageKey  string
+	// ImageHash is the perceptual hash of the image, to find near-duplicate images.
+	// It is zero for sightings recorded before the hash was stored, and for images too plain to be compared.
+	ImageHash uint64
+	// ReporterName and ReporterEmail identify who reported the sighting
+	// Empty ReporterEmail means the reporter doesn't want to be notified of next sightings.
+	ReporterName  string
+	ReporterEmail string
+	// NeedsReview means the sighting was accepted although it breaks a rule, so a ranger should check it
+	NeedsReview bool
+	// Speed is the speed in km/h implied by the distance and time from the previous sighting
+	// It is null if there is no previous sighting or if the tiger moved between two sightings at the same time.
+	Speed     sql.NullFloat64
 	CreatedAt sql.NullTime
 	UpdatedAt sql.NullTime
 }
+
+// SimilarSighting is a struct to model a sighting whose image is similar to a searched image
+// Distance is the number of bits, out of 64, by which the perceptual hashes of the images differ.
+type SimilarSighting struct {
+	Sighting
+	Distance int32
+}
+
+// SimilarImageQuery is a struct to model a search of sightings whose image is similar to ImageData
+// Null MaxDistance means the default maximum distance.
+type SimilarImageQuery struct {
+	ImageData   string
+	MaxDistance sql.NullInt32
+	Limit       int32
+}
+
+// SightingNotification is a struct to model a message telling a previous reporter that a tiger was sighted again
+// It is the payload of the job sending the message.
+type SightingNotification struct {
+	Recipient string    `json:"recipient"`
+	TigerID   int32     `json:"tiger_id"`
+	TigerName string    `json:"tiger_name"`
+	SeenAt    time.Time `json:"seen_at"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+}
+
+// WatchedSighting is a struct to model a new sighting sent to everyone watching sightings
+// ImageData and ReporterEmail of the sighting are left empty.
+type WatchedSighting struct {
+	Sighting
+	TigerName string
+}
+
+// SightingWatchFilter is a struct to model the new sightings a watcher receives
+// Empty TigerIDs means every tiger. Nil BoundingBox means everywhere.
+type SightingWatchFilter struct {
+	TigerIDs    []int32
+	BoundingBox *BoundingBox
+}
+
+// Waypoint is a struct to model a known position of a tiger with its distance from a new sighting
+// Distance is in meters.
+type Waypoint struct {
+	SeenAt   time.Time
+	Distance float64
+}
+
+// Movement is a struct to model the move of a tiger between a new sighting and a waypoint
+// Distance is in meters and Speed in km/h. Speed is null if the tiger moved at the time of the waypoint.
+type Movement struct {
+	Distance float64
+	Speed    sql.NullFloat64
+}
+
+// TigerFilter is a struct to model filters of tiger list
+// Zero value of a field means the field is not used to filter.
+type TigerFilter struct {
+	NamePrefix     string
+	LastSeenAfter  time.Time
+	LastSeenBefore time.Time
+	BoundingBox    *BoundingBox
+}
+
+// BoundingBox is a struct to model an area between two latitudes and two longitudes
+// MinLongitude greater than MaxLongitude means the area crosses the antimeridian.
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// TigerCursor is a struct to model position of the last tiger in a page
+// Tigers are ordered by last seen timestamp then ID, so both are needed to continue from the position.
+type TigerCursor struct {
+	LastSeenTimestamp time.Time `json:"last_seen_timestamp"`
+	ID                int32     `json:"id"`
+}
+
+// TigerPage is a struct to model a page of tiger list
+type TigerPage struct {
+	Tigers        []*Tiger
+	NextPageToken string
+}
+
+// SightingFilter is a struct to model filters of sighting list
+// Zero value of a field means the field is not used to filter.
+type SightingFilter struct {
+	SeenFrom time.Time
+	SeenTo   time.Time
+	// IncludeImageData sets ImageData of the returned sightings to their medium image, empty otherwise.
+	IncludeImageData bool
+}
+
+// SightingCursor is a struct to model position of the last sighting in a page
+type SightingCursor struct {
+	SeenAt time.Time `json:"seen_at"`
+	ID     int32     `json:"id"`
+}
+
+// SightingPage is a struct to model a page of sighting list
+type SightingPage struct {
+	Sightings     []*Sighting
+	NextPageToken string
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// WebhookEventSightingRecorded is the type of the webhook event delivered for a new sighting. Its data is a WebhookSighting.
+const WebhookEventSightingRecorded = "sighting.recorded"
+
+// WebhookSubscription is a struct to model an endpoint of a partner pushed new sightings
+// Invalid TigerID and nil BoundingBox mean the subscription is not filtered by tiger and by area.
+// Secret signs the deliveries. It is only set when the subscription is created or delivered to.
+type WebhookSubscription struct {
+	ID          int32
+	URL         string
+	Secret      string
+	TigerID     sql.NullInt32
+	BoundingBox *BoundingBox
+	CreatedAt   time.Time
+}
+
+// WebhookDeliveryAttempt is a struct to model one POST of a webhook event to a subscription
+// StatusCode is 0 when no response was received. Error is empty when the attempt succeeded.
+type WebhookDeliveryAttempt struct {
+	ID             int64
+	SubscriptionID int32
+	DeliveryID     string
+	EventType      string
+	Attempt        int32
+	StatusCode     int32
+	Error          string
+	Duration       time.Duration
+	CreatedAt      time.Time
+}
+
+// WebhookEvent is a struct to model the JSON body POSTed to a webhook subscription
+// ID is the same for every attempt of a delivery, so that receivers can ignore duplicates.
+type WebhookEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookSighting is a struct to model the data of WebhookEventSightingRecorded
+// It must never contain emails of reporters, since it leaves our system.
+type WebhookSighting struct {
+	SightingID int32     `json:"sighting_id"`
+	TigerID    int32     `json:"tiger_id"`
+	TigerName  string    `json:"tiger_name"`
+	SeenAt     time.Time `json:"seen_at"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+}
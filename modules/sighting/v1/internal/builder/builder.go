@@ -5,20 +5,82 @@ package builder
 
 import (
 	goredis "github.com/go-redis/redis/v8"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
 	"github.com/ibrahimker/tigerhall-kittens/driver/redis"
+	"github.com/ibrahimker/tigerhall-kittens/driver/smtp"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/internal/grpc/handler"
+	resthandler "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/internal/rest/handler"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/notification"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/outbox"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/repository/postgres"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/webhook"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
 )
 
 // BuildTigerSightingHandler builds tiger sighting handler including all of its dependencies.
 func BuildTigerSightingHandler(cfg *config.Config, pool *pgxpool.Pool, rds *goredis.Client, logger *logrus.Entry) *handler.TigerSighting {
 	redisRepo := redis.NewRedisClient(rds)
 	tigerSightingRepo := postgres.NewTigerSightingRepo(pool)
-	tigerSightingService := service.NewTigerSightingService(tigerSightingRepo, redisRepo)
-	return handler.NewTigerSighting(logger, tigerSightingService)
+	sightingValidator := service.SightingValidators{
+		service.NewDistanceValidator(cfg.Sighting.MaxDistance, cfg.Sighting.DistancePolicy == config.PolicyReview),
+		service.NewSpeedValidator(cfg.Sighting.MaxSpeed, cfg.Sighting.SpeedPolicy == config.PolicyReview),
+	}
+	imageStore := imagestore.NewImageStore(&cfg.ImageStore, &cfg.S3)
+	imageLimits := service.ImageLimits{MaxBytes: cfg.ImageLimits.MaxBytes, MaxWidth: cfg.ImageLimits.MaxWidth, MaxHeight: cfg.ImageLimits.MaxHeight}
+	exifTolerance := service.ExifTolerance{Time: cfg.Sighting.ExifTimeTolerance, Distance: cfg.Sighting.ExifDistanceTolerance}
+	duplicateImages := service.DuplicateImages{MaxDistance: int32(cfg.Sighting.DuplicateImageDistance), Review: cfg.Sighting.DuplicateImagePolicy == config.PolicyReview}
+	tigerSightingService := service.NewTigerSightingService(tigerSightingRepo, redisRepo, imageStore, imageLimits, imageRenditions(&cfg.ImageRenditions),
+		exifTolerance, duplicateImages, sightingValidator, cfg.Sighting.ClockSkew)
+	webhookService := service.NewWebhookService(tigerSightingRepo, cfg.Webhook.AllowPrivateNetworks)
+	sightingWatchService := service.NewSightingWatchService(redisRepo)
+	return handler.NewTigerSighting(logger, tigerSightingService, webhookService, sightingWatchService, cfg.Sighting.WatchHeartbeat)
+}
+
+// imageRenditions returns the renditions generated from sighting images, configured by cfg.
+func imageRenditions(cfg *config.ImageRenditions) []service.ImageRendition {
+	return []service.ImageRendition{
+		{Name: service.ImageRenditionThumbnail, Width: uint(cfg.ThumbnailWidth), Height: uint(cfg.ThumbnailHeight), Fill: cfg.ThumbnailMode == config.ImageFill},
+		{Name: service.ImageRenditionMedium, Width: uint(cfg.MediumWidth), Height: uint(cfg.MediumHeight), Fill: cfg.MediumMode == config.ImageFill},
+		{Name: service.ImageRenditionLarge, Width: uint(cfg.LargeWidth), Height: uint(cfg.LargeHeight), Fill: cfg.LargeMode == config.ImageFill},
+	}
+}
+
+// BuildSightingImageHandler builds sighting image REST handler writing errors with the mux, including all of its dependencies.
+func BuildSightingImageHandler(cfg *config.Config, pool *pgxpool.Pool, mux *runtime.ServeMux, logger *logrus.Entry) *resthandler.SightingImage {
+	imageService := service.NewSightingImageService(postgres.NewTigerSightingRepo(pool), imagestore.NewImageStore(&cfg.ImageStore, &cfg.S3))
+	return resthandler.NewSightingImage(logger, mux, imageService)
+}
+
+// BuildTigerSightingJobs builds the background work of tiger sighting including all of its dependencies
+// and registers it to the worker: the outbox relay and the jobs enqueued by its handlers.
+func BuildTigerSightingJobs(w *worker.Worker, cfg *config.Config, pool *pgxpool.Pool, rds *goredis.Client, logger *logrus.Entry) {
+	redisRepo := redis.NewRedisClient(rds)
+	tigerSightingRepo := postgres.NewTigerSightingRepo(pool)
+
+	cacheInvalidator := service.NewCacheInvalidator(redisRepo)
+	notificationDispatcher := notification.NewDispatcher(w.Queue())
+	webhookDispatcher := webhook.NewDispatcher(tigerSightingRepo, w.Queue())
+	relay := outbox.NewRelay(tigerSightingRepo, logger, &cfg.Outbox)
+	relay.Register(entity.EventTigerCreated, "cache", cacheInvalidator)
+	relay.Register(entity.EventTigerUpdated, "cache", cacheInvalidator)
+	relay.Register(entity.EventTigerDeleted, "cache", cacheInvalidator)
+	relay.Register(entity.EventSightingRecorded, "cache", cacheInvalidator)
+	relay.Register(entity.EventSightingRecorded, "notification", notificationDispatcher)
+	relay.Register(entity.EventSightingRecorded, "webhook", webhookDispatcher)
+	w.Go(relay.Run)
+
+	w.Register(notification.JobSendNotification, notification.NewMailer(smtp.NewClient(&cfg.SMTP)), cfg.Notification.Workers)
+	w.Register(webhook.JobDeliverWebhook, webhook.NewDeliverer(tigerSightingRepo, &cfg.Webhook), cfg.Webhook.Workers)
+}
+
+// BuildImageMigration builds the migration of sighting images from database to the image store including all of its dependencies.
+func BuildImageMigration(cfg *config.Config, pool *pgxpool.Pool) service.ImageMigration {
+	return service.NewImageMigrationService(postgres.NewTigerSightingRepo(pool), imagestore.NewImageStore(&cfg.ImageStore, &cfg.S3))
 }
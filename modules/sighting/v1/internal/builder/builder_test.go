@@ -4,12 +4,14 @@ import (
 	"testing"
 
 	"github.com/go-redis/redismock/v8"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ibrahimker/tigerhall-kittens/common/config"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/internal/builder"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
 )
 
 func TestBuildTigerSightingandler(t *testing.T) {
@@ -23,3 +25,25 @@ func TestBuildTigerSightingandler(t *testing.T) {
 		assert.NotNil(t, hdr)
 	})
 }
+
+func TestBuildSightingImageHandler(t *testing.T) {
+	t.Run("successfully build sighting image handler", func(t *testing.T) {
+		pool := &pgxpool.Pool{}
+		cfg, _ := config.NewConfig("../../../../../test/fixture/env.valid")
+
+		hdr := builder.BuildSightingImageHandler(cfg, pool, runtime.NewServeMux(), logging.NewTestLogger())
+
+		assert.NotNil(t, hdr)
+	})
+}
+
+func TestBuildTigerSightingJobs(t *testing.T) {
+	t.Run("successfully build Tiger Sighting jobs", func(t *testing.T) {
+		pool := &pgxpool.Pool{}
+		cfg, _ := config.NewConfig("../../../../../test/fixture/env.valid")
+		rds, _ := redismock.NewClientMock()
+		w := worker.NewWorker(worker.NewRedisQueue(rds, cfg.Worker.PollInterval, cfg.Worker.Lease), logging.NewTestLogger(), &cfg.Worker)
+
+		builder.BuildTigerSightingJobs(w, cfg, pool, rds, logging.NewTestLogger())
+	})
+}
@@ -1,37 +1,237 @@
 package handler
 
 import (
+	"database/sql"
+	"fmt"
+
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+)
+
+const (
+	// sightingImageURL is the REST path of the original image of a sighting, formatted with tiger ID and sighting ID.
+	sightingImageURL = "/v1/tiger/%d/sighting/%d/image"
+	// sightingImageRenditionURL is the REST path of a rendition of a sighting image,
+	// formatted with tiger ID, sighting ID and the name of the rendition.
+	sightingImageRenditionURL = sightingImageURL + "/%s"
 )
 
 func composeTigersProto(req []*entity.Tiger) (res []*tigerv1.Tiger) {
 	for _, v := range req {
-		res = append(res, &tigerv1.Tiger{
-			Id:                v.ID,
-			Name:              v.Name,
-			DateOfBirth:       timestamppb.New(v.DateOfBirth),
-			LastSeenTimestamp: timestamppb.New(v.LastSeenTimestamp),
-			LastSeenLatitude:  wrapperspb.Double(v.LastSeenLatitude),
-			LastSeenLongitude: wrapperspb.Double(v.LastSeenLongitude),
-			CreatedAt:         timestamppb.New(v.CreatedAt.Time),
-			UpdatedAt:         timestamppb.New(v.UpdatedAt.Time),
+		res = append(res, composeTigerProto(v))
+	}
+	return res
+}
+
+func composeTigerProto(v *entity.Tiger) *tigerv1.Tiger {
+	res := &tigerv1.Tiger{
+		Id:                v.ID,
+		Name:              v.Name,
+		DateOfBirth:       timestamppb.New(v.DateOfBirth),
+		LastSeenTimestamp: timestamppb.New(v.LastSeenTimestamp),
+		LastSeenLatitude:  wrapperspb.Double(v.LastSeenLatitude),
+		LastSeenLongitude: wrapperspb.Double(v.LastSeenLongitude),
+		CreatedAt:         timestamppb.New(v.CreatedAt.Time),
+		UpdatedAt:         timestamppb.New(v.UpdatedAt.Time),
+	}
+	if v.MaxSightingDistance.Valid {
+		res.MaxSightingDistance = wrapperspb.Double(v.MaxSightingDistance.Float64)
+	}
+	return res
+}
+
+func composeNullFloat64(v *wrapperspb.DoubleValue) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: v.GetValue(), Valid: true}
+}
+
+func composeTigerFilter(req *tigerv1.GetTigersRequest) *entity.TigerFilter {
+	filter := &entity.TigerFilter{
+		NamePrefix: req.GetNamePrefix(),
+	}
+	if req.GetLastSeenAfter() != nil {
+		filter.LastSeenAfter = req.GetLastSeenAfter().AsTime()
+	}
+	if req.GetLastSeenBefore() != nil {
+		filter.LastSeenBefore = req.GetLastSeenBefore().AsTime()
+	}
+	filter.BoundingBox = composeBoundingBox(req.GetBoundingBox())
+	return filter
+}
+
+func composeBoundingBox(box *tigerv1.BoundingBox) *entity.BoundingBox {
+	if box == nil {
+		return nil
+	}
+	return &entity.BoundingBox{
+		MinLatitude:  box.GetMinLatitude(),
+		MinLongitude: box.GetMinLongitude(),
+		MaxLatitude:  box.GetMaxLatitude(),
+		MaxLongitude: box.GetMaxLongitude(),
+	}
+}
+
+// composeNearbyQuery returns a validation error if lat or lng is missing, since 0 is a valid coordinate.
+func composeNearbyQuery(req *tigerv1.SearchTigersNearbyRequest) (*entity.NearbyQuery, error) {
+	var violations []apperrors.FieldViolation
+	if req.GetLat() == nil {
+		violations = append(violations, apperrors.FieldViolation{Field: "lat", Description: "latitude is required"})
+	}
+	if req.GetLng() == nil {
+		violations = append(violations, apperrors.FieldViolation{Field: "lng", Description: "longitude is required"})
+	}
+	if err := apperrors.NewValidationError(violations...); err != nil {
+		return nil, err
+	}
+
+	query := &entity.NearbyQuery{
+		Latitude:  req.GetLat().GetValue(),
+		Longitude: req.GetLng().GetValue(),
+		RadiusKm:  req.GetRadiusKm(),
+		Limit:     req.GetLimit(),
+	}
+	if req.GetSince() != nil {
+		query.Since = req.GetSince().AsTime()
+	}
+	return query, nil
+}
+
+func composeNearbyTigersProto(req []*entity.NearbyTiger) (res []*tigerv1.NearbyTiger) {
+	for _, v := range req {
+		res = append(res, &tigerv1.NearbyTiger{
+			Tiger:      composeTigerProto(&v.Tiger),
+			DistanceKm: v.Distance / 1000,
 		})
 	}
 	return res
 }
 
+func composeSightingFilter(req *tigerv1.GetSightingsRequest) *entity.SightingFilter {
+	filter := &entity.SightingFilter{
+		IncludeImageData: req.GetIncludeImageData(),
+	}
+	if req.GetSeenFrom() != nil {
+		filter.SeenFrom = req.GetSeenFrom().AsTime()
+	}
+	if req.GetSeenTo() != nil {
+		filter.SeenTo = req.GetSeenTo().AsTime()
+	}
+	return filter
+}
+
 func composeSightingsProto(req []*entity.Sighting) (res []*tigerv1.Sighting) {
 	for _, v := range req {
-		res = append(res, &tigerv1.Sighting{
-			Id:        v.ID,
-			SeenAt:    timestamppb.New(v.SeenAt),
-			Latitude:  wrapperspb.Double(v.Latitude),
-			Longitude: wrapperspb.Double(v.Longitude),
-			ImageData: v.ImageData,
+		res = append(res, composeSightingProto(v))
+	}
+	return res
+}
+
+func composeSightingProto(v *entity.Sighting) *tigerv1.Sighting {
+	sighting := &tigerv1.Sighting{
+		Id:           v.ID,
+		SeenAt:       timestamppb.New(v.SeenAt),
+		Latitude:     wrapperspb.Double(v.Latitude),
+		Longitude:    wrapperspb.Double(v.Longitude),
+		ImageData:    v.ImageData,
+		ImageUrl:     fmt.Sprintf(sightingImageURL, v.TigerID, v.ID),
+		NeedsReview:  v.NeedsReview,
+		ReporterName: v.ReporterName,
+		ThumbnailUrl: fmt.Sprintf(sightingImageRenditionURL, v.TigerID, v.ID, service.ImageRenditionThumbnail),
+		MediumUrl:    fmt.Sprintf(sightingImageRenditionURL, v.TigerID, v.ID, service.ImageRenditionMedium),
+		LargeUrl:     fmt.Sprintf(sightingImageRenditionURL, v.TigerID, v.ID, service.ImageRenditionLarge),
+	}
+	if v.Speed.Valid {
+		sighting.SpeedKmh = wrapperspb.Double(v.Speed.Float64)
+	}
+	return sighting
+}
+
+func composeSimilarImageQuery(req *tigerv1.FindSimilarImagesRequest) *entity.SimilarImageQuery {
+	query := &entity.SimilarImageQuery{
+		ImageData: req.GetImageData(),
+		Limit:     req.GetLimit(),
+	}
+	if req.GetMaxDistance() != nil {
+		query.MaxDistance = sql.NullInt32{Int32: req.GetMaxDistance().GetValue(), Valid: true}
+	}
+	return query
+}
+
+func composeSimilarSightingsProto(req []*entity.SimilarSighting) (res []*tigerv1.SimilarSighting) {
+	for _, v := range req {
+		res = append(res, &tigerv1.SimilarSighting{
+			TigerId:  v.TigerID,
+			Sighting: composeSightingProto(&v.Sighting),
+			Distance: v.Distance,
+		})
+	}
+	return res
+}
+
+func composeWatchedSightingProto(v *entity.WatchedSighting) *tigerv1.WatchSightingsResponse {
+	return &tigerv1.WatchSightingsResponse{
+		Event: &tigerv1.WatchSightingsResponse_Sighting{
+			Sighting: &tigerv1.WatchedSighting{
+				TigerId:   v.TigerID,
+				TigerName: v.TigerName,
+				Sighting:  composeSightingProto(&v.Sighting),
+			},
+		},
+	}
+}
+
+func composeHeartbeatProto() *tigerv1.WatchSightingsResponse {
+	return &tigerv1.WatchSightingsResponse{
+		Event: &tigerv1.WatchSightingsResponse_Heartbeat{Heartbeat: timestamppb.Now()},
+	}
+}
+
+func composeWebhookSubscriptionsProto(req []*entity.WebhookSubscription) (res []*tigerv1.WebhookSubscription) {
+	for _, v := range req {
+		res = append(res, composeWebhookSubscriptionProto(v))
+	}
+	return res
+}
+
+// composeWebhookSubscriptionProto leaves out the secret, which is returned only when the subscription is created.
+func composeWebhookSubscriptionProto(v *entity.WebhookSubscription) *tigerv1.WebhookSubscription {
+	res := &tigerv1.WebhookSubscription{
+		Id:        v.ID,
+		Url:       v.URL,
+		CreatedAt: timestamppb.New(v.CreatedAt),
+	}
+	if v.TigerID.Valid {
+		res.TigerId = wrapperspb.Int32(v.TigerID.Int32)
+	}
+	if box := v.BoundingBox; box != nil {
+		res.BoundingBox = &tigerv1.BoundingBox{
+			MinLatitude:  box.MinLatitude,
+			MinLongitude: box.MinLongitude,
+			MaxLatitude:  box.MaxLatitude,
+			MaxLongitude: box.MaxLongitude,
+		}
+	}
+	return res
+}
+
+func composeWebhookDeliveryAttemptsProto(req []*entity.WebhookDeliveryAttempt) (res []*tigerv1.WebhookDeliveryAttempt) {
+	for _, v := range req {
+		res = append(res, &tigerv1.WebhookDeliveryAttempt{
+			Id:         v.ID,
+			DeliveryId: v.DeliveryID,
+			EventType:  v.EventType,
+			Attempt:    v.Attempt,
+			StatusCode: v.StatusCode,
+			Error:      v.Error,
+			DurationMs: int32(v.Duration.Milliseconds()),
+			CreatedAt:  timestamppb.New(v.CreatedAt),
 		})
 	}
 	return res
@@ -4,6 +4,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -16,15 +17,22 @@ import (
 // TigerSighting handles HTTP/2 gRPC request for tiger sighting services.
 type TigerSighting struct {
 	tigerv1.UnimplementedTigerSightingServiceServer
-	logger      *logrus.Entry
-	sightingSvc service.TigerSighting
+	logger         *logrus.Entry
+	sightingSvc    service.TigerSighting
+	webhookSvc     service.Webhook
+	watchSvc       service.SightingWatch
+	watchHeartbeat time.Duration
 }
 
 // NewTigerSighting creates an instance of TigerSighting.
-func NewTigerSighting(logger *logrus.Entry, sightingSvc service.TigerSighting) *TigerSighting {
+// watchHeartbeat is how long a stream of WatchSightings stays idle before a heartbeat is sent.
+func NewTigerSighting(logger *logrus.Entry, sightingSvc service.TigerSighting, webhookSvc service.Webhook, watchSvc service.SightingWatch, watchHeartbeat time.Duration) *TigerSighting {
 	return &TigerSighting{
-		logger:      logger,
-		sightingSvc: sightingSvc,
+		logger:         logger,
+		sightingSvc:    sightingSvc,
+		webhookSvc:     webhookSvc,
+		watchSvc:       watchSvc,
+		watchHeartbeat: watchHeartbeat,
 	}
 }
 
@@ -32,14 +40,15 @@ func NewTigerSighting(logger *logrus.Entry, sightingSvc service.TigerSighting) *
 func (s *TigerSighting) GetTigers(ctx context.Context, req *tigerv1.GetTigersRequest) (*tigerv1.GetTigersResponse, error) {
 	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetTigers", req)
 
-	data, err := s.sightingSvc.GetTigers(ctx)
+	data, err := s.sightingSvc.GetTigers(ctx, composeTigerFilter(req), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		logging.WithError(err, logger).Error("Error when call s.sightingSvc.GetTigers")
 		return nil, err
 	}
 
 	res := &tigerv1.GetTigersResponse{
-		Data: composeTigersProto(data),
+		Data:          composeTigersProto(data.Tigers),
+		NextPageToken: data.NextPageToken,
 	}
 	return res, nil
 }
@@ -49,11 +58,12 @@ func (s *TigerSighting) CreateTiger(ctx context.Context, req *tigerv1.CreateTige
 	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetTigers", req)
 
 	if err := s.sightingSvc.CreateTiger(ctx, &entity.Tiger{
-		Name:              req.GetName(),
-		DateOfBirth:       req.GetDateOfBirth().AsTime(),
-		LastSeenTimestamp: req.GetLastSeenTimestamp().AsTime(),
-		LastSeenLatitude:  req.GetLastSeenLatitude().GetValue(),
-		LastSeenLongitude: req.GetLastSeenLongitude().GetValue(),
+		Name:                req.GetName(),
+		DateOfBirth:         req.GetDateOfBirth().AsTime(),
+		LastSeenTimestamp:   req.GetLastSeenTimestamp().AsTime(),
+		LastSeenLatitude:    req.GetLastSeenLatitude().GetValue(),
+		LastSeenLongitude:   req.GetLastSeenLongitude().GetValue(),
+		MaxSightingDistance: composeNullFloat64(req.GetMaxSightingDistance()),
 	}); err != nil {
 		logging.WithError(err, logger).Error("Error when call s.sightingSvc.CreateTiger")
 		return nil, err
@@ -65,18 +75,98 @@ func (s *TigerSighting) CreateTiger(ctx context.Context, req *tigerv1.CreateTige
 	return res, nil
 }
 
+// SearchTigersNearby handles HTTP/2 gRPC request similar to GET in HTTP/1.1.
+func (s *TigerSighting) SearchTigersNearby(ctx context.Context, req *tigerv1.SearchTigersNearbyRequest) (*tigerv1.SearchTigersNearbyResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "SearchTigersNearby", req)
+
+	query, err := composeNearbyQuery(req)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when compose nearby query")
+		return nil, err
+	}
+
+	data, err := s.sightingSvc.SearchTigersNearby(ctx, query)
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.sightingSvc.SearchTigersNearby")
+		return nil, err
+	}
+
+	res := &tigerv1.SearchTigersNearbyResponse{
+		Data: composeNearbyTigersProto(data),
+	}
+	return res, nil
+}
+
+// GetTiger handles HTTP/2 gRPC request similar to GET in HTTP/1.1.
+func (s *TigerSighting) GetTiger(ctx context.Context, req *tigerv1.GetTigerRequest) (*tigerv1.GetTigerResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetTiger", req)
+
+	data, err := s.sightingSvc.GetTiger(ctx, req.GetId())
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.sightingSvc.GetTiger")
+		return nil, err
+	}
+
+	res := &tigerv1.GetTigerResponse{
+		Data: composeTigerProto(data),
+	}
+	return res, nil
+}
+
+// UpdateTiger handles HTTP/2 gRPC request similar to PATCH in HTTP/1.1.
+func (s *TigerSighting) UpdateTiger(ctx context.Context, req *tigerv1.UpdateTigerRequest) (*tigerv1.UpdateTigerResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "UpdateTiger", req)
+
+	tiger := &entity.Tiger{
+		ID:                  req.GetId(),
+		Name:                req.GetTiger().GetName(),
+		MaxSightingDistance: composeNullFloat64(req.GetTiger().GetMaxSightingDistance()),
+	}
+	// an omitted date of birth stays zero rather than the Unix epoch, so that it is kept or rejected by the service
+	if dateOfBirth := req.GetTiger().GetDateOfBirth(); dateOfBirth != nil {
+		tiger.DateOfBirth = dateOfBirth.AsTime()
+	}
+
+	data, err := s.sightingSvc.UpdateTiger(ctx, tiger, req.GetUpdateMask().GetPaths())
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.sightingSvc.UpdateTiger")
+		return nil, err
+	}
+
+	res := &tigerv1.UpdateTigerResponse{
+		Data: composeTigerProto(data),
+	}
+	return res, nil
+}
+
+// DeleteTiger handles HTTP/2 gRPC request similar to DELETE in HTTP/1.1.
+func (s *TigerSighting) DeleteTiger(ctx context.Context, req *tigerv1.DeleteTigerRequest) (*tigerv1.DeleteTigerResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "DeleteTiger", req)
+
+	if err := s.sightingSvc.DeleteTiger(ctx, req.GetId()); err != nil {
+		logging.WithError(err, logger).Error("Error when call s.sightingSvc.DeleteTiger")
+		return nil, err
+	}
+
+	res := &tigerv1.DeleteTigerResponse{
+		Message: "Successfully delete tiger",
+	}
+	return res, nil
+}
+
 // GetSightings handles HTTP/2 gRPC request similar to GET in HTTP/1.1.
 func (s *TigerSighting) GetSightings(ctx context.Context, req *tigerv1.GetSightingsRequest) (*tigerv1.GetSightingsResponse, error) {
 	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetTigers", req)
 
-	data, err := s.sightingSvc.GetSightingsByTigerID(ctx, req.GetId())
+	data, err := s.sightingSvc.GetSightingsByTigerID(ctx, req.GetId(), composeSightingFilter(req), req.GetPageSize(), req.GetPageToken())
 	if err != nil {
 		logging.WithError(err, logger).Error("Error when call s.sightingSvc.GetSightingsByTigerID")
 		return nil, err
 	}
 
 	res := &tigerv1.GetSightingsResponse{
-		Data: composeSightingsProto(data),
+		Data:          composeSightingsProto(data.Sightings),
+		NextPageToken: data.NextPageToken,
 	}
 	return res, nil
 }
@@ -85,19 +175,41 @@ func (s *TigerSighting) GetSightings(ctx context.Context, req *tigerv1.GetSighti
 func (s *TigerSighting) CreateSighting(ctx context.Context, req *tigerv1.CreateSightingRequest) (*tigerv1.CreateSightingResponse, error) {
 	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "CreateSighting", req)
 
-	if err := s.sightingSvc.CreateSighting(ctx, &entity.Sighting{
-		TigerID:   req.GetId(),
-		SeenAt:    req.GetSeenAt().AsTime(),
-		Latitude:  req.GetLatitude().GetValue(),
-		Longitude: req.GetLongitude().GetValue(),
-		ImageData: req.GetImageData(),
-	}); err != nil {
+	sighting := &entity.Sighting{
+		TigerID:       req.GetId(),
+		SeenAt:        req.GetSeenAt().AsTime(),
+		Latitude:      req.GetLatitude().GetValue(),
+		Longitude:     req.GetLongitude().GetValue(),
+		ImageData:     req.GetImageData(),
+		ReporterName:  req.GetReporterName(),
+		ReporterEmail: req.GetReporterEmail(),
+		// a location without latitude or without longitude is read from the image
+		LocationOmitted: req.GetLatitude() == nil || req.GetLongitude() == nil,
+	}
+	if err := s.sightingSvc.CreateSighting(ctx, sighting); err != nil {
 		logging.WithError(err, logger).Error("Error when call s.sightingSvc.CreateSighting")
 		return nil, err
 	}
 
 	res := &tigerv1.CreateSightingResponse{
-		Message: "Successfully create new sighting",
+		Message:     "Successfully create new sighting",
+		NeedsReview: sighting.NeedsReview,
+	}
+	return res, nil
+}
+
+// FindSimilarImages handles HTTP/2 gRPC request similar to POST in HTTP/1.1.
+func (s *TigerSighting) FindSimilarImages(ctx context.Context, req *tigerv1.FindSimilarImagesRequest) (*tigerv1.FindSimilarImagesResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "FindSimilarImages", req)
+
+	data, err := s.sightingSvc.FindSimilarImages(ctx, composeSimilarImageQuery(req))
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.sightingSvc.FindSimilarImages")
+		return nil, err
+	}
+
+	res := &tigerv1.FindSimilarImagesResponse{
+		Data: composeSimilarSightingsProto(data),
 	}
 	return res, nil
 }
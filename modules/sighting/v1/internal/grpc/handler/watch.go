@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// WatchSightings handles HTTP/2 gRPC server-streaming request similar to GET with Server-Sent Events in HTTP/1.1.
+// It streams until the client leaves. A heartbeat is sent first and whenever the stream stays idle for the heartbeat interval.
+func (s *TigerSighting) WatchSightings(req *tigerv1.WatchSightingsRequest, stream tigerv1.TigerSightingService_WatchSightingsServer) error {
+	logger, ctx := logging.NewHandlerLogger(stream.Context(), s.logger, "WatchSightings", req)
+
+	sightings, err := s.watchSvc.WatchSightings(ctx, &entity.SightingWatchFilter{
+		TigerIDs:    req.GetTigerIds(),
+		BoundingBox: composeBoundingBox(req.GetBoundingBox()),
+	})
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.watchSvc.WatchSightings")
+		return err
+	}
+
+	// the first heartbeat tells the client the watch started, e.g. the REST gateway writes the response headers with it
+	if err = stream.Send(composeHeartbeatProto()); err != nil {
+		logging.WithError(err, logger).Warn("Error when call stream.Send")
+		return err
+	}
+
+	heartbeat := time.NewTicker(s.watchHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sighting, ok := <-sightings:
+			if !ok {
+				logger.Warn("Watched sightings closed before the client left")
+				return status.Error(codes.Unavailable, "sightings can't be watched anymore, watch again")
+			}
+			err = stream.Send(composeWatchedSightingProto(sighting))
+			heartbeat.Reset(s.watchHeartbeat)
+		case <-heartbeat.C:
+			err = stream.Send(composeHeartbeatProto())
+		}
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when call stream.Send")
+			return err
+		}
+	}
+}
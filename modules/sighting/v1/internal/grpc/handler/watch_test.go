@@ -0,0 +1,147 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+const watchHeartbeat = 20 * time.Millisecond
+
+// watchSightingsStream is a server stream of WatchSightings passing the sent responses to the test.
+type watchSightingsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    chan *tigerv1.WatchSightingsResponse
+	sendErr error
+}
+
+func newWatchSightingsStream(ctx context.Context) *watchSightingsStream {
+	return &watchSightingsStream{ctx: ctx, sent: make(chan *tigerv1.WatchSightingsResponse, 1)}
+}
+
+func (s *watchSightingsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchSightingsStream) Send(res *tigerv1.WatchSightingsResponse) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent <- res
+	return nil
+}
+
+func TestHelpCenterService_WatchSightings(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	req := &tigerv1.WatchSightingsRequest{
+		TigerIds:    []int32{1},
+		BoundingBox: &tigerv1.BoundingBox{MinLatitude: -10, MinLongitude: 100, MaxLatitude: 10, MaxLongitude: 110},
+	}
+	filter := &entity.SightingWatchFilter{
+		TigerIDs:    []int32{1},
+		BoundingBox: &entity.BoundingBox{MinLatitude: -10, MinLongitude: 100, MaxLatitude: 10, MaxLongitude: 110},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "Error when hit service",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				stream := newWatchSightingsStream(context.Background())
+				serviceSuite.watchSvc.EXPECT().WatchSightings(gomock.Any(), filter).Return(nil, errors.New("redis error"))
+
+				err := serviceSuite.sightingHandler.WatchSightings(req, stream)
+				require.Error(t, err)
+				require.Empty(t, stream.sent)
+			},
+		},
+		{
+			testcaseName: "Error when send to stream",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				stream := newWatchSightingsStream(context.Background())
+				stream.sendErr = errors.New("transport is closing")
+				serviceSuite.watchSvc.EXPECT().WatchSightings(gomock.Any(), filter).Return(make(chan *entity.WatchedSighting), nil)
+
+				err := serviceSuite.sightingHandler.WatchSightings(req, stream)
+				require.Equal(t, stream.sendErr, err)
+			},
+		},
+		{
+			testcaseName: "Error unavailable when watched sightings are closed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				stream := newWatchSightingsStream(context.Background())
+				sightings := make(chan *entity.WatchedSighting)
+				serviceSuite.watchSvc.EXPECT().WatchSightings(gomock.Any(), filter).Return(sightings, nil)
+				close(sightings)
+
+				errs := make(chan error)
+				go func() { errs <- serviceSuite.sightingHandler.WatchSightings(req, stream) }()
+				require.NotNil(t, (<-stream.sent).GetHeartbeat())
+				require.Equal(t, codes.Unavailable, status.Code(<-errs))
+			},
+		},
+		{
+			testcaseName: "successfully stream sightings and heartbeats until the client leaves",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				ctx, cancel := context.WithCancel(context.Background())
+				stream := newWatchSightingsStream(ctx)
+				sightings := make(chan *entity.WatchedSighting)
+				serviceSuite.watchSvc.EXPECT().WatchSightings(gomock.Any(), filter).Return(sightings, nil)
+				seenAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+				errs := make(chan error)
+				go func() { errs <- serviceSuite.sightingHandler.WatchSightings(req, stream) }()
+				require.NotNil(t, (<-stream.sent).GetHeartbeat())
+
+				sightings <- &entity.WatchedSighting{
+					Sighting: entity.Sighting{ID: 2, TigerID: 1, SeenAt: seenAt, Latitude: -6.18, Longitude: 106.0,
+						ReporterName: "Ranger", NeedsReview: true},
+					TigerName: "tiger-1",
+				}
+				watched := (<-stream.sent).GetSighting()
+				require.Equal(t, int32(1), watched.GetTigerId())
+				require.Equal(t, "tiger-1", watched.GetTigerName())
+				require.Equal(t, int32(2), watched.GetSighting().GetId())
+				require.True(t, seenAt.Equal(watched.GetSighting().GetSeenAt().AsTime()))
+				require.Equal(t, -6.18, watched.GetSighting().GetLatitude().GetValue())
+				require.Equal(t, 106.0, watched.GetSighting().GetLongitude().GetValue())
+				require.Equal(t, "/v1/tiger/1/sighting/2/image", watched.GetSighting().GetImageUrl())
+				require.Equal(t, "/v1/tiger/1/sighting/2/image/thumbnail", watched.GetSighting().GetThumbnailUrl())
+				require.Equal(t, "Ranger", watched.GetSighting().GetReporterName())
+				require.True(t, watched.GetSighting().GetNeedsReview())
+				require.Empty(t, watched.GetSighting().GetImageData())
+
+				// a heartbeat is sent once the stream stays idle for the heartbeat interval
+				require.NotNil(t, (<-stream.sent).GetHeartbeat())
+
+				cancel()
+				require.NoError(t, <-errs)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// CreateWebhookSubscription handles HTTP/2 gRPC request similar to POST in HTTP/1.1.
+func (s *TigerSighting) CreateWebhookSubscription(ctx context.Context, req *tigerv1.CreateWebhookSubscriptionRequest) (*tigerv1.CreateWebhookSubscriptionResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "CreateWebhookSubscription", req)
+
+	subscription := &entity.WebhookSubscription{
+		URL:         req.GetUrl(),
+		BoundingBox: composeBoundingBox(req.GetBoundingBox()),
+	}
+	if req.GetTigerId() != nil {
+		subscription.TigerID = sql.NullInt32{Int32: req.GetTigerId().GetValue(), Valid: true}
+	}
+	if err := s.webhookSvc.CreateWebhookSubscription(ctx, subscription); err != nil {
+		logging.WithError(err, logger).Error("Error when call s.webhookSvc.CreateWebhookSubscription")
+		return nil, err
+	}
+
+	res := &tigerv1.CreateWebhookSubscriptionResponse{
+		Data:   composeWebhookSubscriptionProto(subscription),
+		Secret: subscription.Secret,
+	}
+	return res, nil
+}
+
+// GetWebhookSubscriptions handles HTTP/2 gRPC request similar to GET in HTTP/1.1.
+func (s *TigerSighting) GetWebhookSubscriptions(ctx context.Context, req *tigerv1.GetWebhookSubscriptionsRequest) (*tigerv1.GetWebhookSubscriptionsResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetWebhookSubscriptions", req)
+
+	data, err := s.webhookSvc.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.webhookSvc.GetWebhookSubscriptions")
+		return nil, err
+	}
+
+	res := &tigerv1.GetWebhookSubscriptionsResponse{
+		Data: composeWebhookSubscriptionsProto(data),
+	}
+	return res, nil
+}
+
+// DeleteWebhookSubscription handles HTTP/2 gRPC request similar to DELETE in HTTP/1.1.
+func (s *TigerSighting) DeleteWebhookSubscription(ctx context.Context, req *tigerv1.DeleteWebhookSubscriptionRequest) (*tigerv1.DeleteWebhookSubscriptionResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "DeleteWebhookSubscription", req)
+
+	if err := s.webhookSvc.DeleteWebhookSubscription(ctx, req.GetId()); err != nil {
+		logging.WithError(err, logger).Error("Error when call s.webhookSvc.DeleteWebhookSubscription")
+		return nil, err
+	}
+
+	res := &tigerv1.DeleteWebhookSubscriptionResponse{
+		Message: "Successfully delete webhook subscription",
+	}
+	return res, nil
+}
+
+// GetWebhookDeliveries handles HTTP/2 gRPC request similar to GET in HTTP/1.1.
+func (s *TigerSighting) GetWebhookDeliveries(ctx context.Context, req *tigerv1.GetWebhookDeliveriesRequest) (*tigerv1.GetWebhookDeliveriesResponse, error) {
+	logger, ctx := logging.NewHandlerLogger(ctx, s.logger, "GetWebhookDeliveries", req)
+
+	data, err := s.webhookSvc.GetWebhookDeliveries(ctx, req.GetId(), req.GetPageSize())
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.webhookSvc.GetWebhookDeliveries")
+		return nil, err
+	}
+
+	res := &tigerv1.GetWebhookDeliveriesResponse{
+		Data: composeWebhookDeliveryAttemptsProto(data),
+	}
+	return res, nil
+}
@@ -0,0 +1,196 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+func TestHelpCenterService_CreateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	createdAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	req := &tigerv1.CreateWebhookSubscriptionRequest{
+		Url:         "https://example.com/hook",
+		TigerId:     wrapperspb.Int32(1),
+		BoundingBox: &tigerv1.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170},
+	}
+	subscription := &entity.WebhookSubscription{
+		URL:         "https://example.com/hook",
+		TigerID:     sql.NullInt32{Int32: 1, Valid: true},
+		BoundingBox: &entity.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "Error when create webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().CreateWebhookSubscription(gomock.Any(), subscription).Return(errors.New("error"))
+
+				resData, resErr := serviceSuite.sightingHandler.CreateWebhookSubscription(mockCtx, req)
+				require.Error(t, resErr)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Successfully create webhook subscription and return its secret",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().CreateWebhookSubscription(gomock.Any(), subscription).
+					DoAndReturn(func(_ context.Context, s *entity.WebhookSubscription) error {
+						s.ID = 3
+						s.Secret = "secret"
+						s.CreatedAt = createdAt
+						return nil
+					})
+
+				resData, resErr := serviceSuite.sightingHandler.CreateWebhookSubscription(mockCtx, req)
+				require.NoError(t, resErr)
+				require.Equal(t, "secret", resData.GetSecret())
+				require.Equal(t, int32(3), resData.GetData().GetId())
+				require.Equal(t, int32(1), resData.GetData().GetTigerId().GetValue())
+				require.Equal(t, req.GetBoundingBox().GetMinLongitude(), resData.GetData().GetBoundingBox().GetMinLongitude())
+				require.Equal(t, timestamppb.New(createdAt), resData.GetData().GetCreatedAt())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestHelpCenterService_GetWebhookSubscriptions(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "Error when get webhook subscriptions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().GetWebhookSubscriptions(gomock.Any()).Return(nil, errors.New("error"))
+
+				resData, resErr := serviceSuite.sightingHandler.GetWebhookSubscriptions(mockCtx, &tigerv1.GetWebhookSubscriptionsRequest{})
+				require.Error(t, resErr)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Successfully get webhook subscriptions without filter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().GetWebhookSubscriptions(gomock.Any()).
+					Return([]*entity.WebhookSubscription{{ID: 1, URL: "https://example.com/hook"}}, nil)
+
+				resData, resErr := serviceSuite.sightingHandler.GetWebhookSubscriptions(mockCtx, &tigerv1.GetWebhookSubscriptionsRequest{})
+				require.NoError(t, resErr)
+				require.Len(t, resData.GetData(), 1)
+				require.Equal(t, "https://example.com/hook", resData.GetData()[0].GetUrl())
+				require.Nil(t, resData.GetData()[0].GetTigerId())
+				require.Nil(t, resData.GetData()[0].GetBoundingBox())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestHelpCenterService_DeleteWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "Error unknown webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().DeleteWebhookSubscription(gomock.Any(), int32(1)).Return(entity.ErrWebhookSubscriptionNotFound)
+
+				resData, resErr := serviceSuite.sightingHandler.DeleteWebhookSubscription(mockCtx, &tigerv1.DeleteWebhookSubscriptionRequest{Id: 1})
+				require.ErrorIs(t, resErr, entity.ErrWebhookSubscriptionNotFound)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Successfully delete webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().DeleteWebhookSubscription(gomock.Any(), int32(1)).Return(nil)
+
+				resData, resErr := serviceSuite.sightingHandler.DeleteWebhookSubscription(mockCtx, &tigerv1.DeleteWebhookSubscriptionRequest{Id: 1})
+				require.NoError(t, resErr)
+				require.NotNil(t, resData)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestHelpCenterService_GetWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "Error unknown webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().GetWebhookDeliveries(gomock.Any(), int32(1), int32(0)).Return(nil, entity.ErrWebhookSubscriptionNotFound)
+
+				resData, resErr := serviceSuite.sightingHandler.GetWebhookDeliveries(mockCtx, &tigerv1.GetWebhookDeliveriesRequest{Id: 1})
+				require.ErrorIs(t, resErr, entity.ErrWebhookSubscriptionNotFound)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Successfully get webhook deliveries",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				serviceSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceSuite.webhookSvc.EXPECT().GetWebhookDeliveries(gomock.Any(), int32(1), int32(10)).
+					Return([]*entity.WebhookDeliveryAttempt{{ID: 7, DeliveryID: "42-1", Attempt: 2, StatusCode: 503, Error: "unexpected status 503", Duration: 1500 * time.Millisecond}}, nil)
+
+				resData, resErr := serviceSuite.sightingHandler.GetWebhookDeliveries(mockCtx, &tigerv1.GetWebhookDeliveriesRequest{Id: 1, PageSize: 10})
+				require.NoError(t, resErr)
+				require.Len(t, resData.GetData(), 1)
+				require.Equal(t, int64(7), resData.GetData()[0].GetId())
+				require.Equal(t, "42-1", resData.GetData()[0].GetDeliveryId())
+				require.Equal(t, int32(503), resData.GetData()[0].GetStatusCode())
+				require.Equal(t, int32(1500), resData.GetData()[0].GetDurationMs())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,101 @@
+// Package handler provides the functionality of HTTP/1.1 REST handler for responses which aren't proto messages.
+// It is registered to the grpc-gateway mux, next to the handlers generated from the proto.
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/sirupsen/logrus"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+)
+
+const (
+	// SightingImagePath is the REST path of the original image of a sighting.
+	SightingImagePath = "/v1/tiger/{id}/sighting/{sighting_id}/image"
+	// SightingImageRenditionPath is the REST path of a rendition of a sighting image, e.g. thumbnail.
+	SightingImageRenditionPath = SightingImagePath + "/{rendition}"
+	// imageCacheControl lets clients and proxies keep an image for a day, the entity tag revalidates it afterwards.
+	imageCacheControl = "public, max-age=86400"
+)
+
+// SightingImage handles HTTP/1.1 REST request for sighting images.
+// Images are streamed from the image store, supporting conditional and range requests.
+type SightingImage struct {
+	logger   *logrus.Entry
+	mux      *runtime.ServeMux
+	imageSvc service.SightingImage
+}
+
+// NewSightingImage creates an instance of SightingImage. Errors are written with the error handler of the mux.
+func NewSightingImage(logger *logrus.Entry, mux *runtime.ServeMux, imageSvc service.SightingImage) *SightingImage {
+	return &SightingImage{
+		logger:   logger,
+		mux:      mux,
+		imageSvc: imageSvc,
+	}
+}
+
+// Register registers GET and HEAD of the sighting image and its renditions to the mux.
+func (s *SightingImage) Register() error {
+	for _, path := range []string{SightingImagePath, SightingImageRenditionPath} {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			if err := s.mux.HandlePath(method, path, s.getSightingImage); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getSightingImage handles HTTP/1.1 REST request of the original image of a sighting, or of its rendition.
+func (s *SightingImage) getSightingImage(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	logger, ctx := logging.NewHandlerLogger(r.Context(), s.logger, "GetSightingImage", pathParams)
+
+	tigerID, err := parseID(pathParams, "id")
+	if err != nil {
+		s.writeError(ctx, w, r, err)
+		return
+	}
+	sightingID, err := parseID(pathParams, "sighting_id")
+	if err != nil {
+		s.writeError(ctx, w, r, err)
+		return
+	}
+
+	reader, err := s.imageSvc.OpenSightingImage(ctx, tigerID, sightingID, pathParams["rendition"])
+	if err != nil {
+		logging.WithError(err, logger).Error("Error when call s.imageSvc.OpenSightingImage")
+		s.writeError(ctx, w, r, err)
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", reader.ContentType)
+	w.Header().Set("Cache-Control", imageCacheControl)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if reader.ETag != "" {
+		w.Header().Set("ETag", reader.ETag)
+	}
+	// ServeContent answers If-None-Match, If-Modified-Since, Range and HEAD, reading only the requested bytes
+	http.ServeContent(w, r, "", reader.ModTime, reader)
+}
+
+// writeError writes err the way the gateway writes the errors of gRPC calls.
+func (s *SightingImage) writeError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	_, outbound := runtime.MarshalerForRequest(s.mux, r)
+	runtime.HTTPError(ctx, s.mux, outbound, w, r, apperrors.ToGRPCError(err))
+}
+
+// parseID returns the ID in the path parameter, or a validation error if it isn't a positive int32.
+func parseID(pathParams map[string]string, name string) (int32, error) {
+	id, err := runtime.Int32(pathParams[name])
+	if err != nil || id <= 0 {
+		return 0, apperrors.NewValidationError(apperrors.FieldViolation{Field: name, Description: "must be a positive integer"})
+	}
+	return id, nil
+}
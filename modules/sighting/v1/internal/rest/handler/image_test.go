@@ -0,0 +1,164 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/internal/rest/handler"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	mockService "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/service"
+)
+
+type HandlerTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+type SightingImageTestSuite struct {
+	mux      *runtime.ServeMux
+	imageSvc *mockService.MockSightingImage
+}
+
+func SightingImageHandlerTestSuite(t *testing.T) *SightingImageTestSuite {
+	ctrl := gomock.NewController(t)
+	imageSvc := mockService.NewMockSightingImage(ctrl)
+	mux := runtime.NewServeMux()
+	require.NoError(t, handler.NewSightingImage(logging.NewTestLogger(), mux, imageSvc).Register())
+	return &SightingImageTestSuite{mux: mux, imageSvc: imageSvc}
+}
+
+func (s *SightingImageTestSuite) serve(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSightingImage_GetSightingImage(t *testing.T) {
+	t.Parallel()
+	png := &imagestore.Image{Data: []byte("0123456789"), ContentType: "image/png"}
+	etag := imagestore.NewImageReader(png).ETag
+
+	testCases := []HandlerTestCase{
+		{
+			testcaseName: "successfully get image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(imagestore.NewImageReader(png), nil)
+
+				res := suite.serve(httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image", nil))
+				require.Equal(t, http.StatusOK, res.Code)
+				require.Equal(t, "image/png", res.Header().Get("Content-Type"))
+				require.Equal(t, "10", res.Header().Get("Content-Length"))
+				require.Equal(t, etag, res.Header().Get("ETag"))
+				require.Equal(t, "public, max-age=86400", res.Header().Get("Cache-Control"))
+				require.Equal(t, "bytes", res.Header().Get("Accept-Ranges"))
+				require.Equal(t, "0123456789", res.Body.String())
+			},
+		},
+		{
+			testcaseName: "successfully get rendition",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), service.ImageRenditionThumbnail).
+					Return(imagestore.NewImageReader(png), nil)
+
+				res := suite.serve(httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image/thumbnail", nil))
+				require.Equal(t, http.StatusOK, res.Code)
+				require.Equal(t, "0123456789", res.Body.String())
+			},
+		},
+		{
+			testcaseName: "successfully get range of image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(imagestore.NewImageReader(png), nil)
+
+				req := httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image", nil)
+				req.Header.Set("Range", "bytes=2-5")
+				res := suite.serve(req)
+				require.Equal(t, http.StatusPartialContent, res.Code)
+				require.Equal(t, "bytes 2-5/10", res.Header().Get("Content-Range"))
+				require.Equal(t, "2345", res.Body.String())
+			},
+		},
+		{
+			testcaseName: "successfully answer not modified when entity tag matches",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(imagestore.NewImageReader(png), nil)
+
+				req := httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image", nil)
+				req.Header.Set("If-None-Match", etag)
+				res := suite.serve(req)
+				require.Equal(t, http.StatusNotModified, res.Code)
+				require.Equal(t, etag, res.Header().Get("ETag"))
+				require.Empty(t, res.Body.String())
+			},
+		},
+		{
+			testcaseName: "successfully head image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(imagestore.NewImageReader(png), nil)
+
+				res := suite.serve(httptest.NewRequest(http.MethodHead, "/v1/tiger/1/sighting/2/image", nil))
+				require.Equal(t, http.StatusOK, res.Code)
+				require.Equal(t, "10", res.Header().Get("Content-Length"))
+				require.Empty(t, res.Body.String())
+			},
+		},
+		{
+			testcaseName: "Error invalid sighting ID",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+
+				res := suite.serve(httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/abc/image", nil))
+				require.Equal(t, http.StatusBadRequest, res.Code)
+				require.Equal(t, "application/json", res.Header().Get("Content-Type"))
+			},
+		},
+		{
+			testcaseName: "Error sighting image not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(nil, entity.ErrSightingImageNotFound)
+
+				res := suite.serve(httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image", nil))
+				require.Equal(t, http.StatusNotFound, res.Code)
+				require.Contains(t, res.Body.String(), "sighting image not found")
+				require.Empty(t, res.Header().Get("Cache-Control"))
+			},
+		},
+		{
+			testcaseName: "Error when hit service",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				suite := SightingImageHandlerTestSuite(t)
+				suite.imageSvc.EXPECT().OpenSightingImage(gomock.Any(), int32(1), int32(2), "").Return(nil, errors.New("s3 error"))
+
+				res := suite.serve(httptest.NewRequest(http.MethodGet, "/v1/tiger/1/sighting/2/image", nil))
+				require.Equal(t, http.StatusInternalServerError, res.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,90 @@
+// Package notification tells the prior reporters of a tiger that it was sighted again.
+// Notifications are sent by background jobs so that the mail server affects neither the latency
+// nor the result of the request creating the sighting.
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ibrahimker/tigerhall-kittens/driver/smtp"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+// JobSendNotification is the type of the job sending one entity.SightingNotification.
+const JobSendNotification = "sighting.send_notification"
+
+// Dispatcher is responsible for turning sighting recorded events into notification jobs.
+type Dispatcher struct {
+	queue worker.Queue
+}
+
+// NewDispatcher creates an instance of Dispatcher.
+func NewDispatcher(queue worker.Queue) *Dispatcher {
+	return &Dispatcher{queue: queue}
+}
+
+// Handle enqueues a JobSendNotification for each prior reporter of an EventSightingRecorded event.
+// If one of them fails the event is retried, so the reporters before it may be notified twice.
+func (d *Dispatcher) Handle(ctx context.Context, event *entity.OutboxEvent) error {
+	var payload entity.SightingRecordedEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return err
+	}
+
+	for _, reporter := range payload.PriorReporters {
+		data, err := json.Marshal(&entity.SightingNotification{
+			Recipient: reporter,
+			TigerID:   payload.TigerID,
+			TigerName: payload.TigerName,
+			SeenAt:    payload.SeenAt,
+			Latitude:  payload.Latitude,
+			Longitude: payload.Longitude,
+		})
+		if err != nil {
+			return err
+		}
+		if err = d.queue.Enqueue(ctx, JobSendNotification, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mailer is responsible for sending the notification of a JobSendNotification job by email.
+type Mailer struct {
+	sender smtp.Sender
+}
+
+// NewMailer creates an instance of Mailer.
+func NewMailer(sender smtp.Sender) *Mailer {
+	return &Mailer{sender: sender}
+}
+
+// Handle sends the notification of the job. A failed notification is returned so that the job is retried.
+func (m *Mailer) Handle(ctx context.Context, job *worker.Job) error {
+	var notification entity.SightingNotification
+	if err := json.Unmarshal(job.Payload, &notification); err != nil {
+		return err
+	}
+
+	subject, body := composeMessage(&notification)
+	return m.sender.Send(ctx, notification.Recipient, subject, body)
+}
+
+func composeMessage(notification *entity.SightingNotification) (subject, body string) {
+	subject = fmt.Sprintf("Tiger %s was sighted again", notification.TigerName)
+	body = fmt.Sprintf("Hello,\n\n"+
+		"You reported a sighting of tiger %s before. The tiger was sighted again:\n\n"+
+		"Seen at: %s\n"+
+		"Location: %.6f, %.6f\n",
+		notification.TigerName,
+		notification.SeenAt.UTC().Format(time.RFC1123),
+		notification.Latitude,
+		notification.Longitude,
+	)
+	return subject, body
+}
@@ -0,0 +1,134 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/notification"
+	mock_smtp "github.com/ibrahimker/tigerhall-kittens/test/mock/driver/smtp"
+	mock_worker "github.com/ibrahimker/tigerhall-kittens/test/mock/worker"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+type NotificationTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+func notificationPayload(recipient string) []byte {
+	return []byte(`{"recipient":"` + recipient + `","tiger_id":1,"tiger_name":"Tiger-1","seen_at":"2022-01-01T12:00:00Z",` +
+		`"latitude":-6.18,"longitude":108}`)
+}
+
+func TestDispatcher_Handle(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	sightingRecorded := func(payload string) *entity.OutboxEvent {
+		return &entity.OutboxEvent{ID: 1, Type: entity.EventSightingRecorded, AggregateID: 1, Payload: []byte(payload)}
+	}
+	payload := `{"sighting_id":3,"tiger_id":1,"tiger_name":"Tiger-1","seen_at":"2022-01-01T12:00:00Z",` +
+		`"latitude":-6.18,"longitude":108,"reporter_name":"Ranger","prior_reporters":["a@example.com","b@example.com"]}`
+
+	testCases := []NotificationTestCase{
+		{
+			testcaseName: "successfully enqueue a notification job for each prior reporter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				gomock.InOrder(
+					mockQueue.EXPECT().Enqueue(mockCtx, notification.JobSendNotification, notificationPayload("a@example.com")).Return(nil),
+					mockQueue.EXPECT().Enqueue(mockCtx, notification.JobSendNotification, notificationPayload("b@example.com")).Return(nil),
+				)
+
+				require.NoError(t, notification.NewDispatcher(mockQueue).Handle(mockCtx, sightingRecorded(payload)))
+			},
+		},
+		{
+			testcaseName: "successfully enqueue nothing without prior reporter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+
+				require.NoError(t, notification.NewDispatcher(mockQueue).Handle(mockCtx, sightingRecorded(`{"tiger_id":1}`)))
+			},
+		},
+		{
+			testcaseName: "Error when enqueue job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				mockQueue.EXPECT().Enqueue(mockCtx, notification.JobSendNotification, gomock.Any()).Return(errors.New("redis error"))
+
+				require.Error(t, notification.NewDispatcher(mockQueue).Handle(mockCtx, sightingRecorded(payload)))
+			},
+		},
+		{
+			testcaseName: "Error invalid payload",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+
+				require.Error(t, notification.NewDispatcher(mockQueue).Handle(mockCtx, sightingRecorded("not-json")))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestMailer_Handle(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []NotificationTestCase{
+		{
+			testcaseName: "successfully send the notification",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockSender := mock_smtp.NewMockSender(gomock.NewController(t))
+				mockSender.EXPECT().
+					Send(mockCtx, "a@example.com", "Tiger Tiger-1 was sighted again", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _, body string) error {
+						require.Contains(t, body, "Seen at: Sat, 01 Jan 2022 12:00:00 UTC")
+						require.Contains(t, body, "Location: -6.180000, 108.000000")
+						return nil
+					})
+
+				job := &worker.Job{Type: notification.JobSendNotification, Payload: notificationPayload("a@example.com")}
+				require.NoError(t, notification.NewMailer(mockSender).Handle(mockCtx, job))
+			},
+		},
+		{
+			testcaseName: "Error when send email",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockSender := mock_smtp.NewMockSender(gomock.NewController(t))
+				mockSender.EXPECT().Send(mockCtx, "a@example.com", gomock.Any(), gomock.Any()).Return(errors.New("smtp error"))
+
+				job := &worker.Job{Type: notification.JobSendNotification, Payload: notificationPayload("a@example.com")}
+				require.Error(t, notification.NewMailer(mockSender).Handle(mockCtx, job))
+			},
+		},
+		{
+			testcaseName: "Error invalid payload",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				mockSender := mock_smtp.NewMockSender(gomock.NewController(t))
+
+				job := &worker.Job{Type: notification.JobSendNotification, Payload: []byte("not-json")}
+				require.Error(t, notification.NewMailer(mockSender).Handle(mockCtx, job))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
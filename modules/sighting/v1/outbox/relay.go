@@ -0,0 +1,179 @@
+// Package outbox dispatches the domain events stored in the outbox to their handlers.
+// Events are written in the same transaction as the change they describe, so a side effect is never lost
+// even if the process stops right after the change is committed.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+// Handler defines the interface to handle an outbox event.
+// An event is delivered at least once, hence Handle must be idempotent.
+// Once a handler succeeds the event isn't delivered to it again, even if another handler of the event fails,
+// unless recording its success fails too.
+// Events of the same aggregate may be delivered out of order if one of them is retried.
+type Handler interface {
+	Handle(ctx context.Context, event *entity.OutboxEvent) error
+}
+
+// HandlerFunc is an adapter to use an ordinary function as a Handler.
+type HandlerFunc func(ctx context.Context, event *entity.OutboxEvent) error
+
+// Handle calls f(ctx, event).
+func (f HandlerFunc) Handle(ctx context.Context, event *entity.OutboxEvent) error {
+	return f(ctx, event)
+}
+
+// Repository defines the interface to the outbox storage.
+type Repository interface {
+	// ClaimOutboxEvents get at most limit pending events due for dispatch order by ID, with the handlers which already
+	// handled them, and hide them from other relays until leaseUntil. The attempts of every claimed event are incremented.
+	ClaimOutboxEvents(ctx context.Context, limit int32, leaseUntil time.Time) ([]*entity.OutboxEvent, error)
+	// MarkOutboxEventHandled record that the handler of the given name handled the event
+	MarkOutboxEventHandled(ctx context.Context, eventID int64, handler string) error
+	// MarkOutboxEventDispatched mark the event as dispatched so that it is never claimed again
+	MarkOutboxEventDispatched(ctx context.Context, eventID int64) error
+	// MarkOutboxEventFailed record the error of the last dispatch of the event
+	// The event is claimed again at nextAttemptAt, unless dead is set.
+	MarkOutboxEventFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+// Relay is responsible for dispatching outbox events to the handlers registered for their type.
+// Several relays may run concurrently, each event is claimed by one of them at a time.
+type Relay struct {
+	repo     Repository
+	logger   *logrus.Entry
+	cfg      config.Outbox
+	handlers map[string][]namedHandler
+}
+
+// namedHandler is a handler with the name its success is recorded under.
+type namedHandler struct {
+	name    string
+	handler Handler
+}
+
+// NewRelay creates an instance of Relay.
+func NewRelay(repo Repository, logger *logrus.Entry, cfg *config.Outbox) *Relay {
+	return &Relay{
+		repo:     repo,
+		logger:   logger,
+		cfg:      *cfg,
+		handlers: make(map[string][]namedHandler),
+	}
+}
+
+// Register adds the handler of the given event type. An event is dispatched once every handler of its type succeeds.
+// The success of a handler is recorded under its name, which must be unique for the event type and kept across releases,
+// so that a retried event is only delivered to the handlers which failed.
+// Events without handler are marked as dispatched. Register must not be called once the relay runs.
+func (r *Relay) Register(eventType, name string, handler Handler) {
+	for _, h := range r.handlers[eventType] {
+		if h.name == name {
+			panic(fmt.Sprintf("outbox: handler %s registered twice for %s", name, eventType))
+		}
+	}
+	r.handlers[eventType] = append(r.handlers[eventType], namedHandler{name: name, handler: handler})
+}
+
+// Run dispatches events until ctx is done.
+// It polls the outbox every poll interval, or immediately while the last batch was full.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		n, err := r.ProcessBatch(ctx)
+		if err != nil {
+			logging.WithError(err, r.logger).Warn("Error when process outbox batch")
+		}
+
+		wait := r.cfg.PollInterval
+		if err == nil && n == r.cfg.BatchSize {
+			wait = 0
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// ProcessBatch claims one batch of due events and dispatches them. It returns the number of claimed events.
+// A failed event is retried after an exponential backoff, or moved to the dead letter once it reaches max attempts.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimOutboxEvents(ctx, int32(r.cfg.BatchSize), time.Now().Add(r.cfg.Lease))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		logger := r.logger.WithFields(logrus.Fields{
+			"event_id":   event.ID,
+			"event_type": event.Type,
+			"attempts":   event.Attempts,
+		})
+
+		derr := r.dispatch(ctx, event, logger)
+		if derr == nil {
+			if err = r.repo.MarkOutboxEventDispatched(ctx, event.ID); err != nil {
+				// the lease expires, so the event is dispatched again
+				logging.WithError(err, logger).Warn("Error when get from repo.MarkOutboxEventDispatched")
+			}
+			continue
+		}
+
+		dead := int(event.Attempts) >= r.cfg.MaxAttempts
+		if dead {
+			logging.WithError(derr, logger).Error("Outbox event moved to dead letter")
+		} else {
+			logging.WithError(derr, logger).Warn("Error when dispatch outbox event")
+		}
+		if err = r.repo.MarkOutboxEventFailed(ctx, event.ID, derr.Error(), time.Now().Add(worker.Backoff(r.cfg.MinBackoff, r.cfg.MaxBackoff, int(event.Attempts))), dead); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.MarkOutboxEventFailed")
+		}
+	}
+
+	return len(events), nil
+}
+
+// dispatch calls the handlers of the event which didn't handle it yet, and records the success of each of them
+// but the last, since the event is marked as dispatched after it.
+func (r *Relay) dispatch(ctx context.Context, event *entity.OutboxEvent, logger *logrus.Entry) error {
+	var pending []namedHandler
+	for _, h := range r.handlers[event.Type] {
+		if !contains(event.Handled, h.name) {
+			pending = append(pending, h)
+		}
+	}
+
+	for i, h := range pending {
+		if err := h.handler.Handle(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", h.name, err)
+		}
+		if i == len(pending)-1 {
+			break
+		}
+		if err := r.repo.MarkOutboxEventHandled(ctx, event.ID, h.name); err != nil {
+			// the handler is called again if the event is retried
+			logging.WithError(err, logger.WithField("handler", h.name)).Warn("Error when get from repo.MarkOutboxEventHandled")
+		}
+	}
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
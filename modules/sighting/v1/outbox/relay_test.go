@@ -0,0 +1,280 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/outbox"
+	mock_outbox "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/outbox"
+)
+
+type RelayTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+var relayConfig = &config.Outbox{
+	PollInterval: 10 * time.Millisecond,
+	BatchSize:    2,
+	Lease:        time.Minute,
+	MaxAttempts:  3,
+	MinBackoff:   time.Second,
+	MaxBackoff:   3 * time.Second,
+}
+
+// timeMatcher matches a time between from and now+max.
+type timeMatcher struct {
+	from time.Time
+	max  time.Duration
+}
+
+// within returns a matcher of a time between now+min and now+max.
+func within(min, max time.Duration) gomock.Matcher {
+	return timeMatcher{from: time.Now().Add(min), max: max}
+}
+
+func (m timeMatcher) Matches(x interface{}) bool {
+	v, ok := x.(time.Time)
+	return ok && !v.Before(m.from) && !v.After(time.Now().Add(m.max))
+}
+
+func (m timeMatcher) String() string {
+	return fmt.Sprintf("is between %s and now + %s", m.from, m.max)
+}
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	tigerCreated := func(attempts int32) *entity.OutboxEvent {
+		return &entity.OutboxEvent{ID: 1, Type: entity.EventTigerCreated, AggregateID: 1, Payload: []byte(`{}`), Attempts: attempts}
+	}
+
+	testCases := []RelayTestCase{
+		{
+			testcaseName: "Error when claim events",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), within(time.Minute, time.Minute+time.Second)).
+					Return(nil, errors.New("db error"))
+
+				n, err := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig).ProcessBatch(mockCtx)
+				require.Error(t, err)
+				require.Zero(t, n)
+			},
+		},
+		{
+			testcaseName: "successfully dispatch events to every handler of their type",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				sightingRecorded := &entity.OutboxEvent{ID: 2, Type: entity.EventSightingRecorded, Attempts: 1}
+				unhandled := &entity.OutboxEvent{ID: 3, Type: "Unknown", Attempts: 1}
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{tigerCreated(1), sightingRecorded, unhandled}, nil)
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(1)).Return(nil)
+				mockRepo.EXPECT().MarkOutboxEventHandled(mockCtx, int64(2), "cache").Return(nil)
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(2)).Return(nil)
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(3)).Return(nil)
+
+				var handled []string
+				record := func(name string) outbox.HandlerFunc {
+					return func(_ context.Context, event *entity.OutboxEvent) error {
+						handled = append(handled, name+":"+event.Type)
+						return nil
+					}
+				}
+				relay := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventTigerCreated, "cache", record("cache"))
+				relay.Register(entity.EventSightingRecorded, "cache", record("cache"))
+				relay.Register(entity.EventSightingRecorded, "notification", record("notification"))
+
+				n, err := relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, 3, n)
+				require.Equal(t, []string{"cache:TigerCreated", "cache:SightingRecorded", "notification:SightingRecorded"}, handled)
+			},
+		},
+		{
+			testcaseName: "failed event is retried after an exponential backoff",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{tigerCreated(2)}, nil)
+				mockRepo.EXPECT().MarkOutboxEventFailed(mockCtx, int64(1), "cache: redis error", within(2*time.Second, 2*time.Second+time.Second), false).
+					Return(nil)
+
+				relay := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventTigerCreated, "cache", outbox.HandlerFunc(func(context.Context, *entity.OutboxEvent) error {
+					return errors.New("redis error")
+				}))
+
+				n, err := relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, 1, n)
+			},
+		},
+		{
+			testcaseName: "retried event is only dispatched to the handlers which didn't handle it",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				sightingRecorded := &entity.OutboxEvent{ID: 2, Type: entity.EventSightingRecorded, Attempts: 2, Handled: []string{"cache"}}
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{sightingRecorded}, nil)
+				mockRepo.EXPECT().MarkOutboxEventHandled(mockCtx, int64(2), "notification").Return(nil)
+				mockRepo.EXPECT().MarkOutboxEventFailed(mockCtx, int64(2), "webhook: db error", gomock.Any(), false).Return(nil)
+
+				var handled []string
+				handler := func(name string, err error) outbox.HandlerFunc {
+					return func(context.Context, *entity.OutboxEvent) error {
+						handled = append(handled, name)
+						return err
+					}
+				}
+				relay := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventSightingRecorded, "cache", handler("cache", nil))
+				relay.Register(entity.EventSightingRecorded, "notification", handler("notification", nil))
+				relay.Register(entity.EventSightingRecorded, "webhook", handler("webhook", errors.New("db error")))
+
+				_, err := relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, []string{"notification", "webhook"}, handled)
+
+				// the next retry only calls the failed handler
+				handled = nil
+				sightingRecorded = &entity.OutboxEvent{ID: 2, Type: entity.EventSightingRecorded, Attempts: 3, Handled: []string{"cache", "notification"}}
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{sightingRecorded}, nil)
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(2)).Return(nil)
+				relay = outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventSightingRecorded, "cache", handler("cache", nil))
+				relay.Register(entity.EventSightingRecorded, "notification", handler("notification", nil))
+				relay.Register(entity.EventSightingRecorded, "webhook", handler("webhook", nil))
+
+				_, err = relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, []string{"webhook"}, handled)
+			},
+		},
+		{
+			testcaseName: "event is dispatched even if recording a handler fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{{ID: 2, Type: entity.EventSightingRecorded, Attempts: 1}}, nil)
+				mockRepo.EXPECT().MarkOutboxEventHandled(mockCtx, int64(2), "cache").Return(errors.New("db error"))
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(2)).Return(nil)
+
+				noop := outbox.HandlerFunc(func(context.Context, *entity.OutboxEvent) error { return nil })
+				relay := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventSightingRecorded, "cache", noop)
+				relay.Register(entity.EventSightingRecorded, "notification", noop)
+
+				_, err := relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "failed event is moved to dead letter at max attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{tigerCreated(3)}, nil)
+				mockRepo.EXPECT().MarkOutboxEventFailed(mockCtx, int64(1), "cache: redis error", within(3*time.Second, 3*time.Second+time.Second), true).
+					Return(nil)
+
+				relay := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig)
+				relay.Register(entity.EventTigerCreated, "cache", outbox.HandlerFunc(func(context.Context, *entity.OutboxEvent) error {
+					return errors.New("redis error")
+				}))
+
+				_, err := relay.ProcessBatch(mockCtx)
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "event is not dispatched again by the batch if marking it fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				mockRepo := mock_outbox.NewMockRepository(ctrl)
+				mockRepo.EXPECT().ClaimOutboxEvents(mockCtx, int32(2), gomock.Any()).
+					Return([]*entity.OutboxEvent{tigerCreated(1)}, nil)
+				mockRepo.EXPECT().MarkOutboxEventDispatched(mockCtx, int64(1)).Return(errors.New("db error"))
+
+				n, err := outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig).ProcessBatch(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, 1, n)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestRelay_Register(t *testing.T) {
+	t.Parallel()
+	relay := outbox.NewRelay(mock_outbox.NewMockRepository(gomock.NewController(t)), logging.NewTestLogger(), relayConfig)
+	noop := outbox.HandlerFunc(func(context.Context, *entity.OutboxEvent) error { return nil })
+	relay.Register(entity.EventTigerCreated, "cache", noop)
+	relay.Register(entity.EventTigerUpdated, "cache", noop)
+
+	require.Panics(t, func() { relay.Register(entity.EventTigerCreated, "cache", noop) })
+}
+
+func TestRelay_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dispatch events until context is done", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_outbox.NewMockRepository(ctrl)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		full := []*entity.OutboxEvent{{ID: 1, Type: entity.EventTigerCreated}, {ID: 2, Type: entity.EventTigerCreated}}
+		gomock.InOrder(
+			mockRepo.EXPECT().ClaimOutboxEvents(ctx, int32(2), gomock.Any()).Return(full, nil),
+			mockRepo.EXPECT().ClaimOutboxEvents(ctx, int32(2), gomock.Any()).Return(nil, errors.New("db error")),
+			mockRepo.EXPECT().ClaimOutboxEvents(ctx, int32(2), gomock.Any()).
+				DoAndReturn(func(context.Context, int32, time.Time) ([]*entity.OutboxEvent, error) {
+					cancel()
+					return nil, nil
+				}),
+		)
+		mockRepo.EXPECT().MarkOutboxEventDispatched(ctx, gomock.Any()).Return(nil).Times(2)
+
+		done := make(chan struct{})
+		go func() {
+			outbox.NewRelay(mockRepo, logging.NewTestLogger(), relayConfig).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("relay did not stop")
+		}
+	})
+}
@@ -2,21 +2,24 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
 )
 
-func queryWrapper(ctx context.Context, pool PgxPoolIface, queryString string, args ...interface{}) (pgx.Rows, error) {
+func queryWrapper(ctx context.Context, conn querier, queryString string, args ...interface{}) (pgx.Rows, error) {
 	logger := logging.FromContext(ctx).WithFields(logrus.Fields{
 		"sub-repo-name": "repo.queryWrapper",
 		"queryString":   queryString,
 		"args":          args,
 	})
 
-	rows, err := pool.Query(ctx, queryString, args...)
+	rows, err := conn.Query(ctx, queryString, args...)
 	if err != nil {
 		logging.WithError(err, logger).Warn("Error when execute query")
 		return nil, err
@@ -28,3 +31,83 @@ func queryWrapper(ctx context.Context, pool PgxPoolIface, queryString string, ar
 
 	return rows, nil
 }
+
+// geographyPoint builds a PostGIS geography point (SRID 4326) from latitude and longitude placeholders.
+// PostGIS expects longitude first.
+func geographyPoint(latitude, longitude string) string {
+	return fmt.Sprintf("ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography", longitude, latitude)
+}
+
+// tigerFilterCondition builds WHERE condition of sighting.tiger and its positional arguments.
+func tigerFilterCondition(filter *entity.TigerFilter, cursor *entity.TigerCursor) (string, []interface{}) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"deleted_at IS NULL"}
+	if filter != nil {
+		if filter.NamePrefix != "" {
+			conditions = append(conditions, "name LIKE "+arg(escapeLike(filter.NamePrefix)+"%"))
+		}
+		if !filter.LastSeenAfter.IsZero() {
+			conditions = append(conditions, "last_seen_timestamp >= "+arg(filter.LastSeenAfter))
+		}
+		if !filter.LastSeenBefore.IsZero() {
+			conditions = append(conditions, "last_seen_timestamp < "+arg(filter.LastSeenBefore))
+		}
+		if box := filter.BoundingBox; box != nil {
+			conditions = append(conditions, "last_seen_latitude BETWEEN "+arg(box.MinLatitude)+" AND "+arg(box.MaxLatitude))
+			if box.MinLongitude <= box.MaxLongitude {
+				conditions = append(conditions, "last_seen_longitude BETWEEN "+arg(box.MinLongitude)+" AND "+arg(box.MaxLongitude))
+			} else {
+				conditions = append(conditions, "(last_seen_longitude >= "+arg(box.MinLongitude)+" OR last_seen_longitude <= "+arg(box.MaxLongitude)+")")
+			}
+		}
+	}
+	if cursor != nil {
+		conditions = append(conditions, "(last_seen_timestamp, id) < ("+arg(cursor.LastSeenTimestamp)+", "+arg(cursor.ID)+")")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// sightingFilterCondition builds WHERE condition of sighting.sighting for given tiger ID and its positional arguments.
+func sightingFilterCondition(tigerID int32, filter *entity.SightingFilter, cursor *entity.SightingCursor) (string, []interface{}) {
+	args := []interface{}{tigerID}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"tiger_id = $1", "deleted_at IS NULL"}
+	if filter != nil {
+		if !filter.SeenFrom.IsZero() {
+			conditions = append(conditions, "seen_at >= "+arg(filter.SeenFrom))
+		}
+		if !filter.SeenTo.IsZero() {
+			conditions = append(conditions, "seen_at < "+arg(filter.SeenTo))
+		}
+	}
+	if cursor != nil {
+		conditions = append(conditions, "(seen_at, id) < ("+arg(cursor.SeenAt)+", "+arg(cursor.ID)+")")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes LIKE wildcards so that s is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// imageHashBands splits an image hash into the 8 bands of 8 bits indexed by idx_sighting_image_hash_byte_N, highest first.
+// Hashes differing by at most 7 bits share at least one band, by the pigeonhole principle.
+func imageHashBands(hash uint64) [8]int64 {
+	var bands [8]int64
+	for i := range bands {
+		bands[i] = int64(hash >> (56 - 8*i) & 0xff)
+	}
+	return bands
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// Statuses of an outbox event
+const (
+	outboxStatusPending    = "pending"
+	outboxStatusDispatched = "dispatched"
+	outboxStatusDead       = "dead"
+)
+
+// CreateOutboxEvent store a new pending event in the outbox and set its ID
+// Call it with the context of the transaction making the change described by the event.
+func (t *TigerSightingRepo) CreateOutboxEvent(ctx context.Context, event *entity.OutboxEvent) error {
+	logger := logging.NewRepoLogger(ctx, "CreateOutboxEvent", logrus.Fields{})
+
+	queryString := "INSERT INTO sighting.outbox (event_type,aggregate_id,payload,status,created_at) " +
+		"VALUES ($1, $2, $3, $4, $5) RETURNING id"
+
+	event.CreatedAt = time.Now()
+	err := t.conn(ctx).QueryRow(ctx, queryString,
+		event.Type,
+		event.AggregateID,
+		event.Payload,
+		outboxStatusPending,
+		event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+	}
+
+	return err
+}
+
+// ClaimOutboxEvents get at most limit pending events due for dispatch from database order by ID, with the handlers
+// which already handled them, and hide them from other relays until leaseUntil. The attempts of every claimed event are incremented.
+// Events locked by a concurrent claim are skipped.
+func (t *TigerSightingRepo) ClaimOutboxEvents(ctx context.Context, limit int32, leaseUntil time.Time) ([]*entity.OutboxEvent, error) {
+	logger := logging.NewRepoLogger(ctx, "ClaimOutboxEvents", logrus.Fields{})
+
+	queryString := `UPDATE sighting.outbox SET attempts = attempts + 1, next_attempt_at = $2
+WHERE id IN (SELECT id FROM sighting.outbox WHERE status = $3 AND next_attempt_at <= $4 ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED)
+RETURNING id, event_type, aggregate_id, payload, attempts, created_at,
+ARRAY(SELECT handler FROM sighting.outbox_handler WHERE event_id = outbox.id ORDER BY handler) AS handled`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, limit, leaseUntil, outboxStatusPending, time.Now())
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.OutboxEvent
+	for rows.Next() {
+		var tmp entity.OutboxEvent
+		if serr := rows.Scan(&tmp.ID, &tmp.Type, &tmp.AggregateID, &tmp.Payload, &tmp.Attempts, &tmp.CreatedAt, &tmp.Handled); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		res = append(res, &tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	// UPDATE ... RETURNING doesn't keep the order of the sub query
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+// MarkOutboxEventHandled record that the handler of the given name handled the event
+// Recording it again is a no-op.
+func (t *TigerSightingRepo) MarkOutboxEventHandled(ctx context.Context, eventID int64, handler string) error {
+	logger := logging.NewRepoLogger(ctx, "MarkOutboxEventHandled", logrus.Fields{})
+
+	queryString := "INSERT INTO sighting.outbox_handler (event_id,handler,handled_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING"
+	if _, err := t.conn(ctx).Exec(ctx, queryString, eventID, handler, time.Now()); err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+
+	return nil
+}
+
+// MarkOutboxEventDispatched mark the event as dispatched so that it is never claimed again
+func (t *TigerSightingRepo) MarkOutboxEventDispatched(ctx context.Context, eventID int64) error {
+	logger := logging.NewRepoLogger(ctx, "MarkOutboxEventDispatched", logrus.Fields{})
+
+	queryString := "UPDATE sighting.outbox SET status = $2, last_error = '', dispatched_at = $3 WHERE id = $1"
+	if _, err := t.conn(ctx).Exec(ctx, queryString, eventID, outboxStatusDispatched, time.Now()); err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+
+	return nil
+}
+
+// MarkOutboxEventFailed record the error of the last dispatch of the event
+// The event is claimed again at nextAttemptAt, unless dead is set. A dead event is never claimed again.
+func (t *TigerSightingRepo) MarkOutboxEventFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	logger := logging.NewRepoLogger(ctx, "MarkOutboxEventFailed", logrus.Fields{})
+
+	status := outboxStatusPending
+	if dead {
+		status = outboxStatusDead
+	}
+	queryString := "UPDATE sighting.outbox SET status = $2, last_error = $3, next_attempt_at = $4 WHERE id = $1"
+	if _, err := t.conn(ctx).Exec(ctx, queryString, eventID, status, lastError, nextAttemptAt); err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+func TestCreateOutboxEvent(t *testing.T) {
+	t.Parallel()
+	queryString := `INSERT INTO sighting.outbox \(event_type,aggregate_id,payload,status,created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id`
+	newEvent := func() *entity.OutboxEvent {
+		return &entity.OutboxEvent{Type: entity.EventTigerCreated, AggregateID: 1, Payload: []byte(`{"tiger_id":1}`)}
+	}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when insert to database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.CreateOutboxEvent(context.Background(), newEvent())
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly create outbox event",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(entity.EventTigerCreated, int32(1), []byte(`{"tiger_id":1}`), "pending", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(42)))
+
+				event := newEvent()
+				err := repositorySuite.repo.CreateOutboxEvent(context.Background(), event)
+				require.NoError(t, err)
+				require.Equal(t, int64(42), event.ID)
+				require.False(t, event.CreatedAt.IsZero())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestClaimOutboxEvents(t *testing.T) {
+	t.Parallel()
+	queryString := `UPDATE sighting.outbox SET attempts = attempts \+ 1, next_attempt_at = \$2
+WHERE id IN \(SELECT id FROM sighting.outbox WHERE status = \$3 AND next_attempt_at <= \$4 ORDER BY id LIMIT \$1 FOR UPDATE SKIP LOCKED\)
+RETURNING id, event_type, aggregate_id, payload, attempts, created_at,
+ARRAY\(SELECT handler FROM sighting.outbox_handler WHERE event_id = outbox.id ORDER BY handler\) AS handled`
+	queryStringRow := []string{"id", "event_type", "aggregate_id", "payload", "attempts", "created_at", "handled"}
+	leaseUntil := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2022, 1, 1, 11, 0, 0, 0, time.UTC)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.ClaimOutboxEvents(context.Background(), 10, leaseUntil)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("not-an-id"))
+
+				_, err := repositorySuite.repo.ClaimOutboxEvents(context.Background(), 10, leaseUntil)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly claim events order by ID",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(10), leaseUntil, "pending", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow(int64(2), entity.EventSightingRecorded, int32(1), []byte(`{}`), int32(2), createdAt, []string{"cache", "notification"}).
+						AddRow(int64(1), entity.EventTigerCreated, int32(1), []byte(`{}`), int32(3), createdAt, []string{}),
+					)
+
+				res, err := repositorySuite.repo.ClaimOutboxEvents(context.Background(), 10, leaseUntil)
+				require.NoError(t, err)
+				require.Equal(t, []*entity.OutboxEvent{
+					{ID: 1, Type: entity.EventTigerCreated, AggregateID: 1, Payload: []byte(`{}`), Attempts: 3, CreatedAt: createdAt, Handled: []string{}},
+					{
+						ID: 2, Type: entity.EventSightingRecorded, AggregateID: 1, Payload: []byte(`{}`), Attempts: 2, CreatedAt: createdAt,
+						Handled: []string{"cache", "notification"},
+					},
+				}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestMarkOutboxEventHandled(t *testing.T) {
+	t.Parallel()
+	queryString := `INSERT INTO sighting.outbox_handler \(event_id,handler,handled_at\) VALUES \(\$1, \$2, \$3\) ON CONFLICT DO NOTHING`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when insert to database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.MarkOutboxEventHandled(context.Background(), 1, "notification")
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly mark event as handled by the handler",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int64(1), "notification", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				err := repositorySuite.repo.MarkOutboxEventHandled(context.Background(), 1, "notification")
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestMarkOutboxEventDispatched(t *testing.T) {
+	t.Parallel()
+	queryString := `UPDATE sighting.outbox SET status = \$2, last_error = '', dispatched_at = \$3 WHERE id = \$1`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when update database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.MarkOutboxEventDispatched(context.Background(), 1)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly mark event as dispatched",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int64(1), "dispatched", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				err := repositorySuite.repo.MarkOutboxEventDispatched(context.Background(), 1)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestMarkOutboxEventFailed(t *testing.T) {
+	t.Parallel()
+	queryString := `UPDATE sighting.outbox SET status = \$2, last_error = \$3, next_attempt_at = \$4 WHERE id = \$1`
+	nextAttemptAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when update database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.MarkOutboxEventFailed(context.Background(), 1, "smtp error", nextAttemptAt, false)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly schedule the next attempt",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int64(1), "pending", "smtp error", nextAttemptAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				err := repositorySuite.repo.MarkOutboxEventFailed(context.Background(), 1, "smtp error", nextAttemptAt, false)
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly move the event to dead letter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int64(1), "dead", "smtp error", nextAttemptAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				err := repositorySuite.repo.MarkOutboxEventFailed(context.Background(), 1, "smtp error", nextAttemptAt, true)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -3,6 +3,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgconn"
@@ -20,6 +21,7 @@ type PgxPoolIface interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 	Ping(ctx context.Context) error
 }
 
@@ -33,13 +35,16 @@ func NewTigerSightingRepo(pool PgxPoolIface) *TigerSightingRepo {
 	return &TigerSightingRepo{pool: pool}
 }
 
-// GetTigers get list of tigers from database order by last seen timestamp
-func (t *TigerSightingRepo) GetTigers(ctx context.Context) ([]*entity.Tiger, error) {
+// GetTigers get a page of tigers matching the filter from database order by last seen timestamp
+// Tigers positioned after the cursor are returned. Nil cursor means the first page.
+func (t *TigerSightingRepo) GetTigers(ctx context.Context, filter *entity.TigerFilter, cursor *entity.TigerCursor, limit int32) ([]*entity.Tiger, error) {
 	logger := logging.NewRepoLogger(ctx, "GetTigers", logrus.Fields{})
 
-	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at
-FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
-	rows, err := queryWrapper(ctx, t.pool, queryString)
+	where, args := tigerFilterCondition(filter, cursor)
+	args = append(args, limit)
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
+FROM sighting.tiger WHERE ` + where + fmt.Sprintf(` ORDER BY last_seen_timestamp desc, id desc LIMIT $%d`, len(args))
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, args...)
 	if err != nil {
 		logging.WithError(err, logger).Warn("Error when hit query wrapper")
 		return []*entity.Tiger{}, err
@@ -50,7 +55,7 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 	for rows.Next() {
 		var tmp entity.Tiger
 		if serr := rows.Scan(
-			&tmp.ID, &tmp.Name, &tmp.DateOfBirth, &tmp.LastSeenTimestamp, &tmp.LastSeenLatitude, &tmp.LastSeenLongitude,
+			&tmp.ID, &tmp.Name, &tmp.DateOfBirth, &tmp.LastSeenTimestamp, &tmp.LastSeenLatitude, &tmp.LastSeenLongitude, &tmp.MaxSightingDistance,
 			&tmp.CreatedAt, &tmp.UpdatedAt,
 		); serr != nil {
 			logging.WithError(serr, logger).Warn("Error when scan rows")
@@ -67,12 +72,32 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 }
 
 // GetTigerByID get tiger by ID from database
+// It returns entity.ErrTigerNotFound if the tiger doesn't exist.
 func (t *TigerSightingRepo) GetTigerByID(ctx context.Context, tigerID int32) (*entity.Tiger, error) {
 	logger := logging.NewRepoLogger(ctx, "GetTigerByID", logrus.Fields{})
 
-	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
 FROM sighting.tiger WHERE id = $1 and deleted_at IS NULL`
-	rows, err := queryWrapper(ctx, t.pool, queryString, tigerID)
+	return t.getTiger(ctx, logger, queryString, tigerID)
+}
+
+// GetTigerByIDForUpdate get tiger by ID from database and lock the row until the transaction ends.
+// It must be called with a context from WithTransaction.
+func (t *TigerSightingRepo) GetTigerByIDForUpdate(ctx context.Context, tigerID int32) (*entity.Tiger, error) {
+	logger := logging.NewRepoLogger(ctx, "GetTigerByIDForUpdate", logrus.Fields{})
+
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); !ok {
+		logging.WithError(errNoTransaction, logger).Warn("Error when check transaction")
+		return nil, errNoTransaction
+	}
+
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
+FROM sighting.tiger WHERE id = $1 and deleted_at IS NULL FOR UPDATE`
+	return t.getTiger(ctx, logger, queryString, tigerID)
+}
+
+func (t *TigerSightingRepo) getTiger(ctx context.Context, logger *logrus.Entry, queryString string, tigerID int32) (*entity.Tiger, error) {
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, tigerID)
 	if err != nil {
 		logging.WithError(err, logger).Warn("Error when hit query wrapper")
 		return nil, err
@@ -80,9 +105,11 @@ FROM sighting.tiger WHERE id = $1 and deleted_at IS NULL`
 	defer rows.Close()
 
 	var res entity.Tiger
+	found := false
 	for rows.Next() {
+		found = true
 		if serr := rows.Scan(
-			&res.ID, &res.Name, &res.DateOfBirth, &res.LastSeenTimestamp, &res.LastSeenLatitude, &res.LastSeenLongitude,
+			&res.ID, &res.Name, &res.DateOfBirth, &res.LastSeenTimestamp, &res.LastSeenLatitude, &res.LastSeenLongitude, &res.MaxSightingDistance,
 			&res.CreatedAt, &res.UpdatedAt,
 		); serr != nil {
 			logging.WithError(serr, logger).Warn("Error when scan rows")
@@ -93,20 +120,24 @@ FROM sighting.tiger WHERE id = $1 and deleted_at IS NULL`
 		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
 		return nil, rows.Err()
 	}
+	if !found {
+		return nil, entity.ErrTigerNotFound
+	}
 
 	return &res, nil
 }
 
-// CreateTiger store a new tiger in database
+// CreateTiger store a new tiger in database and set its ID
+// last_seen_location is kept in sync with last_seen_latitude and last_seen_longitude.
 func (t *TigerSightingRepo) CreateTiger(ctx context.Context, tiger *entity.Tiger) error {
 	logger := logging.NewRepoLogger(ctx, "CreateTiger", logrus.Fields{})
 
 	queryString := "INSERT INTO sighting.tiger" +
-		" (name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at) " +
-		"VALUES ($1, $2, $3, $4, $5, $6, $7)"
+		" (name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,last_seen_location,created_at,updated_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $8, " + geographyPoint("$4", "$5") + ", $6, $7) RETURNING id"
 
 	currentTime := time.Now()
-	_, err := t.pool.Exec(ctx, queryString,
+	err := t.conn(ctx).QueryRow(ctx, queryString,
 		tiger.Name,
 		tiger.DateOfBirth,
 		tiger.LastSeenTimestamp,
@@ -114,7 +145,8 @@ func (t *TigerSightingRepo) CreateTiger(ctx context.Context, tiger *entity.Tiger
 		tiger.LastSeenLongitude,
 		currentTime,
 		currentTime,
-	)
+		tiger.MaxSightingDistance,
+	).Scan(&tiger.ID)
 	if err != nil {
 		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
 	}
@@ -123,14 +155,25 @@ func (t *TigerSightingRepo) CreateTiger(ctx context.Context, tiger *entity.Tiger
 }
 
 // UpdateTiger update tiger data in database
+// last_seen_location is kept in sync with last_seen_latitude and last_seen_longitude.
 func (t *TigerSightingRepo) UpdateTiger(ctx context.Context, tiger *entity.Tiger) error {
 	logger := logging.NewRepoLogger(ctx, "UpdateTiger", logrus.Fields{})
 
 	queryString := "UPDATE sighting.tiger " +
-		"SET last_seen_timestamp = $2, last_seen_latitude = $3, last_seen_longitude = $4, updated_at = $5 " +
+		"SET name = $2, date_of_birth = $3, last_seen_timestamp = $4, last_seen_latitude = $5, last_seen_longitude = $6, " +
+		"last_seen_location = " + geographyPoint("$5", "$6") + ", max_sighting_distance = $8, updated_at = $7 " +
 		"WHERE id = $1"
 
-	_, err := t.pool.Exec(ctx, queryString, tiger.ID, tiger.LastSeenTimestamp, tiger.LastSeenLatitude, tiger.LastSeenLongitude, time.Now())
+	_, err := t.conn(ctx).Exec(ctx, queryString,
+		tiger.ID,
+		tiger.Name,
+		tiger.DateOfBirth,
+		tiger.LastSeenTimestamp,
+		tiger.LastSeenLatitude,
+		tiger.LastSeenLongitude,
+		time.Now(),
+		tiger.MaxSightingDistance,
+	)
 	if err != nil {
 		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
 	}
@@ -138,13 +181,189 @@ func (t *TigerSightingRepo) UpdateTiger(ctx context.Context, tiger *entity.Tiger
 	return err
 }
 
-// GetSightingsByTigerID get list of sightings for given tiger ID order by latest sighting
-func (t *TigerSightingRepo) GetSightingsByTigerID(ctx context.Context, tigerID int32) ([]*entity.Sighting, error) {
+// DeleteTiger soft delete tiger in database by setting its deleted_at
+// It returns entity.ErrTigerNotFound if the tiger doesn't exist or has been deleted.
+func (t *TigerSightingRepo) DeleteTiger(ctx context.Context, tigerID int32) error {
+	logger := logging.NewRepoLogger(ctx, "DeleteTiger", logrus.Fields{})
+
+	queryString := "UPDATE sighting.tiger " +
+		"SET deleted_at = $2, updated_at = $2 " +
+		"WHERE id = $1 and deleted_at IS NULL"
+
+	tag, err := t.conn(ctx).Exec(ctx, queryString, tigerID, time.Now())
+	if err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrTigerNotFound
+	}
+
+	return nil
+}
+
+// GetTigersNearby get at most limit tigers last seen within radius meters of the given point from database
+// order by distance, nearest first. Zero since means tigers are not filtered by last seen timestamp.
+func (t *TigerSightingRepo) GetTigersNearby(ctx context.Context, latitude, longitude, radius float64, since time.Time, limit int32) ([]*entity.NearbyTiger, error) {
+	logger := logging.NewRepoLogger(ctx, "GetTigersNearby", logrus.Fields{})
+
+	point := geographyPoint("$1", "$2")
+	args := []interface{}{latitude, longitude, radius}
+	where := "deleted_at IS NULL AND ST_DWithin(last_seen_location, " + point + ", $3)"
+	if !since.IsZero() {
+		args = append(args, since)
+		where += fmt.Sprintf(" AND last_seen_timestamp >= $%d", len(args))
+	}
+	args = append(args, limit)
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at,
+ST_Distance(last_seen_location, ` + point + `) AS distance
+FROM sighting.tiger WHERE ` + where + fmt.Sprintf(`
+ORDER BY distance, id LIMIT $%d`, len(args))
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, args...)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return []*entity.NearbyTiger{}, err
+	}
+	defer rows.Close()
+
+	var res []*entity.NearbyTiger
+	for rows.Next() {
+		var tmp entity.NearbyTiger
+		if serr := rows.Scan(
+			&tmp.ID, &tmp.Name, &tmp.DateOfBirth, &tmp.LastSeenTimestamp, &tmp.LastSeenLatitude, &tmp.LastSeenLongitude, &tmp.MaxSightingDistance,
+			&tmp.CreatedAt, &tmp.UpdatedAt, &tmp.Distance,
+		); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			continue
+		}
+		res = append(res, &tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return []*entity.NearbyTiger{}, rows.Err()
+	}
+
+	return res, nil
+}
+
+// GetDistanceFromLastSeen get distance in meters between last seen location of given tiger ID and the given point
+// It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+func (t *TigerSightingRepo) GetDistanceFromLastSeen(ctx context.Context, tigerID int32, latitude, longitude float64) (float64, error) {
+	logger := logging.NewRepoLogger(ctx, "GetDistanceFromLastSeen", logrus.Fields{})
+
+	queryString := `SELECT ST_Distance(last_seen_location, ` + geographyPoint("$2", "$3") + `)
+FROM sighting.tiger WHERE id = $1 and deleted_at IS NULL`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, tigerID, latitude, longitude)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return 0, err
+	}
+	defer rows.Close()
+
+	var res float64
+	found := false
+	for rows.Next() {
+		found = true
+		if serr := rows.Scan(&res); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return 0, serr
+		}
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return 0, rows.Err()
+	}
+	if !found {
+		return 0, entity.ErrTigerNotFound
+	}
+
+	return res, nil
+}
+
+// GetAdjacentSightings get the latest sighting seen at or before seenAt and the earliest sighting seen after seenAt
+// for given tiger ID with their distance in meters from the given point. Nil means there is no such sighting.
+func (t *TigerSightingRepo) GetAdjacentSightings(ctx context.Context, tigerID int32, seenAt time.Time, latitude, longitude float64) (previous, next *entity.Waypoint, err error) {
+	logger := logging.NewRepoLogger(ctx, "GetAdjacentSightings", logrus.Fields{})
+
+	queryString := `(SELECT seen_at, ST_Distance(location, ` + geographyPoint("$3", "$4") + `)
+FROM sighting.sighting WHERE tiger_id = $1 AND deleted_at IS NULL AND seen_at <= $2 ORDER BY seen_at desc, id desc LIMIT 1)
+UNION ALL
+(SELECT seen_at, ST_Distance(location, ` + geographyPoint("$3", "$4") + `)
+FROM sighting.sighting WHERE tiger_id = $1 AND deleted_at IS NULL AND seen_at > $2 ORDER BY seen_at, id LIMIT 1)`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, tigerID, seenAt, latitude, longitude)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tmp entity.Waypoint
+		if serr := rows.Scan(&tmp.SeenAt, &tmp.Distance); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, nil, serr
+		}
+		if tmp.SeenAt.After(seenAt) {
+			next = &tmp
+		} else {
+			previous = &tmp
+		}
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, nil, rows.Err()
+	}
+
+	return previous, next, nil
+}
+
+// GetPriorReporters get distinct emails of everyone who reported a sighting of given tiger ID from database
+// The exclude email, usually the reporter of the new sighting, and empty emails are left out.
+func (t *TigerSightingRepo) GetPriorReporters(ctx context.Context, tigerID int32, exclude string) ([]string, error) {
+	logger := logging.NewRepoLogger(ctx, "GetPriorReporters", logrus.Fields{})
+
+	queryString := `SELECT DISTINCT reporter_email FROM sighting.sighting
+WHERE tiger_id = $1 AND deleted_at IS NULL AND reporter_email <> '' AND reporter_email <> $2 ORDER BY reporter_email`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, tigerID, exclude)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var email string
+		if serr := rows.Scan(&email); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		res = append(res, email)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	return res, nil
+}
+
+// GetSightingsByTigerID get a page of sightings matching the filter for given tiger ID order by latest sighting
+// Sightings positioned after the cursor are returned. Nil cursor means the first page.
+// image_data, only filled for sightings recorded before the image store, is selected only if filter.IncludeImageData is set.
+func (t *TigerSightingRepo) GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, cursor *entity.SightingCursor, limit int32) ([]*entity.Sighting, error) {
 	logger := logging.NewRepoLogger(ctx, "GetSightingsByTigerID", logrus.Fields{})
 
-	queryString := `SELECT id,tiger_id,seen_at,latitude,longitude,image_data,created_at,updated_at
-FROM sighting.sighting WHERE tiger_id = $1 and deleted_at IS NULL ORDER BY seen_at desc`
-	rows, err := queryWrapper(ctx, t.pool, queryString, tigerID)
+	includeImage := filter != nil && filter.IncludeImageData
+	columns := "id,tiger_id,seen_at,latitude,longitude,needs_review,speed,reporter_name,image_key"
+	if includeImage {
+		columns += ",image_data"
+	}
+	where, args := sightingFilterCondition(tigerID, filter, cursor)
+	args = append(args, limit)
+	queryString := `SELECT ` + columns + `
+FROM sighting.sighting WHERE ` + where + fmt.Sprintf(` ORDER BY seen_at desc, id desc LIMIT $%d`, len(args))
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, args...)
 	if err != nil {
 		logging.WithError(err, logger).Warn("Error when hit query wrapper")
 		return []*entity.Sighting{}, err
@@ -154,10 +373,11 @@ FROM sighting.sighting WHERE tiger_id = $1 and deleted_at IS NULL ORDER BY seen_
 	var res []*entity.Sighting
 	for rows.Next() {
 		var tmp entity.Sighting
-		if serr := rows.Scan(
-			&tmp.ID, &tmp.TigerID, &tmp.SeenAt, &tmp.Latitude, &tmp.Longitude, &tmp.ImageData,
-			&tmp.CreatedAt, &tmp.UpdatedAt,
-		); serr != nil {
+		dest := []interface{}{&tmp.ID, &tmp.TigerID, &tmp.SeenAt, &tmp.Latitude, &tmp.Longitude, &tmp.NeedsReview, &tmp.Speed, &tmp.ReporterName, &tmp.ImageKey}
+		if includeImage {
+			dest = append(dest, &tmp.ImageData)
+		}
+		if serr := rows.Scan(dest...); serr != nil {
 			logging.WithError(serr, logger).Warn("Error when scan rows")
 			continue
 		}
@@ -171,27 +391,158 @@ FROM sighting.sighting WHERE tiger_id = $1 and deleted_at IS NULL ORDER BY seen_
 	return res, nil
 }
 
-// CreateSighting store a new sighting for given tiger ID in database
+// GetSightingByID get sighting by ID of given tiger ID from database
+// It returns entity.ErrSightingNotFound if the sighting doesn't exist or the tiger has been deleted.
+func (t *TigerSightingRepo) GetSightingByID(ctx context.Context, tigerID, sightingID int32) (*entity.Sighting, error) {
+	logger := logging.NewRepoLogger(ctx, "GetSightingByID", logrus.Fields{})
+
+	queryString := `SELECT s.id,s.tiger_id,s.seen_at,s.latitude,s.longitude,s.needs_review,s.speed,s.reporter_name,s.image_key,s.image_data
+FROM sighting.sighting s JOIN sighting.tiger t ON t.id = s.tiger_id
+WHERE s.id = $1 AND s.tiger_id = $2 AND s.deleted_at IS NULL AND t.deleted_at IS NULL`
+
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, sightingID, tigerID)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if rows.Err() != nil {
+			logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+			return nil, rows.Err()
+		}
+		return nil, entity.ErrSightingNotFound
+	}
+	var res entity.Sighting
+	if serr := rows.Scan(
+		&res.ID, &res.TigerID, &res.SeenAt, &res.Latitude, &res.Longitude, &res.NeedsReview, &res.Speed, &res.ReporterName,
+		&res.ImageKey, &res.ImageData,
+	); serr != nil {
+		logging.WithError(serr, logger).Warn("Error when scan rows")
+		return nil, serr
+	}
+
+	return &res, nil
+}
+
+// CreateSighting store a new sighting for given tiger ID in database and set its ID
+// location is kept in sync with latitude and longitude.
 func (t *TigerSightingRepo) CreateSighting(ctx context.Context, sighting *entity.Sighting) error {
 	logger := logging.NewRepoLogger(ctx, "CreateTiger", logrus.Fields{})
 
 	queryString := "INSERT INTO sighting.sighting" +
-		" (tiger_id,seen_at,latitude,longitude,image_data,created_at,updated_at) " +
-		"VALUES ($1, $2, $3, $4, $5, $6, $7)"
+		" (tiger_id,seen_at,latitude,longitude,location,image_key,image_hash,needs_review,speed,reporter_name,reporter_email,created_at,updated_at) " +
+		"VALUES ($1, $2, $3, $4, " + geographyPoint("$3", "$4") + ", $5, $12, $6, $7, $8, $9, $10, $11) RETURNING id"
 
 	currentTime := time.Now()
-	_, err := t.pool.Exec(ctx, queryString,
+	err := t.conn(ctx).QueryRow(ctx, queryString,
 		sighting.TigerID,
 		sighting.SeenAt,
 		sighting.Latitude,
 		sighting.Longitude,
-		sighting.ImageData,
+		sighting.ImageKey,
+		sighting.NeedsReview,
+		sighting.Speed,
+		sighting.ReporterName,
+		sighting.ReporterEmail,
 		currentTime,
 		currentTime,
-	)
+		int64(sighting.ImageHash),
+	).Scan(&sighting.ID)
 	if err != nil {
 		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
 	}
 
 	return err
 }
+
+// GetSimilarSightings get at most limit sightings, of tigers not deleted, whose image hash differs from hash by at most
+// maxDistance bits, order by distance then latest sighting. maxDistance must be at most 7, see imageHashBands.
+// image_data is not selected.
+func (t *TigerSightingRepo) GetSimilarSightings(ctx context.Context, hash uint64, maxDistance, limit int32) ([]*entity.SimilarSighting, error) {
+	logger := logging.NewRepoLogger(ctx, "GetSimilarSightings", logrus.Fields{})
+
+	// the Hamming distance is the number of bits set in the exclusive or of the hashes
+	distance := "length(replace(((s.image_hash # $1)::bit(64))::text, '0', ''))"
+	bands := imageHashBands(hash)
+	queryString := `SELECT s.id,s.tiger_id,s.seen_at,s.latitude,s.longitude,s.needs_review,s.speed,s.reporter_name,s.image_key,` + distance + ` AS distance
+FROM sighting.sighting s JOIN sighting.tiger t ON t.id = s.tiger_id
+WHERE s.deleted_at IS NULL AND t.deleted_at IS NULL
+AND (((s.image_hash >> 56) & 255) = $2 OR ((s.image_hash >> 48) & 255) = $3 OR ((s.image_hash >> 40) & 255) = $4
+OR ((s.image_hash >> 32) & 255) = $5 OR ((s.image_hash >> 24) & 255) = $6 OR ((s.image_hash >> 16) & 255) = $7
+OR ((s.image_hash >> 8) & 255) = $8 OR (s.image_hash & 255) = $9)
+AND ` + distance + ` <= $10
+ORDER BY distance, s.seen_at desc, s.id desc LIMIT $11`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, int64(hash), bands[0], bands[1], bands[2], bands[3], bands[4], bands[5],
+		bands[6], bands[7], maxDistance, limit)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.SimilarSighting
+	for rows.Next() {
+		var tmp entity.SimilarSighting
+		if serr := rows.Scan(
+			&tmp.ID, &tmp.TigerID, &tmp.SeenAt, &tmp.Latitude, &tmp.Longitude, &tmp.NeedsReview, &tmp.Speed, &tmp.ReporterName,
+			&tmp.ImageKey, &tmp.Distance,
+		); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		res = append(res, &tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	return res, nil
+}
+
+// GetSightingsWithImageData get at most limit sightings, deleted ones included, whose image is still stored in database
+// and whose ID is greater than afterID, order by ID. Only ID, TigerID and ImageData of the sightings are set.
+func (t *TigerSightingRepo) GetSightingsWithImageData(ctx context.Context, afterID int32, limit int32) ([]*entity.Sighting, error) {
+	logger := logging.NewRepoLogger(ctx, "GetSightingsWithImageData", logrus.Fields{})
+
+	queryString := `SELECT id,tiger_id,image_data
+FROM sighting.sighting WHERE image_key = '' AND image_data <> '' AND id > $1 ORDER BY id LIMIT $2`
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, afterID, limit)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.Sighting
+	for rows.Next() {
+		var tmp entity.Sighting
+		if serr := rows.Scan(&tmp.ID, &tmp.TigerID, &tmp.ImageData); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		res = append(res, &tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	return res, nil
+}
+
+// UpdateSightingImageKey set the image key of a sighting whose image is stored in database, and remove the image from database
+// Sightings which already have an image key are left unchanged.
+func (t *TigerSightingRepo) UpdateSightingImageKey(ctx context.Context, sightingID int32, imageKey string) error {
+	logger := logging.NewRepoLogger(ctx, "UpdateSightingImageKey", logrus.Fields{})
+
+	queryString := "UPDATE sighting.sighting SET image_key = $2, image_data = '' WHERE id = $1 AND image_key = ''"
+
+	if _, err := t.conn(ctx).Exec(ctx, queryString, sightingID, imageKey); err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package postgres_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -48,10 +49,10 @@ func TestGetTigers(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at
-FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
-	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "created_at", "updated_at"}
-	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
+FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc, id desc LIMIT \$1`
+	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "max_sighting_distance", "created_at", "updated_at"}
+	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullFloat64{}, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
 
 	testCases := []RepositoryTestCases{
 		{
@@ -62,7 +63,7 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 					ExpectQuery(queryString).
 					WillReturnError(pgx.ErrNoRows)
 
-				resData, err := repositorySuite.repo.GetTigers(context.Background())
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), nil, nil, 10)
 				require.Error(t, err)
 				require.Equal(t, 0, len(resData))
 			},
@@ -77,7 +78,7 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 					ExpectQuery(queryString).
 					WillReturnError(pgx.ErrNoRows)
 
-				resData, err := repositorySuite.repo.GetTigers(context.Background())
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), nil, nil, 10)
 				require.Error(t, err)
 				require.Equal(t, 0, len(resData))
 			},
@@ -93,7 +94,7 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 						AddRow("test-id"),
 					)
 
-				resData, err := repositorySuite.repo.GetTigers(context.Background())
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), nil, nil, 10)
 				require.NoError(t, err)
 				require.Equal(t, 0, len(resData))
 			},
@@ -109,7 +110,7 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 						AddRow(expQueryStringRes...).RowError(1, pgx.ErrNoRows),
 					)
 
-				resData, err := repositorySuite.repo.GetTigers(context.Background())
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), nil, nil, 10)
 				require.Error(t, err)
 				require.Equal(t, 0, len(resData))
 			},
@@ -126,12 +127,44 @@ FROM sighting.tiger WHERE deleted_at IS NULL ORDER BY last_seen_timestamp desc`
 						AddRow(expQueryStringRes...),
 					)
 
-				resData, err := repositorySuite.repo.GetTigers(context.Background())
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), nil, nil, 10)
 				require.NoError(t, err)
 				require.Equal(t, 1, len(resData))
 				require.Equal(t, expQueryStringRes, expQueryStringRes)
 			},
 		},
+		{
+			testcaseName: "sucessfullly retrieve filtered tigers data after cursor",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				filteredQueryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
+FROM sighting.tiger WHERE deleted_at IS NULL AND name LIKE \$1 AND last_seen_timestamp >= \$2 AND last_seen_timestamp < \$3 ` +
+					`AND last_seen_latitude BETWEEN \$4 AND \$5 AND \(last_seen_longitude >= \$6 OR last_seen_longitude <= \$7\) ` +
+					`AND \(last_seen_timestamp, id\) < \(\$8, \$9\) ORDER BY last_seen_timestamp desc, id desc LIMIT \$10`
+				after := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+				before := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+				filter := &entity.TigerFilter{
+					NamePrefix:     "tiger_1%",
+					LastSeenAfter:  after,
+					LastSeenBefore: before,
+					BoundingBox:    &entity.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170},
+				}
+				cursor := &entity.TigerCursor{LastSeenTimestamp: before, ID: 5}
+
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(filteredQueryString).
+					WithArgs(`tiger\_1\%%`, after, before, -10.0, 10.0, 170.0, -170.0, before, int32(5), int32(10)).
+					WillReturnRows(pgxmock.
+						NewRows(queryStringRow).
+						AddRow(expQueryStringRes...),
+					)
+
+				resData, err := repositorySuite.repo.GetTigers(context.Background(), filter, cursor, 10)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(resData))
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -143,10 +176,10 @@ func TestGetTigerByID(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
 FROM sighting.tiger WHERE id = \$1 and deleted_at IS NULL`
-	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "created_at", "updated_at"}
-	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
+	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "max_sighting_distance", "created_at", "updated_at"}
+	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullFloat64{}, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
 	tigerID := int32(1)
 
 	testCases := []RepositoryTestCases{
@@ -178,6 +211,20 @@ FROM sighting.tiger WHERE id = \$1 and deleted_at IS NULL`
 				require.Nil(t, resData)
 			},
 		},
+		{
+			testcaseName: "Error tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow))
+
+				resData, err := repositorySuite.repo.GetTigerByID(context.Background(), tigerID)
+				require.ErrorIs(t, err, entity.ErrTigerNotFound)
+				require.Nil(t, resData)
+			},
+		},
 		{
 			testcaseName: "Error when scanning rows",
 			testcaseFunction: func(t *testing.T) {
@@ -235,11 +282,75 @@ FROM sighting.tiger WHERE id = \$1 and deleted_at IS NULL`
 	}
 }
 
+func TestGetTigerByIDForUpdate(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at
+FROM sighting.tiger WHERE id = \$1 and deleted_at IS NULL FOR UPDATE`
+	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "max_sighting_distance", "created_at", "updated_at"}
+	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullFloat64{}, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
+	tigerID := int32(1)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when called outside transaction",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+
+				resData, err := repositorySuite.repo.GetTigerByIDForUpdate(context.Background(), tigerID)
+				require.Error(t, err)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(queryString).WillReturnError(pgx.ErrNoRows)
+				repositorySuite.pgx.ExpectRollback()
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					_, err := repositorySuite.repo.GetTigerByIDForUpdate(ctx, tigerID)
+					return err
+				})
+				require.Error(t, err)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "sucessfullly lock and retrieve tiger data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(queryString).WillReturnRows(pgxmock.NewRows(queryStringRow).AddRow(expQueryStringRes...))
+				repositorySuite.pgx.ExpectCommit()
+
+				var resData *entity.Tiger
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) (err error) {
+					resData, err = repositorySuite.repo.GetTigerByIDForUpdate(ctx, tigerID)
+					return err
+				})
+				require.NoError(t, err)
+				require.Equal(t, int32(1), resData.ID)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
 func TestCreateTiger(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	queryString := `INSERT INTO sighting.tiger \(name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,created_at,updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`
+	queryString := `INSERT INTO sighting.tiger \(name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,last_seen_location,created_at,updated_at\) ` +
+		`VALUES \(\$1, \$2, \$3, \$4, \$5, \$8, ST_SetSRID\(ST_MakePoint\(\$5, \$4\), 4326\)::geography, \$6, \$7\) RETURNING id`
 	tiger := &entity.Tiger{
 		Name:              "tiger 1",
 		DateOfBirth:       time.Now(),
@@ -254,7 +365,7 @@ func TestCreateTiger(t *testing.T) {
 			testcaseFunction: func(t *testing.T) {
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
+					ExpectQuery(queryString).
 					WillReturnError(pgx.ErrNoRows)
 
 				err := repositorySuite.repo.CreateTiger(context.Background(), tiger)
@@ -268,7 +379,7 @@ func TestCreateTiger(t *testing.T) {
 
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
+					ExpectQuery(queryString).
 					WillReturnError(pgx.ErrNoRows)
 
 				err := repositorySuite.repo.CreateTiger(context.Background(), tiger)
@@ -281,11 +392,13 @@ func TestCreateTiger(t *testing.T) {
 				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
-					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(7)))
 
-				err := repositorySuite.repo.CreateTiger(context.Background(), tiger)
+				created := *tiger
+				err := repositorySuite.repo.CreateTiger(context.Background(), &created)
 				require.NoError(t, err)
+				require.Equal(t, int32(7), created.ID)
 			},
 		},
 	}
@@ -299,7 +412,8 @@ func TestUpdateTiger(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	queryString := `UPDATE sighting.tiger SET last_seen_timestamp = \$2, last_seen_latitude = \$3, last_seen_longitude = \$4, updated_at = \$5 WHERE id = \$1`
+	queryString := `UPDATE sighting.tiger SET name = \$2, date_of_birth = \$3, last_seen_timestamp = \$4, last_seen_latitude = \$5, last_seen_longitude = \$6, ` +
+		`last_seen_location = ST_SetSRID\(ST_MakePoint\(\$6, \$5\), 4326\)::geography, max_sighting_distance = \$8, updated_at = \$7 WHERE id = \$1`
 	tiger := &entity.Tiger{
 		Name:              "tiger 1",
 		DateOfBirth:       time.Now(),
@@ -355,93 +469,140 @@ func TestUpdateTiger(t *testing.T) {
 	}
 }
 
-func TestGetSightingsByTigerID(t *testing.T) {
+func TestDeleteTiger(t *testing.T) {
 	t.Parallel()
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	queryString := `SELECT id,tiger_id,seen_at,latitude,longitude,image_data,created_at,updated_at
-FROM sighting.sighting WHERE tiger_id = \$1 and deleted_at IS NULL ORDER BY seen_at desc`
-	queryStringRow := []string{"id", "tiger_id", "seen_at", "latitude", "longitude", "image_data", "created_at", "updated_at"}
-	expQueryStringRes := []interface{}{int32(1), int32(1), time.Now(), -6.19, 108.0, "https://test.com/dummy.jpeg", sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}}
-	tigerID := int32(1)
+	queryString := `UPDATE sighting.tiger SET deleted_at = \$2, updated_at = \$2 WHERE id = \$1 and deleted_at IS NULL`
 
 	testCases := []RepositoryTestCases{
 		{
-			testcaseName: "database returns no rows when scanning",
+			testcaseName: "database returns error",
 			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectQuery(queryString).
-					WillReturnError(pgx.ErrNoRows)
+					ExpectExec(queryString).
+					WillReturnError(errors.New("connection refused"))
 
-				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID)
+				err := repositorySuite.repo.DeleteTiger(context.Background(), 1)
 				require.Error(t, err)
-				require.Equal(t, 0, len(resData))
 			},
 		},
 		{
-			testcaseName: "database returns no rows when scanning",
+			testcaseName: "tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+				err := repositorySuite.repo.DeleteTiger(context.Background(), 1)
+				require.ErrorIs(t, err, entity.ErrTigerNotFound)
+			},
+		},
+		{
+			testcaseName: "successfully delete tiger",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				err := repositorySuite.repo.DeleteTiger(context.Background(), 1)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
 
+func TestGetTigersNearby(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at,
+ST_Distance\(last_seen_location, ST_SetSRID\(ST_MakePoint\(\$2, \$1\), 4326\)::geography\) AS distance
+FROM sighting.tiger WHERE deleted_at IS NULL AND ST_DWithin\(last_seen_location, ST_SetSRID\(ST_MakePoint\(\$2, \$1\), 4326\)::geography, \$3\)
+ORDER BY distance, id LIMIT \$4`
+	sinceQueryString := `SELECT id,name,date_of_birth,last_seen_timestamp,last_seen_latitude,last_seen_longitude,max_sighting_distance,created_at,updated_at,
+ST_Distance\(last_seen_location, ST_SetSRID\(ST_MakePoint\(\$2, \$1\), 4326\)::geography\) AS distance
+FROM sighting.tiger WHERE deleted_at IS NULL AND ST_DWithin\(last_seen_location, ST_SetSRID\(ST_MakePoint\(\$2, \$1\), 4326\)::geography, \$3\) AND last_seen_timestamp >= \$4
+ORDER BY distance, id LIMIT \$5`
+	queryStringRow := []string{"id", "name", "date_of_birth", "last_seen_timestamp", "last_seen_latitude", "last_seen_longitude", "max_sighting_distance", "created_at", "updated_at", "distance"}
+	expQueryStringRes := []interface{}{int32(1), "tiger-1", time.Now(), time.Now(), -6.19, 108.0, sql.NullFloat64{}, sql.NullTime{Time: time.Now()}, sql.NullTime{Time: time.Now()}, 1250.5}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
 					ExpectQuery(queryString).
+					WithArgs(-6.18, 108.0, 5000.0, int32(10)).
 					WillReturnError(pgx.ErrNoRows)
 
-				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID)
+				resData, err := repositorySuite.repo.GetTigersNearby(context.Background(), -6.18, 108.0, 5000.0, time.Time{}, 10)
 				require.Error(t, err)
-				require.Equal(t, 0, len(resData))
+				require.Empty(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error when scanning rows",
+			testcaseName: "Error when check rows",
 			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
 					ExpectQuery(queryString).
 					WillReturnRows(pgxmock.
-						NewRows([]string{"id"}).
-						AddRow("test-id"),
+						NewRows(queryStringRow).
+						AddRow(expQueryStringRes...).RowError(1, pgx.ErrNoRows),
 					)
 
-				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID)
-				require.NoError(t, err)
-				require.Equal(t, 0, len(resData))
+				resData, err := repositorySuite.repo.GetTigersNearby(context.Background(), -6.18, 108.0, 5000.0, time.Time{}, 10)
+				require.Error(t, err)
+				require.Empty(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error when check rows",
+			testcaseName: "sucessfullly retrieve nearby tigers with distance",
 			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
 					ExpectQuery(queryString).
+					WithArgs(-6.18, 108.0, 5000.0, int32(10)).
 					WillReturnRows(pgxmock.
 						NewRows(queryStringRow).
-						AddRow(expQueryStringRes...).RowError(1, pgx.ErrNoRows),
+						AddRow(expQueryStringRes...),
 					)
 
-				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID)
-				require.Error(t, err)
-				require.Equal(t, 0, len(resData))
+				resData, err := repositorySuite.repo.GetTigersNearby(context.Background(), -6.18, 108.0, 5000.0, time.Time{}, 10)
+				require.NoError(t, err)
+				require.Len(t, resData, 1)
+				require.Equal(t, int32(1), resData[0].ID)
+				require.Equal(t, 1250.5, resData[0].Distance)
 			},
 		},
 		{
-			testcaseName: "sucessfullly retrieve sighting data",
+			testcaseName: "sucessfullly retrieve nearby tigers last seen since given time",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
+				since := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectQuery(queryString).
+					ExpectQuery(sinceQueryString).
+					WithArgs(-6.18, 108.0, 5000.0, since, int32(10)).
 					WillReturnRows(pgxmock.
 						NewRows(queryStringRow).
 						AddRow(expQueryStringRes...),
 					)
 
-				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID)
+				resData, err := repositorySuite.repo.GetTigersNearby(context.Background(), -6.18, 108.0, 5000.0, since, 10)
 				require.NoError(t, err)
-				require.Equal(t, 1, len(resData))
-				require.Equal(t, expQueryStringRes, expQueryStringRes)
+				require.Len(t, resData, 1)
 			},
 		},
 	}
@@ -451,58 +612,697 @@ FROM sighting.sighting WHERE tiger_id = \$1 and deleted_at IS NULL ORDER BY seen
 	}
 }
 
-func TestCreateSighting(t *testing.T) {
+func TestGetDistanceFromLastSeen(t *testing.T) {
 	t.Parallel()
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	queryString := `INSERT INTO sighting.sighting \(tiger_id,seen_at,latitude,longitude,image_data,created_at,updated_at\) 
-VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`
-	sighting := &entity.Sighting{
-		TigerID:   1,
-		SeenAt:    time.Now(),
-		Latitude:  -6.18,
-		Longitude: 107.00,
-		ImageData: "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAPoAAADICAYAAADBXvybAAAAAXNSR0IArs4c6QAAFR5JREFUeF7tXWmIXcUSrtGMIYnJEBU089REGZeMG7jinucG4oKKCoriniCK4r4hrug/RVTiiooKbggioiIi+MMFHVdIwI0JMua5m+BEQ6Lz6J7cyZ07997TW3VX9/nOz5nuquqv6quu79zkTs/Q0NDY3Llzafr06eT+jBFRj/t2052R3JiGg3VAwBsB5ppeu3Yt/fbbb9QzMjIytmbNGpo5cyb19/d7xw0DQKAEBJj5FwWilStX0ujoKM2aNWuc6IrgjR8ODAxECUKakxISKw1TxGOCAE/lffPNNxOXt+L2BNFVSKtWraIVK1bQwoULqbe31yRKrAECxSLAQ0FeuNatW0fLly+n+fPnU19fn3Y2hejqh+0W8oYG60AACIRAQF3Uw8PDNDg4OOmibkv0hsPmqz9EEB1t5Ng2WQGBcSBgj0A36d2V6I0rX4n5uup2e7ixAwjER6DqUq4kOnR7/KTBY9kIhBxgTWW2EdFz0u0hQSy73HC63BHopMfbnWsK0auIUjUi5A4e4gcCOSBg+1G48Y3efHhbJzkAhxiBQC4ItL9su1/RTkSHbs+lJLjirJr7uPxmYHcCmvAYmerx6tHdMjYfxxmkDCH6ImBZT77u2PcnPI+NHq8muiNS0O2OwGEbEDBAIIRUdh7dW+MLEYzBmbEECNQKgVCXaDCiQ7dLq7+Ec6Y0KDKMJ7QsDkp0hWfoADPMEUIGAl4IdNLjPq07ONEbJww1cnghhs0TCPgUSVgjeSclCI5dIOCSwGxEV2dpHzQ3VHkXEqLnQSCHquO8HFmJ3qrbp/X22n3ZVA7Z4alLWK0RAjHkLjvRodtrVLE4qjUCJp+Ph7jvohAdut06/9hQAwS49Hg76KISvbNuLzurITpy2QhJOF3cLHHqcRFEb9Xt+F46CUWeewxxSeqDVgw9Lobo0O0+pZJobz5cSgRQtVsTPV5txW1F9NG9NczGCDOvv9/ujXzX86Iq3coBu0Ij0KjEmHpc1I3eHExqEEInF/aAQDMCsfW4WKJDt4MYJSKQSo+LJrrW7evX0fJlk794PusCgILIOn0+wafU4+KJ3ghQwqjjk2TsrTcCEqVo8pdxnUpCIlj1Lt+CTx9w8hq/pGZQf/9/RAHWgegBT+5xXPwdOA/wsDUqApL0eDaje3Og0gGMWk1wJhIBaXo8S6Kz6XYZQ4vIwkVQzQhUf41yDn+yTKxG79SVcgBVMlHQ38JlJ+1LY7tMJiC6XYDUshy6PVyhwpIbAsHkpCUV3KId35WA6D7hju81Bzoikh7HyiNKjwMWtNVPj6fLdBPR5xEF/NfmMXKbdnSKcUL4kIRAzh/5ZnmjNye/LfjMjZPZvKTaRiwbEMj9Usme6CoP0O3gIxcC5jKRK4Iwdosgup1uDwNcJyu47XnxjWndT4/HjLTaVzFEbxy1esQCFavLIs0KSZkJpcelnImP6AlPGCpJacodXlMjUH1ZpI7Q3j8f0e1jCbqjPN2esHMGzYxcY6Xo8XYIF0t0SbpdbmlHjoyxV/maLkmP147o5rqdqPVf4EWmANwlRKAOUq/oG725duqQzIRcydZ1iXpc4I3uO3DZ1Vd5ut3u/HmvDlsrJetxgUSPX3p1S3B8hPk8hqJ6HRt+bUb31vLrPLKFKie+godlVwTGaOXK/1Ed/6tzPYjegbvQ7a6EyXNfXfQ4Rvc2CNRxjMuTpu5Rp5VrMibEetzoFTWSthDcCxg7qxFAIx/HCERvqpU6j3bVlMlvhSRplvpeB9Fb6ldSceRHLcuIGauft2kzBm4JoelyEB263bRWOqyTVfSQYe3TBKJ3KF8UjCf/E2yHHu8MegZET3tj8I6ACdhQqMtxyfUnDQzsVOgJ/Y41mehpOeV3Esbd0O3hwOUoMTTj6vxkcKNXHyLGCoyFMVC28wF5ZY4XiG6OlcX3yVsYxVInBCQ1Xo4pxQmULptAdAdE6zUqyivj3KVUCkRBdAeiqy25F5vjsZNvq1eTDQd3G6Kn6DfhDhTTkqTxsdO5S8lmSD0eA5NxHyE9+dnCjW7QGbpBHLIADUKp5ZIcGqr0xIDogTKEkTIQkC1mIJFccZ18PYHorji22ZdHUbbMJ34ToQd61Y7RPD3gbdMwe0ZGRsb6+/vDWS3KUnVBNh8XY6Z/8iGH/DFstYAbPTym+LzdA1PbRmnXhj0Cy3FrEzggOmMCMXragZuH9LE7k5TVIDpzJlC8ZgCjKZrh5LoKRHdFzmKf7ThqYTr7pZL1eEmyAESPRBXJBR0Jgilu0ADjIQ+ix8Nae5I3oqa5tyBp4hYeiB4Xb+1NfJEzc39qs2N2mCDH0lyC6IkyUsexFfIlRbGNN1EQPQX2G3zGK3yDG9NgiQ9UdWxsPniF3guiNxBlLvRuiZOn28OWmXipEva4Iq1lRvSEbGROX6lkKL2JMZdFMPOZET3YuUUaKmm8jSdLJKZS3oUEogurkxIIUlLDElYezuGA6M7Q8W7MdeQtVYLwZpvfOojOj7Gzh9xIk2tzUgmSN2wblo1h4CC6IZ6pXs5HH4MNC6cZthLkhmUZZLccRM8gZfKItLEbhG5EDn0mgwymDxFET58D4whEjMYtX2YwOjpKAwMDxmfAwjQIgOhpcHf2KkW3i2g6zigybhQ6kmRIdKFIMtZOq+nQ47JN6KwyAqm1SYXV2gyJbnW+YhezEq4DaikbTLGJjHQwED0S0FxuYo3QUiSDLY4YEsYRA9FtK0fgem4SxmomAqGNFJJbO7LZBaJHSiW3G46xOoU84MaprvZB9IIyH5KYHI2jIKizOwqInl3KqgP2HbXHpcCfNDCwU7UzrMgCgfoS3UbgZJHKyUG66nbfJpEhVLUIub5Er0F6bcbvkGN/DaDN7oj+RC/8Zswuoy0BmxDYpiHkjkdd4/cnel2Ry+zcnUZy1xE/s+NHCFf2jQeiRygBKS5aSQ09Hisz6ZsAiB4r1yL8jNGqVatpxYoVNDY2RgsWLKC+vr5okSUv9+QBRIN6iqO2RDfGw3hhugPC82QElB4fHh6mnp4emj9/flSiIxfpEMCNng776J4xureBvCaXFYgenW5pHGbxMq4mpEtRASB6CtQj+sTHaxHBFuwKRBecHN/QbD4fN2kIvvFgfzoEQPR02LN6dv18HB+5sabF0rivltm4H0SPDL2lO6flvmR1bRJOwU7Z5FvcYaIozQqI7pxReQUZcvy2GfudIYy1UV6qYp18wg+IHh1yHoccxAzZOHhO3WoVjO6EszvRgWmc2jXwwj1qT0iBef1EPQYB2SxhrCNG0zYnFLHWnegiwk8cRMJKarj21eOmCHI3E9M4sM4NARDdDbfku1KM1RzyIDmQNQkARM8w0SkJZ99gEo49GeaWK2QQnQtZJrtSRuhYkoEJRiezObes7IjODTa3facK27BJGrl4mo7kDPhkL+3e7IieFq403u3H5XhxNmTE4MKFNK23N57jWJ4K6TsgeqyCcfSTUo+bhmzXiAphjik4JusiQAKimySieY1DUhy2aI88o7Htgc3XS5MW5pGXvxJET5BjE+LnSprcmlOC9CdxCaIngb2zU7sxOGXwndtVDnIjJXIpfIPoKVDv4LMkguTTsFIUgMlMFzauPIkeH6ewqLexVurIG02CdKyJAovFoRrzJLrDQSVviUaGRCCU2sQSwenkFkR3gi3MprbjbaEXUEmypG32hefNk+jCTxeGjyxWii/8NqgVpduDl35wg5My4El0Fg4QEe+huaI2tZtmlHXE1HFbNyxKlyqmdRBznVCix4Qgri8U+TjeaZpd3FxL8gais2Vj8lVY1Ng6CTP3Kz+kfJmIwj0ctkqQMKGC6IzpbZgOWdARwo3qotwGGBXGSmcgeiVEfgswoprhB0ljhpPrKhDdFTmDfcGKt2UcTTKdRnCKpmhQVI5LQHRH4LptwzjqDipkjjt23XayEt35EnDeyAOSjVUUqg1a7deiUfpj2GqhC9EzZlt4nIwsYvQ0gsl4UTDpY+yx3IWsNzo/bHKaEYqSJ9tonmFwzZzoYUDwsYIx0wc9s72QQ2Y4JdPo/uHJtoACjJefoA1VziAYDUDc6I5QY6R0BM5zGySSG4AgusbNrsWj2NyKLdQuNFl7JEF0C8yCjo8WfrF0KgKQTXZVAaIb4oXCMgQq4jI0XnOwa0J0u9G8FT65o6LfuczLRPZKSKnq/NSE6NVAdFqBInLHLuZOuc04JgqdfUUien43D8ZCGQVqEwXkVXKi26Qr/VoUTPocuEaABt0euUg3umva4u/DCNiKeX7TmDoBJNfkPILoTXjUtTicqey8MU4DN2raws8QCikQnYgw7oUqJ3l2IMPGc2JA9LJbXraFUHZagnYMNHIjogfFXJQxjHai0sEeTGpplrI3G9zo7PgncZA66UkOHctpyoquOKNRc2fHKT5AtSM6xjj2KhbvIFu55oFsrYhexwR71EbRW8tq+NUTgliiV4duV4cyRja7mLGaH4G6SDixRA+Z4rokMwxmoVtsmKg4rbBfAp0gjQh10UQvZjyLWBCchJJsW8m64eFhGhwcpN7eXsmhOsVWLNGhx53qodab4l4Mcbu3GKKHPDb7KNaODiEPUGu6pT98iVJPDNFDpTfbJKFRhCqBIHaSXBZBIm9vhIno8as27thl+3WSjBmEaTYEStLtTER3xd6tQUCPu+Idfp9bBsPHEcpi7AskVNytdoQR3f6YpY1Y9ghgRwwE4kvCsC0za6LHBz9GScFHfATMSNXuUjHbaXGi4AbHfWdJ9FLGKYv0F7yUqbKZEMtVt7MRnSt90ONMFSzELFfdhDxejhcNG9FDAtuwZa3Hc6gaDqBgMwoCvNIxbPFmQ3ReUKPURYWTsImVcCLbGHJEwPry6QgK7+nFEz3HMcm2wLG+PQK8pR8OdTfdHvd0oonOpcdDQRzKjnPJJQ/AOfLiNkq/kMQSPdxIxFBTIBgDqGWYlCoxRRJdKljdShHcl0TUtNmQeEmJIrr08UdSKSMW2Qi46Xa+M4khOpce54MuoeW0F1bCg+flWtLFtZHo8/qJetIAKXHUSYMEvOaOQLseLEGKJr/RJYCQe3Gljx8jRlUOUl9myYguaaypSpLc34NgcnMzNbKUuj0J0aHHcypPxBoSgVQXXHSipx5hQiZNii3c61IyYR5HbMkaleixD2cOO1bWHYEUzVKR78/RUdppYIAd/ihETzWusKMHB0DAE4FYup2d6NDjnpUQc3uKa830fJJjMz1Dh3VeF6EhLqxEhx73rABsrxUCnNK2O9ENu0W7bHAGXavsZ3BYjzIJfDo5kbgejOtyDH6je40hruhgHxAoCAEO3R6U6BL1eGWPr1yQVwVle5xsA+epj9AXZjCic40cPDCmsopqToW8r99UmQslgYMQPVQwvsnAfiBQIgIhLlEvom8cLxZQX9+cEjHGmYCACAR8dbsz0SXqcREZ8Qki1XzoEzP2RkPAR7c7ET3EKBENHTgCAh4IvPvuu3T11VfT119/TX19fXTFFVfQ5Zdfri0ed9xx9NZbb9Emm2wy4eGBBx6gCy+8kP7991+67rrr6MknnyRF0COPPJIee+wxmjt3btdovv/+e7rkkkvovffeo2nTptGJJ55I999/P02fPn3C5uOPP07//PMPHXXUUZNsPv3003TLLbfQL7/8Qrvuuis9+uijtNdee2l/1kSPqsdxw3mUKLb6IvDHH3/Q9ttvTw899BCdeeaZ9Pnnn9MhhxxCb775Jh100EF08MEHa9KffvrpU1w9+OCD9Mgjj+i1qkGcf/75tOmmm9IzzzzTNaxDDz2U9txzT7r33ntp9erVdPTRR9Npp51GN954IzXbXLNmjfatbCubX375JR122GH0+uuv0/7776/X3nPPPfTVV19Rb2+vOdF9xgZfwMvYb9a1zFaVgYj0U/z000+aOOecc85EqIpEF198MZ133nm02267aUIec8wxU46imoBad9ZZZ+nfqYlgjz32ICV5FennzJlDS5cu1b9T9tVU8MQTT+gJ4Pjjj6etttpK/+7aa6+lH3/8kZ566indWJptfvLJJ3TggQfSr7/+Snfffbdep6aGxrPtttvSs88+S4cffrgZ0Xn1OEpbVsEz5oPRdAwM1Vi9++6706effko77rgj9ff30wEHHEBffPGFHqtPOukkuuuuu2jGjBm0xRZb6LF+n3320aGNjY3RZpttptdus802+tZ+4YUX6O+//9ZNQ/1ckb/5UZfrfvvtp6WDahidbL744oukxvlFixbRVVddNWFCyYVTTz1VN4fK0R16PEYJRfQhnGydw0sb+MjICB177LG0ePFiuvTSS3XCFIH23XdfTcKff/6ZTj75ZFKjtxqZlaZWN6669RvP7Nmz6Z133tF73njjDX1bKzKrMfuII46YVARr166lc889l9avX0+KyOrpZlO9O1DxqRG/8Zxwwgn6NleNoivRo+rxiLUOV0DABoGPP/5Y63D1oqt5jG+18fLLL9M111xD3377LW255Zb06quvai2vHkVYdaMvX76cdtllF/0zNR0o3a60f/OjJMMpp5yiX6Tdd999+qWcerrZVATfeeed6YILLqCBDf+/XTUd1YSWLFnSnujQ4zZlgLVREEh0oX/44Yf6ZZjSuoo4jeevv/4i9Ts1Ljee5557jm677TZNZrX27LPP1hOAej777DOtsX///XdN+IcffnhidFc390UXXaTXqclg0X//S0sWL6bLLrtsErTdbN5xxx26wSjdPzw8rJuJ0uivvPKK9jvlRufV41FKQoCTRFUp4ORTQsgYCvVme3BwUH9Mpd5+Nz/qd9tttx3deuutepT/4Ycf9Es09ZHbnXfeqd+4qxd1SqerN+PqZlXaXBH8u+++0y/R3n//fVIjuiLwRx99RDvssIPW1OqjMWWj9elmUzUX9b7gtdde09Lg+uuv12/8ly1bpl/0TSK6jx7POJ8S6dE5JgAdLV8vvfSSvs2VNm5+lDZXJP7ggw/oyiuv1De40t9nnHEG3X777Xq9evl20003acKrCVnpZfUx3cyZM/UUoF7cqb3qUXvefvttrcW33nprfeP39Gz8IwtK5w8NDbW3ufQh2nz25trO888/TzfccIN++7733nvTzTffrOWBemk4QXTVoVQQ6of2T52qL5ez5hKnfbVhRxUCG3PfuLxnzZpFPUNDQ2Pq1X1r56oyx/p71dBUvCEfDpsh44MtIMCAgJIH6rP2/wN/XBtlM2lCsQAAAABJRU5ErkJggg==",
-	}
+	queryString := `SELECT ST_Distance\(last_seen_location, ST_SetSRID\(ST_MakePoint\(\$3, \$2\), 4326\)::geography\)
+FROM sighting.tiger WHERE id = \$1 and deleted_at IS NULL`
+	tigerID := int32(1)
 
 	testCases := []RepositoryTestCases{
 		{
-			testcaseName: "database returns no rows when scanning",
+			testcaseName: "Error when query fails",
 			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
-					WillReturnError(pgx.ErrNoRows)
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
 
-				err := repositorySuite.repo.CreateSighting(context.Background(), sighting)
+				_, err := repositorySuite.repo.GetDistanceFromLastSeen(context.Background(), tigerID, -6.18, 108.0)
 				require.Error(t, err)
 			},
 		},
 		{
-			testcaseName: "database returns no rows when scanning",
+			testcaseName: "Error tiger not found",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"st_distance"}))
 
+				_, err := repositorySuite.repo.GetDistanceFromLastSeen(context.Background(), tigerID, -6.18, 108.0)
+				require.ErrorIs(t, err, entity.ErrTigerNotFound)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
-					WillReturnError(pgx.ErrNoRows)
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"st_distance"}).AddRow("far"))
 
-				err := repositorySuite.repo.CreateSighting(context.Background(), sighting)
+				_, err := repositorySuite.repo.GetDistanceFromLastSeen(context.Background(), tigerID, -6.18, 108.0)
 				require.Error(t, err)
 			},
 		},
 		{
-			testcaseName: "sucessfullly create tiger data",
+			testcaseName: "sucessfullly calculate distance",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 				repositorySuite := SightingRepositoryTestSuite()
 				repositorySuite.pgx.
-					ExpectExec(queryString).
-					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+					ExpectQuery(queryString).
+					WithArgs(tigerID, -6.18, 108.0).
+					WillReturnRows(pgxmock.NewRows([]string{"st_distance"}).AddRow(1250.5))
 
-				err := repositorySuite.repo.CreateSighting(context.Background(), sighting)
+				resData, err := repositorySuite.repo.GetDistanceFromLastSeen(context.Background(), tigerID, -6.18, 108.0)
+				require.NoError(t, err)
+				require.Equal(t, 1250.5, resData)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetAdjacentSightings(t *testing.T) {
+	t.Parallel()
+	queryString := `\(SELECT seen_at, ST_Distance\(location, ST_SetSRID\(ST_MakePoint\(\$4, \$3\), 4326\)::geography\)
+FROM sighting.sighting WHERE tiger_id = \$1 AND deleted_at IS NULL AND seen_at <= \$2 ORDER BY seen_at desc, id desc LIMIT 1\)
+UNION ALL
+\(SELECT seen_at, ST_Distance\(location, ST_SetSRID\(ST_MakePoint\(\$4, \$3\), 4326\)::geography\)
+FROM sighting.sighting WHERE tiger_id = \$1 AND deleted_at IS NULL AND seen_at > \$2 ORDER BY seen_at, id LIMIT 1\)`
+	queryStringRow := []string{"seen_at", "st_distance"}
+	tigerID := int32(1)
+	seenAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, _, err := repositorySuite.repo.GetAdjacentSightings(context.Background(), tigerID, seenAt, -6.18, 108.0)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).AddRow(seenAt, "far"))
+
+				_, _, err := repositorySuite.repo.GetAdjacentSightings(context.Background(), tigerID, seenAt, -6.18, 108.0)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve no adjacent sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow))
+
+				previous, next, err := repositorySuite.repo.GetAdjacentSightings(context.Background(), tigerID, seenAt, -6.18, 108.0)
+				require.NoError(t, err)
+				require.Nil(t, previous)
+				require.Nil(t, next)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve previous and next sightings",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(tigerID, seenAt, -6.18, 108.0).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow(seenAt, 1250.5).
+						AddRow(seenAt.Add(time.Hour), 3000.0),
+					)
+
+				previous, next, err := repositorySuite.repo.GetAdjacentSightings(context.Background(), tigerID, seenAt, -6.18, 108.0)
+				require.NoError(t, err)
+				require.Equal(t, &entity.Waypoint{SeenAt: seenAt, Distance: 1250.5}, previous)
+				require.Equal(t, &entity.Waypoint{SeenAt: seenAt.Add(time.Hour), Distance: 3000}, next)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve next sighting only",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).AddRow(seenAt.Add(time.Minute), 10.0))
+
+				previous, next, err := repositorySuite.repo.GetAdjacentSightings(context.Background(), tigerID, seenAt, -6.18, 108.0)
 				require.NoError(t, err)
+				require.Nil(t, previous)
+				require.Equal(t, &entity.Waypoint{SeenAt: seenAt.Add(time.Minute), Distance: 10}, next)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetPriorReporters(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT DISTINCT reporter_email FROM sighting.sighting
+WHERE tiger_id = \$1 AND deleted_at IS NULL AND reporter_email <> '' AND reporter_email <> \$2 ORDER BY reporter_email`
+	queryStringRow := []string{"reporter_email"}
+	tigerID := int32(1)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.GetPriorReporters(context.Background(), tigerID, "new@example.com")
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"reporter_email", "reporter_name"}).AddRow("old@example.com", "Old"))
+
+				_, err := repositorySuite.repo.GetPriorReporters(context.Background(), tigerID, "new@example.com")
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve no reporter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow))
+
+				res, err := repositorySuite.repo.GetPriorReporters(context.Background(), tigerID, "new@example.com")
+				require.NoError(t, err)
+				require.Empty(t, res)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve prior reporters",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(tigerID, "new@example.com").
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow("a@example.com").
+						AddRow("b@example.com"),
+					)
+
+				res, err := repositorySuite.repo.GetPriorReporters(context.Background(), tigerID, "new@example.com")
+				require.NoError(t, err)
+				require.Equal(t, []string{"a@example.com", "b@example.com"}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetSightingsByTigerID(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	queryString := `SELECT id,tiger_id,seen_at,latitude,longitude,needs_review,speed,reporter_name,image_key
+FROM sighting.sighting WHERE tiger_id = \$1 AND deleted_at IS NULL ORDER BY seen_at desc, id desc LIMIT \$2`
+	queryStringRow := []string{"id", "tiger_id", "seen_at", "latitude", "longitude", "needs_review", "speed", "reporter_name", "image_key"}
+	expQueryStringRes := []interface{}{int32(1), int32(1), time.Now(), -6.19, 108.0, false, sql.NullFloat64{Float64: 3.5, Valid: true}, "Ranger", ""}
+	tigerID := int32(1)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "database returns no rows when scanning",
+			testcaseFunction: func(t *testing.T) {
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(pgx.ErrNoRows)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, nil, nil, 10)
+				require.Error(t, err)
+				require.Equal(t, 0, len(resData))
+			},
+		},
+		{
+			testcaseName: "database returns no rows when scanning",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(pgx.ErrNoRows)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, nil, nil, 10)
+				require.Error(t, err)
+				require.Equal(t, 0, len(resData))
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.
+						NewRows([]string{"id"}).
+						AddRow("test-id"),
+					)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, nil, nil, 10)
+				require.NoError(t, err)
+				require.Equal(t, 0, len(resData))
+			},
+		},
+		{
+			testcaseName: "Error when check rows",
+			testcaseFunction: func(t *testing.T) {
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.
+						NewRows(queryStringRow).
+						AddRow(expQueryStringRes...).RowError(1, pgx.ErrNoRows),
+					)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, nil, nil, 10)
+				require.Error(t, err)
+				require.Equal(t, 0, len(resData))
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve sighting data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.
+						NewRows(queryStringRow).
+						AddRow(expQueryStringRes...),
+					)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, nil, nil, 10)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(resData))
+				require.Empty(t, resData[0].ImageData)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve sighting data with image data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				imageQueryString := `SELECT id,tiger_id,seen_at,latitude,longitude,needs_review,speed,reporter_name,image_key,image_data
+FROM sighting.sighting WHERE tiger_id = \$1 AND deleted_at IS NULL ORDER BY seen_at desc, id desc LIMIT \$2`
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(imageQueryString).
+					WillReturnRows(pgxmock.
+						NewRows(append(queryStringRow, "image_data")).
+						AddRow(append(expQueryStringRes[:9:9], "data:image/png;base64,iVBORw0KGgo=")...),
+					)
+
+				filter := &entity.SightingFilter{IncludeImageData: true}
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, filter, nil, 10)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(resData))
+				require.Equal(t, "data:image/png;base64,iVBORw0KGgo=", resData[0].ImageData)
+			},
+		},
+		{
+			testcaseName: "sucessfullly retrieve filtered sighting data without image after cursor",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				filteredQueryString := `SELECT id,tiger_id,seen_at,latitude,longitude,needs_review,speed,reporter_name,image_key
+FROM sighting.sighting WHERE tiger_id = \$1 AND deleted_at IS NULL AND seen_at >= \$2 AND seen_at < \$3 ` +
+					`AND \(seen_at, id\) < \(\$4, \$5\) ORDER BY seen_at desc, id desc LIMIT \$6`
+				from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+				filter := &entity.SightingFilter{SeenFrom: from, SeenTo: to}
+				cursor := &entity.SightingCursor{SeenAt: to, ID: 5}
+
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(filteredQueryString).
+					WithArgs(tigerID, from, to, to, int32(5), int32(10)).
+					WillReturnRows(pgxmock.
+						NewRows(queryStringRow).
+						AddRow(append(expQueryStringRes[:8:8], "sighting/1/a.png")...),
+					)
+
+				resData, err := repositorySuite.repo.GetSightingsByTigerID(context.Background(), tigerID, filter, cursor, 10)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(resData))
+				require.Equal(t, "sighting/1/a.png", resData[0].ImageKey)
+				require.Empty(t, resData[0].ImageData)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetSightingByID(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT s.id,s.tiger_id,s.seen_at,s.latitude,s.longitude,s.needs_review,s.speed,s.reporter_name,s.image_key,s.image_data
+FROM sighting.sighting s JOIN sighting.tiger t ON t.id = s.tiger_id
+WHERE s.id = \$1 AND s.tiger_id = \$2 AND s.deleted_at IS NULL AND t.deleted_at IS NULL`
+	queryStringRow := []string{"id", "tiger_id", "seen_at", "latitude", "longitude", "needs_review", "speed", "reporter_name", "image_key", "image_data"}
+	seenAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tigerID, sightingID := int32(1), int32(2)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				resData, err := repositorySuite.repo.GetSightingByID(context.Background(), tigerID, sightingID)
+				require.EqualError(t, err, "db error")
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error sighting not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(queryStringRow))
+
+				resData, err := repositorySuite.repo.GetSightingByID(context.Background(), tigerID, sightingID)
+				require.ErrorIs(t, err, entity.ErrSightingNotFound)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(sightingID))
+
+				resData, err := repositorySuite.repo.GetSightingByID(context.Background(), tigerID, sightingID)
+				require.Error(t, err)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "successfully get sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(sightingID, tigerID).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow(sightingID, tigerID, seenAt, -6.18, 106.0, false, sql.NullFloat64{}, "Ranger", "sighting/1/a.png", ""))
+
+				resData, err := repositorySuite.repo.GetSightingByID(context.Background(), tigerID, sightingID)
+				require.NoError(t, err)
+				require.Equal(t, &entity.Sighting{ID: sightingID, TigerID: tigerID, SeenAt: seenAt, Latitude: -6.18, Longitude: 106.0,
+					ReporterName: "Ranger", ImageKey: "sighting/1/a.png"}, resData)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestCreateSighting(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	queryString := `INSERT INTO sighting.sighting \(tiger_id,seen_at,latitude,longitude,location,image_key,image_hash,needs_review,speed,reporter_name,reporter_email,created_at,updated_at\) ` +
+		`VALUES \(\$1, \$2, \$3, \$4, ST_SetSRID\(ST_MakePoint\(\$4, \$3\), 4326\)::geography, \$5, \$12, \$6, \$7, \$8, \$9, \$10, \$11\) RETURNING id`
+	sighting := &entity.Sighting{
+		TigerID:       1,
+		SeenAt:        time.Now(),
+		Latitude:      -6.18,
+		Longitude:     107.00,
+		ReporterName:  "Ranger",
+		ReporterEmail: "ranger@example.com",
+		ImageKey:      "sighting/1/0b2a1c1e-8e4f-4a43-9d38-1f8a4a3c2b8e.png",
+		ImageHash:     0xf0e1d2c3b4a59687,
+	}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "database returns no rows when scanning",
+			testcaseFunction: func(t *testing.T) {
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(pgx.ErrNoRows)
+
+				err := repositorySuite.repo.CreateSighting(context.Background(), sighting)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "database returns no rows when scanning",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(pgx.ErrNoRows)
+
+				err := repositorySuite.repo.CreateSighting(context.Background(), sighting)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly create tiger data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				// the hash is stored with the same 64 bits in a bigint
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(sighting.TigerID, sighting.SeenAt, sighting.Latitude, sighting.Longitude, sighting.ImageKey, false, sql.NullFloat64{},
+						sighting.ReporterName, sighting.ReporterEmail, pgxmock.AnyArg(), pgxmock.AnyArg(), int64(-0x0f1e2d3c4b5a6979)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(7)))
+
+				created := *sighting
+				err := repositorySuite.repo.CreateSighting(context.Background(), &created)
+				require.NoError(t, err)
+				require.Equal(t, int32(7), created.ID)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetSimilarSightings(t *testing.T) {
+	t.Parallel()
+	distance := `length\(replace\(\(\(s.image_hash # \$1\)::bit\(64\)\)::text, '0', ''\)\)`
+	queryString := `SELECT s.id,s.tiger_id,s.seen_at,s.latitude,s.longitude,s.needs_review,s.speed,s.reporter_name,s.image_key,` + distance + ` AS distance
+FROM sighting.sighting s JOIN sighting.tiger t ON t.id = s.tiger_id
+WHERE s.deleted_at IS NULL AND t.deleted_at IS NULL
+AND \(\(\(s.image_hash >> 56\) & 255\) = \$2 OR \(\(s.image_hash >> 48\) & 255\) = \$3 OR \(\(s.image_hash >> 40\) & 255\) = \$4
+OR \(\(s.image_hash >> 32\) & 255\) = \$5 OR \(\(s.image_hash >> 24\) & 255\) = \$6 OR \(\(s.image_hash >> 16\) & 255\) = \$7
+OR \(\(s.image_hash >> 8\) & 255\) = \$8 OR \(s.image_hash & 255\) = \$9\)
+AND ` + distance + ` <= \$10
+ORDER BY distance, s.seen_at desc, s.id desc LIMIT \$11`
+	queryStringRow := []string{"id", "tiger_id", "seen_at", "latitude", "longitude", "needs_review", "speed", "reporter_name", "image_key", "distance"}
+	seenAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	hash := uint64(0xf0e1d2c3b4a59687)
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				res, err := repositorySuite.repo.GetSimilarSightings(context.Background(), hash, 3, 10)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("test-id"))
+
+				res, err := repositorySuite.repo.GetSimilarSightings(context.Background(), hash, 3, 10)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "successfully retrieve sightings sharing a band of the image hash",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int64(-0x0f1e2d3c4b5a6979), int64(0xf0), int64(0xe1), int64(0xd2), int64(0xc3), int64(0xb4), int64(0xa5), int64(0x96),
+						int64(0x87), int32(7), int32(10)).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow(int32(4), int32(1), seenAt, -6.18, 106.0, false, sql.NullFloat64{}, "Ranger", "sighting/1/a.jpeg", int32(0)).
+						AddRow(int32(9), int32(2), seenAt, -6.2, 106.1, true, sql.NullFloat64{Float64: 3, Valid: true}, "", "sighting/2/b.png", int32(2)))
+
+				res, err := repositorySuite.repo.GetSimilarSightings(context.Background(), hash, 7, 10)
+				require.NoError(t, err)
+				require.Equal(t, []*entity.SimilarSighting{
+					{Sighting: entity.Sighting{ID: 4, TigerID: 1, SeenAt: seenAt, Latitude: -6.18, Longitude: 106.0, ReporterName: "Ranger",
+						ImageKey: "sighting/1/a.jpeg"}, Distance: 0},
+					{Sighting: entity.Sighting{ID: 9, TigerID: 2, SeenAt: seenAt, Latitude: -6.2, Longitude: 106.1, NeedsReview: true,
+						Speed: sql.NullFloat64{Float64: 3, Valid: true}, ImageKey: "sighting/2/b.png"}, Distance: 2},
+				}, res)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetSightingsWithImageData(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id,tiger_id,image_data
+FROM sighting.sighting WHERE image_key = '' AND image_data <> '' AND id > \$1 ORDER BY id LIMIT \$2`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				res, err := repositorySuite.repo.GetSightingsWithImageData(context.Background(), 0, 10)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error when scanning rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow("test-id"))
+
+				res, err := repositorySuite.repo.GetSightingsWithImageData(context.Background(), 0, 10)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "successfully retrieve sightings with image data after ID",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(5), int32(10)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "tiger_id", "image_data"}).
+						AddRow(int32(6), int32(1), "data:image/png;base64,iVBORw0KGgo=").
+						AddRow(int32(8), int32(2), "iVBORw0KGgo="))
+
+				res, err := repositorySuite.repo.GetSightingsWithImageData(context.Background(), 5, 10)
+				require.NoError(t, err)
+				require.Equal(t, []*entity.Sighting{
+					{ID: 6, TigerID: 1, ImageData: "data:image/png;base64,iVBORw0KGgo="},
+					{ID: 8, TigerID: 2, ImageData: "iVBORw0KGgo="},
+				}, res)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestUpdateSightingImageKey(t *testing.T) {
+	t.Parallel()
+	queryString := `UPDATE sighting.sighting SET image_key = \$2, image_data = '' WHERE id = \$1 AND image_key = ''`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when update",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnError(errors.New("db error"))
+
+				require.Error(t, repositorySuite.repo.UpdateSightingImageKey(context.Background(), 6, "sighting/1/6.png"))
+			},
+		},
+		{
+			testcaseName: "successfully update image key",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int32(6), "sighting/1/6.png").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				require.NoError(t, repositorySuite.repo.UpdateSightingImageKey(context.Background(), 6, "sighting/1/6.png"))
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
 			},
 		},
 	}
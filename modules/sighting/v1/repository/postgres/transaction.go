@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// PostgreSQL error codes raised when a transaction loses against a concurrent one.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgLockNotAvailable     = "55P03"
+)
+
+var errNoTransaction = errors.New("row lock requires a transaction")
+
+// querier is the subset of functionality shared by pgxpool.Pool and pgx.Tx.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+type txKey struct{}
+
+// WithTransaction runs fn as a single unit of work inside a database transaction.
+// Every repository call made with the context given to fn joins the transaction.
+// The transaction is committed if fn returns nil, otherwise it is rolled back.
+// Errors caused by concurrent transactions are reported as entity.ErrConcurrentUpdate.
+// Calling WithTransaction with a context that already carries a transaction joins the outer transaction.
+func (t *TigerSightingRepo) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	logger := logging.NewRepoLogger(ctx, "WithTransaction", logrus.Fields{})
+
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when begin transaction")
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rerr := tx.Rollback(ctx); rerr != nil {
+			logging.WithError(rerr, logger).Warn("Error when rollback transaction")
+		}
+		return translateTxError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logging.WithError(err, logger).Warn("Error when commit transaction")
+		return translateTxError(err)
+	}
+	return nil
+}
+
+// conn returns the transaction carried by ctx, if any, or the pool otherwise.
+func (t *TigerSightingRepo) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return t.pool
+}
+
+// translateTxError wraps errors caused by concurrent transactions with entity.ErrConcurrentUpdate.
+func translateTxError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgSerializationFailure, pgDeadlockDetected, pgLockNotAvailable:
+		return fmt.Errorf("%w: %s", entity.ErrConcurrentUpdate, pgErr.Message)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,172 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+func TestWithTransaction(t *testing.T) {
+	t.Parallel()
+	insertSighting := `INSERT INTO sighting.sighting`
+	updateTiger := `UPDATE sighting.tiger SET name`
+	newSighting := func() *entity.Sighting {
+		return &entity.Sighting{TigerID: 1, SeenAt: time.Now(), Latitude: -6.18, Longitude: 107.00, ImageData: "image"}
+	}
+	tiger := &entity.Tiger{ID: 1, LastSeenTimestamp: time.Now(), LastSeenLatitude: -6.18, LastSeenLongitude: 107.00}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when begin transaction",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin().WillReturnError(errors.New("db error"))
+
+				called := false
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					called = true
+					return nil
+				})
+				require.Error(t, err)
+				require.False(t, called)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "rollback when second statement fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(insertSighting).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(1)))
+				repositorySuite.pgx.ExpectExec(updateTiger).WillReturnError(errors.New("db error"))
+				repositorySuite.pgx.ExpectRollback()
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					if err := repositorySuite.repo.CreateSighting(ctx, newSighting()); err != nil {
+						return err
+					}
+					return repositorySuite.repo.UpdateTiger(ctx, tiger)
+				})
+				require.Error(t, err)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error deadlock is reported as concurrent update",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectExec(updateTiger).WillReturnError(&pgconn.PgError{Code: "40P01", Message: "deadlock detected"})
+				repositorySuite.pgx.ExpectRollback()
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					return repositorySuite.repo.UpdateTiger(ctx, tiger)
+				})
+				require.ErrorIs(t, err, entity.ErrConcurrentUpdate)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error serialization failure on commit is reported as concurrent update",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectCommit().WillReturnError(&pgconn.PgError{Code: "40001"})
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					return nil
+				})
+				require.ErrorIs(t, err, entity.ErrConcurrentUpdate)
+			},
+		},
+		{
+			testcaseName: "Error when commit transaction",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(insertSighting).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(1)))
+				repositorySuite.pgx.ExpectCommit().WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					return repositorySuite.repo.CreateSighting(ctx, newSighting())
+				})
+				require.Error(t, err)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "rollback and re-panic when fn panics",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectRollback()
+
+				require.Panics(t, func() {
+					_ = repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+						panic("boom")
+					})
+				})
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "nested transaction joins the outer one",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(insertSighting).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(1)))
+				repositorySuite.pgx.ExpectExec(updateTiger).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				repositorySuite.pgx.ExpectCommit()
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					if err := repositorySuite.repo.CreateSighting(ctx, newSighting()); err != nil {
+						return err
+					}
+					return repositorySuite.repo.WithTransaction(ctx, func(ctx context.Context) error {
+						return repositorySuite.repo.UpdateTiger(ctx, tiger)
+					})
+				})
+				require.NoError(t, err)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully commit transaction",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.ExpectBegin()
+				repositorySuite.pgx.ExpectQuery(insertSighting).WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(1)))
+				repositorySuite.pgx.ExpectExec(updateTiger).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				repositorySuite.pgx.ExpectCommit()
+
+				err := repositorySuite.repo.WithTransaction(context.Background(), func(ctx context.Context) error {
+					if err := repositorySuite.repo.CreateSighting(ctx, newSighting()); err != nil {
+						return err
+					}
+					return repositorySuite.repo.UpdateTiger(ctx, tiger)
+				})
+				require.NoError(t, err)
+				require.NoError(t, repositorySuite.pgx.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
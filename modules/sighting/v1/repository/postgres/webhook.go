@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+const webhookSubscriptionColumns = "id, url, tiger_id, min_latitude, min_longitude, max_latitude, max_longitude, created_at"
+
+// CreateWebhookSubscription store a new webhook subscription in database and set its ID and creation time
+func (t *TigerSightingRepo) CreateWebhookSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	logger := logging.NewRepoLogger(ctx, "CreateWebhookSubscription", logrus.Fields{})
+
+	queryString := "INSERT INTO sighting.webhook_subscription " +
+		"(url,secret,tiger_id,min_latitude,min_longitude,max_latitude,max_longitude,created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
+
+	subscription.CreatedAt = time.Now()
+	args := append([]interface{}{subscription.URL, subscription.Secret, subscription.TigerID}, boundingBoxArgs(subscription.BoundingBox)...)
+	args = append(args, subscription.CreatedAt)
+	if err := t.conn(ctx).QueryRow(ctx, queryString, args...).Scan(&subscription.ID); err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookSubscriptions get every webhook subscription from database order by ID, without their secret
+func (t *TigerSightingRepo) GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	logger := logging.NewRepoLogger(ctx, "GetWebhookSubscriptions", logrus.Fields{})
+
+	queryString := "SELECT " + webhookSubscriptionColumns + " FROM sighting.webhook_subscription " +
+		"WHERE deleted_at IS NULL ORDER BY id"
+	return t.getWebhookSubscriptions(ctx, logger, queryString)
+}
+
+// GetWebhookSubscriptionsForSighting get webhook subscriptions matching a sighting of given tiger ID at the given point
+// from database order by ID, without their secret
+func (t *TigerSightingRepo) GetWebhookSubscriptionsForSighting(ctx context.Context, tigerID int32, latitude, longitude float64) ([]*entity.WebhookSubscription, error) {
+	logger := logging.NewRepoLogger(ctx, "GetWebhookSubscriptionsForSighting", logrus.Fields{})
+
+	// min_longitude greater than max_longitude means the bounding box crosses the antimeridian
+	queryString := "SELECT " + webhookSubscriptionColumns + " FROM sighting.webhook_subscription " +
+		"WHERE deleted_at IS NULL AND (tiger_id IS NULL OR tiger_id = $1) " +
+		"AND (min_latitude IS NULL OR ($2 BETWEEN min_latitude AND max_latitude AND " +
+		"CASE WHEN min_longitude <= max_longitude THEN $3 BETWEEN min_longitude AND max_longitude " +
+		"ELSE $3 >= min_longitude OR $3 <= max_longitude END)) " +
+		"ORDER BY id"
+	return t.getWebhookSubscriptions(ctx, logger, queryString, tigerID, latitude, longitude)
+}
+
+func (t *TigerSightingRepo) getWebhookSubscriptions(ctx context.Context, logger *logrus.Entry, queryString string, args ...interface{}) ([]*entity.WebhookSubscription, error) {
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, args...)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.WebhookSubscription
+	for rows.Next() {
+		tmp, serr := scanWebhookSubscription(rows)
+		if serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		res = append(res, tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	return res, nil
+}
+
+// GetWebhookSubscriptionByID get webhook subscription by ID with its secret from database
+func (t *TigerSightingRepo) GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int32) (*entity.WebhookSubscription, error) {
+	logger := logging.NewRepoLogger(ctx, "GetWebhookSubscriptionByID", logrus.Fields{})
+
+	queryString := "SELECT " + webhookSubscriptionColumns + ", secret FROM sighting.webhook_subscription " +
+		"WHERE id = $1 AND deleted_at IS NULL"
+	var secret string
+	res, err := scanWebhookSubscription(t.conn(ctx).QueryRow(ctx, queryString, subscriptionID), &secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return nil, err
+	}
+	res.Secret = secret
+
+	return res, nil
+}
+
+// DeleteWebhookSubscription soft delete webhook subscription in database
+func (t *TigerSightingRepo) DeleteWebhookSubscription(ctx context.Context, subscriptionID int32) error {
+	logger := logging.NewRepoLogger(ctx, "DeleteWebhookSubscription", logrus.Fields{})
+
+	queryString := "UPDATE sighting.webhook_subscription SET deleted_at = $2 WHERE id = $1 and deleted_at IS NULL"
+
+	tag, err := t.conn(ctx).Exec(ctx, queryString, subscriptionID, time.Now())
+	if err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhookDeliveryAttempt store a new delivery attempt in database and set its ID and creation time
+func (t *TigerSightingRepo) CreateWebhookDeliveryAttempt(ctx context.Context, attempt *entity.WebhookDeliveryAttempt) error {
+	logger := logging.NewRepoLogger(ctx, "CreateWebhookDeliveryAttempt", logrus.Fields{})
+
+	queryString := "INSERT INTO sighting.webhook_delivery_attempt " +
+		"(subscription_id,delivery_id,event_type,attempt,status_code,error,duration_ms,created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
+
+	attempt.CreatedAt = time.Now()
+	err := t.conn(ctx).QueryRow(ctx, queryString,
+		attempt.SubscriptionID,
+		attempt.DeliveryID,
+		attempt.EventType,
+		attempt.Attempt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.Duration.Milliseconds(),
+		attempt.CreatedAt,
+	).Scan(&attempt.ID)
+	if err != nil {
+		logging.WithError(err, logger).Warnf("Error when execute query %s", queryString)
+	}
+
+	return err
+}
+
+// GetWebhookDeliveryAttempts get at most limit delivery attempts for given subscription ID from database, latest first
+func (t *TigerSightingRepo) GetWebhookDeliveryAttempts(ctx context.Context, subscriptionID int32, limit int32) ([]*entity.WebhookDeliveryAttempt, error) {
+	logger := logging.NewRepoLogger(ctx, "GetWebhookDeliveryAttempts", logrus.Fields{})
+
+	queryString := "SELECT id, subscription_id, delivery_id, event_type, attempt, status_code, error, duration_ms, created_at " +
+		"FROM sighting.webhook_delivery_attempt WHERE subscription_id = $1 ORDER BY id DESC LIMIT $2"
+	rows, err := queryWrapper(ctx, t.conn(ctx), queryString, subscriptionID, limit)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when hit query wrapper")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*entity.WebhookDeliveryAttempt
+	for rows.Next() {
+		var (
+			tmp        entity.WebhookDeliveryAttempt
+			durationMs int64
+		)
+		if serr := rows.Scan(
+			&tmp.ID, &tmp.SubscriptionID, &tmp.DeliveryID, &tmp.EventType, &tmp.Attempt, &tmp.StatusCode, &tmp.Error, &durationMs, &tmp.CreatedAt,
+		); serr != nil {
+			logging.WithError(serr, logger).Warn("Error when scan rows")
+			return nil, serr
+		}
+		tmp.Duration = time.Duration(durationMs) * time.Millisecond
+		res = append(res, &tmp)
+	}
+	if rows.Err() != nil {
+		logging.WithError(rows.Err(), logger).Warn("Error when check rows")
+		return nil, rows.Err()
+	}
+
+	return res, nil
+}
+
+// scanWebhookSubscription scans webhookSubscriptionColumns, followed by extra columns into extra.
+func scanWebhookSubscription(row pgx.Row, extra ...interface{}) (*entity.WebhookSubscription, error) {
+	var (
+		res                                                  entity.WebhookSubscription
+		minLatitude, minLongitude, maxLatitude, maxLongitude sql.NullFloat64
+	)
+	dest := append([]interface{}{&res.ID, &res.URL, &res.TigerID, &minLatitude, &minLongitude, &maxLatitude, &maxLongitude, &res.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if minLatitude.Valid {
+		res.BoundingBox = &entity.BoundingBox{
+			MinLatitude:  minLatitude.Float64,
+			MinLongitude: minLongitude.Float64,
+			MaxLatitude:  maxLatitude.Float64,
+			MaxLongitude: maxLongitude.Float64,
+		}
+	}
+	return &res, nil
+}
+
+// boundingBoxArgs returns min latitude, min longitude, max latitude and max longitude of box, null if box is nil.
+func boundingBoxArgs(box *entity.BoundingBox) []interface{} {
+	if box == nil {
+		return []interface{}{sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}}
+	}
+	return []interface{}{
+		sql.NullFloat64{Float64: box.MinLatitude, Valid: true},
+		sql.NullFloat64{Float64: box.MinLongitude, Valid: true},
+		sql.NullFloat64{Float64: box.MaxLatitude, Valid: true},
+		sql.NullFloat64{Float64: box.MaxLongitude, Valid: true},
+	}
+}
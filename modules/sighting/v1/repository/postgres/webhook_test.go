@@ -0,0 +1,428 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+var (
+	webhookSubscriptionRow = []string{"id", "url", "tiger_id", "min_latitude", "min_longitude", "max_latitude", "max_longitude", "created_at"}
+	webhookCreatedAt       = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	webhookBoundingBox     = &entity.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170}
+)
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	queryString := `INSERT INTO sighting.webhook_subscription \(url,secret,tiger_id,min_latitude,min_longitude,max_latitude,max_longitude,created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when insert to database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.CreateWebhookSubscription(context.Background(), &entity.WebhookSubscription{URL: "https://example.com"})
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly create webhook subscription without filter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs("https://example.com", "secret", sql.NullInt32{},
+						sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(3)))
+
+				subscription := &entity.WebhookSubscription{URL: "https://example.com", Secret: "secret"}
+				err := repositorySuite.repo.CreateWebhookSubscription(context.Background(), subscription)
+				require.NoError(t, err)
+				require.Equal(t, int32(3), subscription.ID)
+				require.False(t, subscription.CreatedAt.IsZero())
+			},
+		},
+		{
+			testcaseName: "sucessfullly create webhook subscription filtered by tiger and bounding box",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs("https://example.com", "secret", sql.NullInt32{Int32: 1, Valid: true},
+						sql.NullFloat64{Float64: -10, Valid: true}, sql.NullFloat64{Float64: 170, Valid: true},
+						sql.NullFloat64{Float64: 10, Valid: true}, sql.NullFloat64{Float64: -170, Valid: true}, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(3)))
+
+				subscription := &entity.WebhookSubscription{
+					URL:         "https://example.com",
+					Secret:      "secret",
+					TigerID:     sql.NullInt32{Int32: 1, Valid: true},
+					BoundingBox: webhookBoundingBox,
+				}
+				err := repositorySuite.repo.CreateWebhookSubscription(context.Background(), subscription)
+				require.NoError(t, err)
+				require.Equal(t, int32(3), subscription.ID)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetWebhookSubscriptions(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id, url, tiger_id, min_latitude, min_longitude, max_latitude, max_longitude, created_at FROM sighting.webhook_subscription WHERE deleted_at IS NULL ORDER BY id`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptions(context.Background())
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when scan rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(1)))
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptions(context.Background())
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when check rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(webhookSubscriptionRow).
+						AddRow(int32(1), "https://example.com", sql.NullInt32{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, webhookCreatedAt).
+						RowError(0, errors.New("row error")))
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptions(context.Background())
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly get webhook subscriptions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows(webhookSubscriptionRow).
+						AddRow(int32(1), "https://example.com", sql.NullInt32{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, webhookCreatedAt).
+						AddRow(int32(2), "https://example.org", sql.NullInt32{Int32: 1, Valid: true},
+							sql.NullFloat64{Float64: -10, Valid: true}, sql.NullFloat64{Float64: 170, Valid: true},
+							sql.NullFloat64{Float64: 10, Valid: true}, sql.NullFloat64{Float64: -170, Valid: true}, webhookCreatedAt))
+
+				res, err := repositorySuite.repo.GetWebhookSubscriptions(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, []*entity.WebhookSubscription{
+					{ID: 1, URL: "https://example.com", CreatedAt: webhookCreatedAt},
+					{ID: 2, URL: "https://example.org", TigerID: sql.NullInt32{Int32: 1, Valid: true}, BoundingBox: webhookBoundingBox, CreatedAt: webhookCreatedAt},
+				}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetWebhookSubscriptionsForSighting(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id, url, tiger_id, min_latitude, min_longitude, max_latitude, max_longitude, created_at FROM sighting.webhook_subscription ` +
+		`WHERE deleted_at IS NULL AND \(tiger_id IS NULL OR tiger_id = \$1\) ` +
+		`AND \(min_latitude IS NULL OR \(\$2 BETWEEN min_latitude AND max_latitude AND ` +
+		`CASE WHEN min_longitude <= max_longitude THEN \$3 BETWEEN min_longitude AND max_longitude ` +
+		`ELSE \$3 >= min_longitude OR \$3 <= max_longitude END\)\) ORDER BY id`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptionsForSighting(context.Background(), 1, -6.2, 106.8)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly get webhook subscriptions matching the sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(1), -6.2, 106.8).
+					WillReturnRows(pgxmock.NewRows(webhookSubscriptionRow).
+						AddRow(int32(1), "https://example.com", sql.NullInt32{Int32: 1, Valid: true}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, webhookCreatedAt))
+
+				res, err := repositorySuite.repo.GetWebhookSubscriptionsForSighting(context.Background(), 1, -6.2, 106.8)
+				require.NoError(t, err)
+				require.Equal(t, []*entity.WebhookSubscription{
+					{ID: 1, URL: "https://example.com", TigerID: sql.NullInt32{Int32: 1, Valid: true}, CreatedAt: webhookCreatedAt},
+				}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetWebhookSubscriptionByID(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id, url, tiger_id, min_latitude, min_longitude, max_latitude, max_longitude, created_at, secret FROM sighting.webhook_subscription WHERE id = \$1 AND deleted_at IS NULL`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when subscription is not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(pgx.ErrNoRows)
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptionByID(context.Background(), 1)
+				require.Equal(t, entity.ErrWebhookSubscriptionNotFound, err)
+			},
+		},
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.GetWebhookSubscriptionByID(context.Background(), 1)
+				require.Error(t, err)
+				require.NotEqual(t, entity.ErrWebhookSubscriptionNotFound, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly get webhook subscription with its secret",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(1)).
+					WillReturnRows(pgxmock.NewRows(append(webhookSubscriptionRow, "secret")).
+						AddRow(int32(1), "https://example.com", sql.NullInt32{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, sql.NullFloat64{}, webhookCreatedAt, "secret"))
+
+				res, err := repositorySuite.repo.GetWebhookSubscriptionByID(context.Background(), 1)
+				require.NoError(t, err)
+				require.Equal(t, &entity.WebhookSubscription{ID: 1, URL: "https://example.com", Secret: "secret", CreatedAt: webhookCreatedAt}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestDeleteWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	queryString := `UPDATE sighting.webhook_subscription SET deleted_at = \$2 WHERE id = \$1 and deleted_at IS NULL`
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when update database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.DeleteWebhookSubscription(context.Background(), 1)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when subscription is not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int32(1), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+				err := repositorySuite.repo.DeleteWebhookSubscription(context.Background(), 1)
+				require.Equal(t, entity.ErrWebhookSubscriptionNotFound, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly delete webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectExec(queryString).
+					WithArgs(int32(1), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				err := repositorySuite.repo.DeleteWebhookSubscription(context.Background(), 1)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestCreateWebhookDeliveryAttempt(t *testing.T) {
+	t.Parallel()
+	queryString := `INSERT INTO sighting.webhook_delivery_attempt \(subscription_id,delivery_id,event_type,attempt,status_code,error,duration_ms,created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8\) RETURNING id`
+	newAttempt := func() *entity.WebhookDeliveryAttempt {
+		return &entity.WebhookDeliveryAttempt{
+			SubscriptionID: 1,
+			DeliveryID:     "42-1",
+			EventType:      entity.WebhookEventSightingRecorded,
+			Attempt:        2,
+			StatusCode:     500,
+			Error:          "unexpected status 500",
+			Duration:       1500 * time.Millisecond,
+		}
+	}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when insert to database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				err := repositorySuite.repo.CreateWebhookDeliveryAttempt(context.Background(), newAttempt())
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly create webhook delivery attempt",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(1), "42-1", entity.WebhookEventSightingRecorded, int32(2), int32(500), "unexpected status 500", int64(1500), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(7)))
+
+				attempt := newAttempt()
+				err := repositorySuite.repo.CreateWebhookDeliveryAttempt(context.Background(), attempt)
+				require.NoError(t, err)
+				require.Equal(t, int64(7), attempt.ID)
+				require.False(t, attempt.CreatedAt.IsZero())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetWebhookDeliveryAttempts(t *testing.T) {
+	t.Parallel()
+	queryString := `SELECT id, subscription_id, delivery_id, event_type, attempt, status_code, error, duration_ms, created_at FROM sighting.webhook_delivery_attempt WHERE subscription_id = \$1 ORDER BY id DESC LIMIT \$2`
+	queryStringRow := []string{"id", "subscription_id", "delivery_id", "event_type", "attempt", "status_code", "error", "duration_ms", "created_at"}
+
+	testCases := []RepositoryTestCases{
+		{
+			testcaseName: "Error when query fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnError(errors.New("db error"))
+
+				_, err := repositorySuite.repo.GetWebhookDeliveryAttempts(context.Background(), 1, 50)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when scan rows",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(1)))
+
+				_, err := repositorySuite.repo.GetWebhookDeliveryAttempts(context.Background(), 1, 50)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "sucessfullly get webhook delivery attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repositorySuite := SightingRepositoryTestSuite()
+				repositorySuite.pgx.
+					ExpectQuery(queryString).
+					WithArgs(int32(1), int32(50)).
+					WillReturnRows(pgxmock.NewRows(queryStringRow).
+						AddRow(int64(8), int32(1), "42-1", entity.WebhookEventSightingRecorded, int32(2), int32(204), "", int64(35), webhookCreatedAt).
+						AddRow(int64(7), int32(1), "42-1", entity.WebhookEventSightingRecorded, int32(1), int32(0), "timeout", int64(10000), webhookCreatedAt))
+
+				res, err := repositorySuite.repo.GetWebhookDeliveryAttempts(context.Background(), 1, 50)
+				require.NoError(t, err)
+				require.Equal(t, []*entity.WebhookDeliveryAttempt{
+					{ID: 8, SubscriptionID: 1, DeliveryID: "42-1", EventType: entity.WebhookEventSightingRecorded, Attempt: 2, StatusCode: 204, Duration: 35 * time.Millisecond, CreatedAt: webhookCreatedAt},
+					{ID: 7, SubscriptionID: 1, DeliveryID: "42-1", EventType: entity.WebhookEventSightingRecorded, Attempt: 1, Error: "timeout", Duration: 10 * time.Second, CreatedAt: webhookCreatedAt},
+				}, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
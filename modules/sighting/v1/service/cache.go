@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ibrahimker/tigerhall-kittens/driver/redis"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// CacheInvalidator is responsible for invalidating the cache entries an outbox event makes stale.
+// Unlike the invalidation done right after a change, it returns errors so that the event is retried.
+type CacheInvalidator struct {
+	redisRepo redis.Redis
+}
+
+// NewCacheInvalidator creates an instance of CacheInvalidator.
+func NewCacheInvalidator(redisRepo redis.Redis) *CacheInvalidator {
+	return &CacheInvalidator{redisRepo: redisRepo}
+}
+
+// Handle invalidates the tiger lists for EventTigerCreated and every cache entry of the tiger for other events.
+func (c *CacheInvalidator) Handle(ctx context.Context, event *entity.OutboxEvent) error {
+	if event.Type == entity.EventTigerCreated {
+		_, err := c.redisRepo.Incr(ctx, GetTigersVersionKey)
+		return err
+	}
+	return invalidateTigerCache(ctx, c.redisRepo, event.AggregateID)
+}
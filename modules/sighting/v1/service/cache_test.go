@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	mockRedisRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/common/redis"
+)
+
+func TestCacheInvalidator_Handle(t *testing.T) {
+	t.Parallel()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	sightingsVersionKey := fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)
+	tigerKey := fmt.Sprintf(service.GetTigerByIDKey, tigerID)
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "invalidate tiger lists on tiger created",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+
+				err := service.NewCacheInvalidator(redisRepo).Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventTigerCreated, AggregateID: tigerID})
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when invalidate tiger lists on tiger created",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(0), errors.New("redis error"))
+
+				err := service.NewCacheInvalidator(redisRepo).Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventTigerCreated, AggregateID: tigerID})
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "invalidate every cache entry of the tiger on sighting recorded",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Incr(mockCtx, sightingsVersionKey).Return(int64(1), nil)
+				redisRepo.EXPECT().Del(mockCtx, tigerKey).Return(nil)
+				redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+
+				err := service.NewCacheInvalidator(redisRepo).Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventSightingRecorded, AggregateID: tigerID})
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "successfully invalidate every cache entry of the tiger on tiger deleted",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Incr(mockCtx, sightingsVersionKey).Return(int64(1), nil)
+				redisRepo.EXPECT().Del(mockCtx, tigerKey).Return(nil)
+				redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+
+				err := service.NewCacheInvalidator(redisRepo).Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventTigerDeleted, AggregateID: tigerID})
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when delete cached tiger on tiger updated",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Incr(mockCtx, sightingsVersionKey).Return(int64(1), nil)
+				redisRepo.EXPECT().Del(mockCtx, tigerKey).Return(errors.New("redis error"))
+				redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+
+				err := service.NewCacheInvalidator(redisRepo).Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventTigerUpdated, AggregateID: tigerID})
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
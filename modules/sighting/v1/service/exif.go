@@ -0,0 +1,347 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// EXIF tags read from the images of sightings.
+const (
+	exifTagOrientation        = 0x0112
+	exifTagExifIFD            = 0x8769
+	exifTagGPSIFD             = 0x8825
+	exifTagDateTimeOriginal   = 0x9003
+	exifTagOffsetTimeOriginal = 0x9011
+	exifTagGPSLatitudeRef     = 0x0001
+	exifTagGPSLatitude        = 0x0002
+	exifTagGPSLongitudeRef    = 0x0003
+	exifTagGPSLongitude       = 0x0004
+	exifTagGPSTimeStamp       = 0x0007
+	exifTagGPSDateStamp       = 0x001d
+)
+
+// EXIF field types read from the images of sightings.
+const (
+	exifTypeASCII    = 2
+	exifTypeShort    = 3
+	exifTypeLong     = 4
+	exifTypeRational = 5
+)
+
+var (
+	// exifHeader starts the APP1 segment of a jpeg image holding its EXIF.
+	exifHeader = []byte("Exif\x00\x00")
+	// errInvalidExif is returned when the EXIF of an image is truncated or malformed.
+	errInvalidExif = errors.New("image EXIF is not valid")
+)
+
+// imageExif is the metadata read from the EXIF of the image of a sighting.
+type imageExif struct {
+	// SeenAt is the capture time. It is zero if the EXIF has no capture time with a time zone,
+	// i.e. neither an original date time with its offset nor a GPS date and time, which is UTC.
+	SeenAt time.Time
+	// HasLocation tells whether Latitude and Longitude are the GPS location of the capture.
+	HasLocation bool
+	Latitude    float64
+	Longitude   float64
+	// Orientation is the EXIF orientation, from 1 to 8. It is zero if the EXIF has none.
+	Orientation int
+}
+
+// ExifTolerance is how far the seen time and location of a new sighting may be from the EXIF of its image
+// before the sighting needs review.
+type ExifTolerance struct {
+	Time time.Duration
+	// Distance is in meters
+	Distance float64
+}
+
+// jpegSegment is a marker segment of a jpeg image before its scan. Data includes the marker and the length.
+type jpegSegment struct {
+	marker byte
+	data   []byte
+}
+
+// payload returns the content of the segment after its marker and length.
+func (s jpegSegment) payload() []byte {
+	if len(s.data) < 4 {
+		return nil
+	}
+	return s.data[4:]
+}
+
+// splitJPEG returns the marker segments of a jpeg image before its scan, and the rest of the image from the scan.
+func splitJPEG(data []byte) (segments []jpegSegment, rest []byte, err error) {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, nil, errors.New("image is not a jpeg image")
+	}
+	for i := 2; ; {
+		if i+1 >= len(data) || data[i] != 0xff {
+			return nil, nil, errors.New("jpeg image is truncated")
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xff:
+			// fill byte before a marker
+			i++
+			continue
+		case marker == 0xda || marker == 0xd9:
+			// start of scan or end of image
+			return segments, data[i:], nil
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			// markers without length
+			segments = append(segments, jpegSegment{marker: marker, data: data[i : i+2]})
+			i += 2
+			continue
+		}
+		if i+3 >= len(data) {
+			return nil, nil, errors.New("jpeg image is truncated")
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) || end < i+4 {
+			return nil, nil, errors.New("jpeg image is truncated")
+		}
+		segments = append(segments, jpegSegment{marker: marker, data: data[i:end]})
+		i = end
+	}
+}
+
+// readJPEGExif returns the metadata in the EXIF of a jpeg image. It returns nil if the image has no EXIF.
+func readJPEGExif(data []byte) (*imageExif, error) {
+	segments, _, err := splitJPEG(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if segment.marker == 0xe1 && bytes.HasPrefix(segment.payload(), exifHeader) {
+			return parseExif(segment.payload()[len(exifHeader):])
+		}
+	}
+	return nil, nil
+}
+
+// stripJPEGMetadata returns the jpeg image without its EXIF, XMP, IPTC and comments, which may hold the location
+// of the reporter. An EXIF holding only the orientation replaces them, unless orientation is 0 or 1, i.e. the image is upright.
+func stripJPEGMetadata(data []byte, orientation int) ([]byte, error) {
+	segments, rest, err := splitJPEG(data)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xff, 0xd8)
+	// the EXIF follows the JFIF header if any, and comes first otherwise
+	if len(segments) > 0 && segments[0].marker == 0xe0 {
+		out = append(out, segments[0].data...)
+		segments = segments[1:]
+	}
+	out = appendOrientationExif(out, orientation)
+	for _, segment := range segments {
+		// APP1 holds the EXIF and the XMP, APP13 the IPTC
+		if segment.marker == 0xe1 || segment.marker == 0xed || segment.marker == 0xfe {
+			continue
+		}
+		out = append(out, segment.data...)
+	}
+	return append(out, rest...), nil
+}
+
+// appendOrientationExif appends an APP1 segment holding an EXIF with only the orientation, if it isn't upright.
+func appendOrientationExif(out []byte, orientation int) []byte {
+	if orientation < 2 || orientation > 8 {
+		return out
+	}
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8,
+		0, 1, // one entry
+		0x01, 0x12, 0, exifTypeShort, 0, 0, 0, 1, 0, byte(orientation), 0, 0,
+		0, 0, 0, 0} // no next IFD
+	length := 2 + len(exifHeader) + len(tiff)
+	out = append(out, 0xff, 0xe1, byte(length>>8), byte(length))
+	out = append(out, exifHeader...)
+	return append(out, tiff...)
+}
+
+// exifReader reads the fields of the IFDs of a TIFF structure, i.e. the content of an EXIF.
+type exifReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// exifField is a field of an IFD.
+type exifField struct {
+	fieldType uint16
+	count     uint32
+	value     []byte
+}
+
+// parseExif reads the orientation, the capture time and the GPS location from an EXIF.
+func parseExif(data []byte) (*imageExif, error) {
+	if len(data) < 8 {
+		return nil, errInvalidExif
+	}
+	r := &exifReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return nil, errInvalidExif
+	}
+	if r.order.Uint16(data[2:]) != 42 {
+		return nil, errInvalidExif
+	}
+
+	ifd0, err := r.readIFD(r.order.Uint32(data[4:]))
+	if err != nil {
+		return nil, err
+	}
+	res := &imageExif{}
+	if orientation, ok := r.uint(ifd0[exifTagOrientation]); ok && orientation >= 1 && orientation <= 8 {
+		res.Orientation = int(orientation)
+	}
+
+	if offset, ok := r.uint(ifd0[exifTagExifIFD]); ok {
+		exifIFD, err := r.readIFD(offset)
+		if err != nil {
+			return nil, err
+		}
+		// the original date time is local time, only usable with its offset
+		layout := "2006:01:02 15:04:05-07:00"
+		if seenAt, err := time.Parse(layout, r.string(exifIFD[exifTagDateTimeOriginal])+r.string(exifIFD[exifTagOffsetTimeOriginal])); err == nil {
+			res.SeenAt = seenAt
+		}
+	}
+
+	if offset, ok := r.uint(ifd0[exifTagGPSIFD]); ok {
+		gpsIFD, err := r.readIFD(offset)
+		if err != nil {
+			return nil, err
+		}
+		latitude, latOK := r.degrees(gpsIFD[exifTagGPSLatitude], r.string(gpsIFD[exifTagGPSLatitudeRef]), "N", "S")
+		longitude, lngOK := r.degrees(gpsIFD[exifTagGPSLongitude], r.string(gpsIFD[exifTagGPSLongitudeRef]), "E", "W")
+		if latOK && lngOK && latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180 {
+			res.HasLocation, res.Latitude, res.Longitude = true, latitude, longitude
+		}
+		if res.SeenAt.IsZero() {
+			res.SeenAt = r.gpsTime(gpsIFD[exifTagGPSDateStamp], gpsIFD[exifTagGPSTimeStamp])
+		}
+	}
+	return res, nil
+}
+
+// readIFD returns the fields of the IFD at the offset by tag.
+func (r *exifReader) readIFD(offset uint32) (map[uint16]exifField, error) {
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return nil, errInvalidExif
+	}
+	count := int(r.order.Uint16(r.data[offset:]))
+	start := int(offset) + 2
+	if start+count*12 > len(r.data) {
+		return nil, errInvalidExif
+	}
+
+	fields := make(map[uint16]exifField, count)
+	for i := 0; i < count; i++ {
+		entry := r.data[start+i*12 : start+(i+1)*12]
+		field := exifField{fieldType: r.order.Uint16(entry[2:]), count: r.order.Uint32(entry[4:])}
+		size := uint64(field.count) * uint64(exifTypeSize(field.fieldType))
+		if size <= 4 {
+			field.value = entry[8 : 8+size]
+		} else {
+			valueOffset := uint64(r.order.Uint32(entry[8:]))
+			if valueOffset+size > uint64(len(r.data)) {
+				return nil, errInvalidExif
+			}
+			field.value = r.data[valueOffset : valueOffset+size]
+		}
+		fields[r.order.Uint16(entry)] = field
+	}
+	return fields, nil
+}
+
+// exifTypeSize returns the size in bytes of a value of the field type, or 0 if the type is not read.
+func exifTypeSize(fieldType uint16) int {
+	switch fieldType {
+	case exifTypeASCII:
+		return 1
+	case exifTypeShort:
+		return 2
+	case exifTypeLong:
+		return 4
+	case exifTypeRational:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// uint returns the first value of a SHORT or LONG field.
+func (r *exifReader) uint(field exifField) (uint32, bool) {
+	switch {
+	case field.fieldType == exifTypeShort && len(field.value) >= 2:
+		return uint32(r.order.Uint16(field.value)), true
+	case field.fieldType == exifTypeLong && len(field.value) >= 4:
+		return r.order.Uint32(field.value), true
+	default:
+		return 0, false
+	}
+}
+
+// string returns the value of an ASCII field without its trailing NUL and spaces.
+func (r *exifReader) string(field exifField) string {
+	if field.fieldType != exifTypeASCII {
+		return ""
+	}
+	return strings.TrimRight(string(field.value), "\x00 ")
+}
+
+// rationals returns the values of a RATIONAL field.
+func (r *exifReader) rationals(field exifField) ([]float64, bool) {
+	if field.fieldType != exifTypeRational || len(field.value) == 0 {
+		return nil, false
+	}
+	values := make([]float64, 0, len(field.value)/8)
+	for i := 0; i+8 <= len(field.value); i += 8 {
+		denominator := r.order.Uint32(field.value[i+4:])
+		if denominator == 0 {
+			return nil, false
+		}
+		values = append(values, float64(r.order.Uint32(field.value[i:]))/float64(denominator))
+	}
+	return values, true
+}
+
+// degrees returns the decimal degrees of a GPS coordinate given as degrees, minutes and seconds,
+// negative if ref is the negative reference, e.g. S for latitudes.
+func (r *exifReader) degrees(field exifField, ref, positive, negative string) (float64, bool) {
+	values, ok := r.rationals(field)
+	if !ok || len(values) != 3 || (ref != positive && ref != negative) {
+		return 0, false
+	}
+	degrees := values[0] + values[1]/60 + values[2]/3600
+	if ref == negative {
+		degrees = -degrees
+	}
+	return degrees, true
+}
+
+// gpsTime returns the UTC time of the GPS date and time stamps, or zero if they are missing or not valid.
+func (r *exifReader) gpsTime(dateStamp, timeStamp exifField) time.Time {
+	date, err := time.Parse("2006:01:02", r.string(dateStamp))
+	if err != nil {
+		return time.Time{}
+	}
+	values, ok := r.rationals(timeStamp)
+	if !ok || len(values) != 3 {
+		return time.Time{}
+	}
+	seconds := values[0]*3600 + values[1]*60 + values[2]
+	if seconds < 0 || seconds >= 24*3600 {
+		return time.Time{}
+	}
+	return date.Add(time.Duration(math.Round(seconds * float64(time.Second))))
+}
@@ -0,0 +1,441 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+)
+
+// tiffEntry is a field of an IFD of a big endian TIFF structure.
+type tiffEntry struct {
+	tag       uint16
+	fieldType uint16
+	count     uint32
+	value     []byte
+}
+
+func asciiEntry(tag uint16, value string) tiffEntry {
+	return tiffEntry{tag: tag, fieldType: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+func shortEntry(tag, value uint16) tiffEntry {
+	return tiffEntry{tag: tag, fieldType: 3, count: 1, value: []byte{byte(value >> 8), byte(value)}}
+}
+
+func longEntry(tag uint16, value uint32) tiffEntry {
+	return tiffEntry{tag: tag, fieldType: 4, count: 1, value: appendUint32(nil, value)}
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// rationalEntry returns a RATIONAL field of the values, with four decimals.
+func rationalEntry(tag uint16, values ...float64) tiffEntry {
+	b := make([]byte, 0, 8*len(values))
+	for _, v := range values {
+		b = appendUint32(b, uint32(math.Round(v*10000)))
+		b = appendUint32(b, 10000)
+	}
+	return tiffEntry{tag: tag, fieldType: 5, count: uint32(len(values)), value: b}
+}
+
+// encodeIFD encodes the IFD stored at the offset, followed by the values not fitting in its entries.
+func encodeIFD(offset int, entries []tiffEntry) []byte {
+	size := 2 + 12*len(entries) + 4
+	var head, tail []byte
+	head = appendUint16(head, uint16(len(entries)))
+	for _, e := range entries {
+		head = appendUint16(head, e.tag)
+		head = appendUint16(head, e.fieldType)
+		head = appendUint32(head, e.count)
+		if len(e.value) <= 4 {
+			head = append(head, e.value...)
+			head = append(head, make([]byte, 4-len(e.value))...)
+			continue
+		}
+		head = appendUint32(head, uint32(offset+size+len(tail)))
+		tail = append(tail, e.value...)
+	}
+	head = appendUint32(head, 0)
+	return append(head, tail...)
+}
+
+// exifSegment returns the APP1 segment of a jpeg image holding an EXIF with the IFDs. Nil IFDs are left out.
+func exifSegment(ifd0, exifIFD, gpsIFD []tiffEntry) []byte {
+	// IFD0 points to the Exif and GPS IFDs stored after it, its size doesn't depend on the offsets
+	ifd0 = append([]tiffEntry{}, ifd0...)
+	exifPointer, gpsPointer := len(ifd0), len(ifd0)
+	if exifIFD != nil {
+		ifd0 = append(ifd0, longEntry(0x8769, 0))
+		gpsPointer++
+	}
+	if gpsIFD != nil {
+		ifd0 = append(ifd0, longEntry(0x8825, 0))
+	}
+	offset := 8 + len(encodeIFD(8, ifd0))
+	var rest []byte
+	if exifIFD != nil {
+		ifd0[exifPointer] = longEntry(0x8769, uint32(offset))
+		rest = append(rest, encodeIFD(offset, exifIFD)...)
+	}
+	if gpsIFD != nil {
+		ifd0[gpsPointer] = longEntry(0x8825, uint32(offset+len(rest)))
+		rest = append(rest, encodeIFD(offset+len(rest), gpsIFD)...)
+	}
+
+	tiff := append([]byte("MM\x00\x2a\x00\x00\x00\x08"), encodeIFD(8, ifd0)...)
+	tiff = append(tiff, rest...)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	return append([]byte{0xff, 0xe1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+}
+
+// xmpSegment is the APP1 segment of a jpeg image holding an XMP with a GPS location.
+var xmpSegment = func() []byte {
+	payload := []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta><exif:GPSLatitude>6,10.8S</exif:GPSLatitude></x:xmpmeta>")
+	return append([]byte{0xff, 0xe1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+}()
+
+// jpegWithSegments returns a 16x8 jpeg image with the segments right after its start.
+func jpegWithSegments(t *testing.T, segments ...[]byte) []byte {
+	var data bytes.Buffer
+	require.NoError(t, jpeg.Encode(&data, image.NewGray(image.Rect(0, 0, 16, 8)), nil))
+	out := append([]byte{}, data.Bytes()[:2]...)
+	for _, segment := range segments {
+		out = append(out, segment...)
+	}
+	return append(out, data.Bytes()[2:]...)
+}
+
+// orientationExif is the EXIF kept in a stored image whose orientation is 6, i.e. rotated 90° clockwise.
+var orientationExif = []byte("\xff\xe1\x00\x22Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+
+// expectCreateSighting expects the sighting of the tiger, stored after its images, to be created as the latest position of the tiger.
+func (s *SightingTestSuite) expectCreateSighting(ctx context.Context, tiger *entity.Tiger, sighting *entity.Sighting) {
+	s.sightingRepo.EXPECT().WithTransaction(ctx, gomock.Any()).DoAndReturn(runInTransaction)
+	s.sightingRepo.EXPECT().GetTigerByIDForUpdate(ctx, tiger.ID).Return(tiger, nil)
+	s.sightingRepo.EXPECT().GetDistanceFromLastSeen(ctx, tiger.ID, gomock.Any(), gomock.Any()).Return(float64(0), nil)
+	s.validator.EXPECT().Validate(ctx, tiger, sighting, gomock.Any()).Return(nil)
+	s.sightingRepo.EXPECT().CreateSighting(ctx, sighting).Return(nil)
+	s.sightingRepo.EXPECT().GetPriorReporters(ctx, tiger.ID, "").Return(nil, nil)
+	s.sightingRepo.EXPECT().CreateOutboxEvent(ctx, outboxEvent(entity.EventSightingRecorded, tiger.ID)).Return(nil)
+	s.sightingRepo.EXPECT().UpdateTiger(ctx, tiger).Return(nil)
+	s.redisRepo.EXPECT().Incr(ctx, service.GetTigersVersionKey).Return(int64(1), nil)
+	s.redisRepo.EXPECT().Incr(ctx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tiger.ID)).Return(int64(1), nil)
+	s.redisRepo.EXPECT().Del(ctx, fmt.Sprintf(service.GetTigerByIDKey, tiger.ID)).Return(nil)
+	s.redisRepo.EXPECT().Publish(ctx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+}
+
+func TestCreateSighting_Exif(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	// the capture time in Jakarta, without the nanoseconds EXIF can't hold
+	capturedAt := time.Now().Truncate(time.Second).In(time.FixedZone("WIB", 7*60*60))
+	currentTiger := func() *entity.Tiger {
+		return &entity.Tiger{ID: tigerID, Name: "tiger-1", DateOfBirth: time.Now(), LastSeenTimestamp: capturedAt.Add(-time.Hour),
+			LastSeenLatitude: -6.18, LastSeenLongitude: 106.8}
+	}
+	// -6°10'48" S 106°49'12" E, i.e. -6.18, 106.82
+	gpsLocation := []tiffEntry{
+		asciiEntry(0x0001, "S"), rationalEntry(0x0002, 6, 10, 48),
+		asciiEntry(0x0003, "E"), rationalEntry(0x0004, 106, 49, 12),
+	}
+	capturedExif := exifSegment(
+		[]tiffEntry{asciiEntry(0x010f, "Camera Trap Inc."), shortEntry(0x0112, 6)},
+		[]tiffEntry{asciiEntry(0x9003, capturedAt.Format("2006:01:02 15:04:05")), asciiEntry(0x9011, "+07:00")},
+		gpsLocation,
+	)
+	imageData := func(data []byte) string {
+		return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data)
+	}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "successfully insert to database a sighting seen when and where its image was captured",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: time.Unix(0, 0).UTC(), LocationOmitted: true,
+					ImageData: imageData(jpegWithSegments(t, capturedExif, xmpSegment))}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, capturedAt.Equal(sighting.SeenAt))
+				require.InDelta(t, -6.18, sighting.Latitude, 1e-6)
+				require.InDelta(t, 106.82, sighting.Longitude, 1e-6)
+				require.False(t, sighting.LocationOmitted)
+				require.False(t, sighting.NeedsReview)
+				require.True(t, capturedAt.Equal(tigerData.LastSeenTimestamp))
+				// the stored image keeps only the orientation of its EXIF, and no XMP
+				stored := images[sighting.ImageKey].Data
+				require.Equal(t, jpegWithSegments(t, orientationExif), stored)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting seen at the GPS time of its image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				utc := capturedAt.UTC()
+				// the original date time without offset is ignored
+				exif := exifSegment(nil,
+					[]tiffEntry{asciiEntry(0x9003, "2001:01:01 00:00:00")},
+					append([]tiffEntry{asciiEntry(0x001d, utc.Format("2006:01:02")),
+						rationalEntry(0x0007, float64(utc.Hour()), float64(utc.Minute()), float64(utc.Second()))}, gpsLocation...),
+				)
+				sighting := &entity.Sighting{TigerID: tigerID, Latitude: -6.18, Longitude: 106.82, ImageData: imageData(jpegWithSegments(t, exif))}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, capturedAt.Equal(sighting.SeenAt))
+				require.False(t, sighting.NeedsReview)
+				// an upright image keeps no EXIF at all
+				require.Equal(t, jpegWithSegments(t), images[sighting.ImageKey].Data)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting needing review when it disagrees with the seen time of its image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: capturedAt.Add(-exifTolerance.Time - time.Second),
+					Latitude: -6.181, Longitude: 106.821, ImageData: imageData(jpegWithSegments(t, capturedExif))}
+
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".jpeg")
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, sighting.NeedsReview)
+				require.True(t, capturedAt.Add(-exifTolerance.Time-time.Second).Equal(sighting.SeenAt))
+				require.Equal(t, -6.181, sighting.Latitude)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting needing review when it is far from the GPS location of its image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				// 0.01° of latitude is about 1.1 km
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: capturedAt.Add(-exifTolerance.Time + time.Second),
+					Latitude: -6.19, Longitude: 106.82, ImageData: imageData(jpegWithSegments(t, capturedExif))}
+
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".jpeg")
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting whose image EXIF is not valid",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				// the IFD0 offset points past the end of the EXIF
+				data := jpegWithSegments(t, []byte("\xff\xe1\x00\x10Exif\x00\x00MM\x00\x2a\x00\x00\x01\x00"))
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: capturedAt, Latitude: -6.19, Longitude: 106.82, ImageData: imageData(data)}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.False(t, sighting.NeedsReview)
+				// the EXIF is stripped even though it can't be read
+				require.Equal(t, jpegWithSegments(t), images[sighting.ImageKey].Data)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting whose image has an XMP, IPTC and comment but no EXIF",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				iptc := []byte("\xff\xed\x00\x12Photoshop 3.0\x00\x38\x42")
+				comment := []byte("\xff\xfe\x00\x0b-6.18,106")
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: capturedAt, Latitude: -6.18, Longitude: 106.82,
+					ImageData: imageData(jpegWithSegments(t, xmpSegment, iptc, comment))}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.Equal(t, jpegWithSegments(t), images[sighting.ImageKey].Data)
+			},
+		},
+		{
+			testcaseName: "Error sighting whose image metadata can't be removed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				// the XMP segment is longer than the image
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: capturedAt, Latitude: -6.18, Longitude: 106.82,
+					ImageData: imageData([]byte("\xff\xd8\xff\xe1\x10\x00http://ns.adobe.com/xap/1.0/\x00"))}
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image metadata can't be removed: jpeg image is truncated")
+			},
+		},
+		{
+			testcaseName: "Error sighting without seen time nor location whose image has no EXIF",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sighting := &entity.Sighting{TigerID: tigerID, LocationOmitted: true, ImageData: imageData(jpegWithSegments(t))}
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "seen_at is required unless the image has a capture time")
+				require.Contains(t, resErr.Error(), "latitude and longitude are required unless the image has a GPS location")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+// meanDifference returns the mean difference of the color channels of two images of the same size, from 0 to 255.
+func meanDifference(a, b image.Image) float64 {
+	var sum float64
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			ar, ag, ab, _ := a.At(x, y).RGBA()
+			br, bg, bb, _ := b.At(x, y).RGBA()
+			sum += math.Abs(float64(ar)-float64(br)) + math.Abs(float64(ag)-float64(bg)) + math.Abs(float64(ab)-float64(bb))
+		}
+	}
+	return sum / float64(3*bounds.Dx()*bounds.Dy()) / 257
+}
+
+func TestCreateSighting_Orientation(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	// testdata/orientation/upright.png is the golden image of 3x2 colored blocks, which each orientation_N.jpeg stores
+	// mirrored and/or rotated the way a camera does for the EXIF orientation N.
+	golden, err := os.ReadFile(filepath.Join("testdata", "orientation", "upright.png"))
+	require.NoError(t, err)
+	upright, err := png.Decode(bytes.NewReader(golden))
+	require.NoError(t, err)
+
+	testCases := []ServiceTestCase{}
+	for orientation := 1; orientation <= 8; orientation++ {
+		name := fmt.Sprintf("orientation_%d.jpeg", orientation)
+		testCases = append(testCases, ServiceTestCase{
+			testcaseName: fmt.Sprintf("successfully turn the renditions of an image with orientation %d upright", orientation),
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				data, err := os.ReadFile(filepath.Join("testdata", "orientation", name))
+				require.NoError(t, err)
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := &entity.Tiger{ID: tigerID, Name: "tiger-1", DateOfBirth: time.Now(), LastSeenTimestamp: time.Now().Add(-time.Hour)}
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: time.Now().Add(-time.Minute), Latitude: -6.18, Longitude: 106.82,
+					ImageData: "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data)}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				var large *imagestore.Image
+				for key, value := range images {
+					if strings.HasSuffix(key, "_"+service.ImageRenditionLarge+".jpeg") {
+						large = value
+					}
+				}
+				require.NotNil(t, large)
+				rendition, err := jpeg.Decode(bytes.NewReader(large.Data))
+				require.NoError(t, err)
+				require.Equal(t, upright.Bounds(), rendition.Bounds())
+				// renditions are lossy jpeg, while a wrongly turned image differs by far more
+				require.Less(t, meanDifference(upright, rendition), 4.0)
+			},
+		})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -2,17 +2,34 @@ package service
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"database/sql"
 	"encoding/base64"
-	"errors"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register gif decoder for image.Decode
 	"image/jpeg"
 	"image/png"
+	"math"
+	"math/bits"
+	"net"
+	"net/mail"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/nfnt/resize"
+	_ "golang.org/x/image/webp" // register webp decoder for image.Decode
 
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/webhook"
 )
 
 func validateTime(in time.Time) bool {
@@ -20,90 +37,568 @@ func validateTime(in time.Time) bool {
 }
 
 func isValidTiger(tiger *entity.Tiger) error {
+	var violations []apperrors.FieldViolation
 	if tiger.Name == "" {
-		return errors.New("name cannot be empty")
+		violations = append(violations, apperrors.FieldViolation{Field: "name", Description: "name cannot be empty"})
 	}
 	if validateTime(tiger.DateOfBirth) {
-		return errors.New("time cannot be zero")
+		violations = append(violations, apperrors.FieldViolation{Field: "date_of_birth", Description: "time cannot be zero"})
 	}
 	if validateTime(tiger.LastSeenTimestamp) {
-		return errors.New("last seen time cannot be null")
+		violations = append(violations, apperrors.FieldViolation{Field: "last_seen_timestamp", Description: "last seen time cannot be null"})
 	}
 	if tiger.LastSeenLatitude < -90.0 || tiger.LastSeenLatitude > 90.0 {
-		return errors.New("not a valid latitude")
+		violations = append(violations, apperrors.FieldViolation{Field: "last_seen_latitude", Description: "not a valid latitude"})
 	}
 	if tiger.LastSeenLongitude < -180.0 || tiger.LastSeenLongitude > 180.0 {
-		return errors.New("not a valid longitude")
+		violations = append(violations, apperrors.FieldViolation{Field: "last_seen_longitude", Description: "not a valid longitude"})
 	}
-	return nil
+	if tiger.MaxSightingDistance.Valid && tiger.MaxSightingDistance.Float64 <= 0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "max_sighting_distance", Description: "max sighting distance must be greater than 0"})
+	}
+	return apperrors.NewValidationError(violations...)
+}
+
+// tigerUpdatablePaths lists proto field names of tiger that can be changed by UpdateTiger.
+var tigerUpdatablePaths = []string{"name", "date_of_birth", "max_sighting_distance"}
+
+// tigerDefaultUpdatePaths lists proto field names of tiger changed by UpdateTiger if no path is given.
+// max_sighting_distance must be listed explicitly so that it isn't cleared by accident.
+var tigerDefaultUpdatePaths = []string{"name", "date_of_birth"}
+
+// defaultTigerUpdatePaths returns tigerDefaultUpdatePaths but date_of_birth if the tiger has none, so that an omitted
+// date of birth is kept rather than cleared.
+func defaultTigerUpdatePaths(tiger *entity.Tiger) []string {
+	var paths []string
+	for _, path := range tigerDefaultUpdatePaths {
+		if path != "date_of_birth" || !tiger.DateOfBirth.IsZero() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func isValidTigerUpdatePaths(paths []string) error {
+	var violations []apperrors.FieldViolation
+	for _, path := range paths {
+		valid := false
+		for _, updatable := range tigerUpdatablePaths {
+			if path == updatable {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			violations = append(violations, apperrors.FieldViolation{Field: "update_mask", Description: fmt.Sprintf("%s is not an updatable field", path)})
+		}
+	}
+	return apperrors.NewValidationError(violations...)
+}
+
+func applyTigerUpdate(dst, src *entity.Tiger, paths []string) {
+	for _, path := range paths {
+		switch path {
+		case "name":
+			dst.Name = src.Name
+		case "date_of_birth":
+			dst.DateOfBirth = src.DateOfBirth
+		case "max_sighting_distance":
+			dst.MaxSightingDistance = src.MaxSightingDistance
+		}
+	}
+}
+
+func isValidTigerFilter(filter *entity.TigerFilter, pageSize int32) error {
+	var violations []apperrors.FieldViolation
+	if pageSize < 0 || pageSize > MaxTigersPageSize {
+		violations = append(violations, apperrors.FieldViolation{Field: "page_size", Description: fmt.Sprintf("page size must be between 0 and %d", MaxTigersPageSize)})
+	}
+	if filter == nil {
+		return apperrors.NewValidationError(violations...)
+	}
+	if !filter.LastSeenAfter.IsZero() && !filter.LastSeenBefore.IsZero() && !filter.LastSeenAfter.Before(filter.LastSeenBefore) {
+		violations = append(violations, apperrors.FieldViolation{Field: "last_seen_before", Description: "last_seen_before must be after last_seen_after"})
+	}
+	violations = append(violations, boundingBoxViolations(filter.BoundingBox)...)
+	return apperrors.NewValidationError(violations...)
+}
+
+// boundingBoxViolations validates the bounding box, if any.
+func boundingBoxViolations(box *entity.BoundingBox) []apperrors.FieldViolation {
+	if box == nil {
+		return nil
+	}
+	var violations []apperrors.FieldViolation
+	if box.MinLatitude < -90.0 || box.MaxLatitude > 90.0 || box.MinLatitude > box.MaxLatitude {
+		violations = append(violations, apperrors.FieldViolation{Field: "bounding_box", Description: "not a valid latitude range"})
+	}
+	if box.MinLongitude < -180.0 || box.MinLongitude > 180.0 || box.MaxLongitude < -180.0 || box.MaxLongitude > 180.0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "bounding_box", Description: "not a valid longitude range"})
+	}
+	return violations
+}
+
+func isValidWebhookSubscription(subscription *entity.WebhookSubscription, allowPrivateNetworks bool) error {
+	var violations []apperrors.FieldViolation
+	if u, err := url.Parse(subscription.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		violations = append(violations, apperrors.FieldViolation{Field: "url", Description: "not a valid http or https URL"})
+	} else if !allowPrivateNetworks && isPrivateHost(u.Hostname()) {
+		violations = append(violations, apperrors.FieldViolation{Field: "url", Description: "host must not be a loopback, private or link-local address"})
+	}
+	if subscription.TigerID.Valid && subscription.TigerID.Int32 <= 0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "tiger_id", Description: "tiger id must be greater than 0"})
+	}
+	violations = append(violations, boundingBoxViolations(subscription.BoundingBox)...)
+	return apperrors.NewValidationError(violations...)
+}
+
+// isPrivateHost reports whether host is localhost or an IP address deliveries may not connect to.
+// Hosts resolving to such addresses are refused by the deliverer when it connects.
+func isPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	// drop the zone of IPv6 link-local addresses, e.g. fe80::1%eth0
+	if i := strings.LastIndexByte(host, '%'); i >= 0 {
+		host = host[:i]
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && !webhook.IsPublicIP(ip)
+}
+
+func isValidSightingWatchFilter(filter *entity.SightingWatchFilter) error {
+	var violations []apperrors.FieldViolation
+	if len(filter.TigerIDs) > MaxWatchedTigers {
+		violations = append(violations, apperrors.FieldViolation{Field: "tiger_ids", Description: fmt.Sprintf("at most %d tiger ids", MaxWatchedTigers)})
+	}
+	for _, id := range filter.TigerIDs {
+		if id <= 0 {
+			violations = append(violations, apperrors.FieldViolation{Field: "tiger_ids", Description: "tiger id must be greater than 0"})
+			break
+		}
+	}
+	violations = append(violations, boundingBoxViolations(filter.BoundingBox)...)
+	return apperrors.NewValidationError(violations...)
+}
+
+func isValidNearbyQuery(query *entity.NearbyQuery) error {
+	var violations []apperrors.FieldViolation
+	if query.Latitude < -90.0 || query.Latitude > 90.0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "lat", Description: "not a valid latitude"})
+	}
+	if query.Longitude < -180.0 || query.Longitude > 180.0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "lng", Description: "not a valid longitude"})
+	}
+	if query.RadiusKm <= 0 || query.RadiusKm > MaxNearbyRadiusKm {
+		violations = append(violations, apperrors.FieldViolation{Field: "radius_km", Description: fmt.Sprintf("radius must be greater than 0 and at most %d", MaxNearbyRadiusKm)})
+	}
+	if query.Limit < 0 || query.Limit > MaxNearbyTigersLimit {
+		violations = append(violations, apperrors.FieldViolation{Field: "limit", Description: fmt.Sprintf("limit must be between 0 and %d", MaxNearbyTigersLimit)})
+	}
+	return apperrors.NewValidationError(violations...)
+}
+
+// isValidSimilarImageQuery validates the query of sightings whose image is similar to an image.
+func isValidSimilarImageQuery(query *entity.SimilarImageQuery) error {
+	var violations []apperrors.FieldViolation
+	if query.ImageData == "" {
+		violations = append(violations, apperrors.FieldViolation{Field: "image_data", Description: "image data should contain valid base64 image format"})
+	}
+	if query.MaxDistance.Valid && (query.MaxDistance.Int32 < 0 || query.MaxDistance.Int32 > MaxSimilarImageDistance) {
+		violations = append(violations, apperrors.FieldViolation{Field: "max_distance", Description: fmt.Sprintf("max distance must be between 0 and %d", MaxSimilarImageDistance)})
+	}
+	if query.Limit < 0 || query.Limit > MaxSimilarImagesLimit {
+		violations = append(violations, apperrors.FieldViolation{Field: "limit", Description: fmt.Sprintf("limit must be between 0 and %d", MaxSimilarImagesLimit)})
+	}
+	return apperrors.NewValidationError(violations...)
 }
 
-func isValidSighting(sighting *entity.Sighting) error {
+// roundNearbyQuery returns a copy of query with the point rounded to the center of its cell, since truncated to minute
+// and default limit applied, so that similar queries share the cache of their candidates.
+func roundNearbyQuery(query *entity.NearbyQuery) *entity.NearbyQuery {
+	cell := *query
+	cell.Latitude = math.Round(query.Latitude/NearbyCellSize) * NearbyCellSize
+	cell.Longitude = math.Round(query.Longitude/NearbyCellSize) * NearbyCellSize
+	if !query.Since.IsZero() {
+		cell.Since = query.Since.Truncate(time.Minute)
+	}
+	if cell.Limit == 0 {
+		cell.Limit = DefaultNearbyTigersLimit
+	}
+	return &cell
+}
+
+// nearbyTigers returns at most limit candidates last seen within the radius of the searched point and at or after since,
+// with their distance from the point, nearest first. Candidates are the tigers nearest to the center of the cell of the point,
+// it reports false if they were cut at candidatesLimit before a tiger which could be among the nearest of the point.
+func nearbyTigers(query *entity.NearbyQuery, limit int32, candidates []*entity.NearbyTiger, candidatesLimit int32) ([]*entity.NearbyTiger, bool) {
+	radius := query.RadiusKm * 1000
+	tigers := make([]*entity.NearbyTiger, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.LastSeenTimestamp.Before(query.Since) {
+			continue
+		}
+		tiger := *candidate
+		tiger.Distance = geodesicDistance(query.Latitude, query.Longitude, tiger.LastSeenLatitude, tiger.LastSeenLongitude)
+		if tiger.Distance <= radius {
+			tigers = append(tigers, &tiger)
+		}
+	}
+	sort.SliceStable(tigers, func(i, j int) bool {
+		if tigers[i].Distance != tigers[j].Distance {
+			return tigers[i].Distance < tigers[j].Distance
+		}
+		return tigers[i].ID < tigers[j].ID
+	})
+	if len(tigers) > int(limit) {
+		tigers = tigers[:limit]
+	}
+
+	if len(candidates) < int(candidatesLimit) {
+		return tigers, true
+	}
+	// tigers which are not candidates are at least as far from the center as the last candidate,
+	// so at least that distance minus the margin from the point
+	bound := candidates[len(candidates)-1].Distance - nearbyCellMargin
+	if radius < bound {
+		return tigers, true
+	}
+	return tigers, len(tigers) == int(limit) && tigers[len(tigers)-1].Distance < bound
+}
+
+func isValidSightingFilter(filter *entity.SightingFilter, pageSize int32) error {
+	var violations []apperrors.FieldViolation
+	if pageSize < 0 || pageSize > MaxSightingsPageSize {
+		violations = append(violations, apperrors.FieldViolation{Field: "page_size", Description: fmt.Sprintf("page size must be between 0 and %d", MaxSightingsPageSize)})
+	}
+	if filter != nil && !filter.SeenFrom.IsZero() && !filter.SeenTo.IsZero() && !filter.SeenFrom.Before(filter.SeenTo) {
+		violations = append(violations, apperrors.FieldViolation{Field: "seen_to", Description: "seen_to must be after seen_from"})
+	}
+	return apperrors.NewValidationError(violations...)
+}
+
+// encodePageToken encodes cursor into an opaque page token.
+func encodePageToken(cursor interface{}) string {
+	b, _ := json.Marshal(cursor) // error is impossible, hence ignored.
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken decodes page token created by encodePageToken into cursor.
+// It returns false if token is empty.
+func decodePageToken(token string, cursor interface{}) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	invalid := apperrors.NewValidationError(apperrors.FieldViolation{Field: "page_token", Description: "not a valid page token"})
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return false, invalid
+	}
+	if err = json.Unmarshal(b, cursor); err != nil {
+		return false, invalid
+	}
+	return true, nil
+}
+
+func decodeTigerPageToken(token string) (*entity.TigerCursor, error) {
+	var cursor entity.TigerCursor
+	ok, err := decodePageToken(token, &cursor)
+	if !ok || err != nil {
+		return nil, err
+	}
+	if cursor.ID == 0 {
+		return nil, apperrors.NewValidationError(apperrors.FieldViolation{Field: "page_token", Description: "not a valid page token"})
+	}
+	return &cursor, nil
+}
+
+func decodeSightingPageToken(token string) (*entity.SightingCursor, error) {
+	var cursor entity.SightingCursor
+	ok, err := decodePageToken(token, &cursor)
+	if !ok || err != nil {
+		return nil, err
+	}
+	if cursor.ID == 0 {
+		return nil, apperrors.NewValidationError(apperrors.FieldViolation{Field: "page_token", Description: "not a valid page token"})
+	}
+	return &cursor, nil
+}
+
+// pageHash identifies a page of a list for caching.
+func pageHash(filter interface{}, pageSize int32, pageToken string) string {
+	b, _ := json.Marshal(struct { // error is impossible, hence ignored.
+		Filter    interface{}
+		PageSize  int32
+		PageToken string
+	}{filter, pageSize, pageToken})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// isValidSighting validates the sighting and normalizes its reporter email. seen_at must not be after latest.
+func isValidSighting(sighting *entity.Sighting, latest time.Time) error {
+	var violations []apperrors.FieldViolation
 	if sighting.TigerID == 0 {
-		return errors.New("tiger id cannot be 0")
+		violations = append(violations, apperrors.FieldViolation{Field: "id", Description: "tiger id cannot be 0"})
 	}
 	if validateTime(sighting.SeenAt) {
-		return errors.New("seen_at cannot be zero")
+		violations = append(violations, apperrors.FieldViolation{Field: "seen_at", Description: "seen_at is required unless the image has a capture time"})
+	} else if sighting.SeenAt.After(latest) {
+		violations = append(violations, apperrors.FieldViolation{Field: "seen_at", Description: "seen_at cannot be in the future"})
 	}
-	if sighting.Latitude < -90.0 || sighting.Latitude > 90.0 {
-		return errors.New("not a valid latitude")
+	if sighting.LocationOmitted {
+		violations = append(violations, apperrors.FieldViolation{Field: "latitude", Description: "latitude and longitude are required unless the image has a GPS location"})
+	} else if sighting.Latitude < -90.0 || sighting.Latitude > 90.0 {
+		violations = append(violations, apperrors.FieldViolation{Field: "latitude", Description: "not a valid latitude"})
 	}
 	if sighting.Longitude < -180.0 || sighting.Longitude > 180.0 {
-		return errors.New("not a valid longitude")
+		violations = append(violations, apperrors.FieldViolation{Field: "longitude", Description: "not a valid longitude"})
 	}
 	if sighting.ImageData == "" {
-		return errors.New("image data should contain valid base64 image format")
+		violations = append(violations, apperrors.FieldViolation{Field: "image_data", Description: "image data should contain valid base64 image format"})
+	}
+	if sighting.ReporterEmail != "" {
+		// only the address is kept, lowercase, so that a reporter is matched whatever the display name or case
+		if addr, err := mail.ParseAddress(sighting.ReporterEmail); err != nil {
+			violations = append(violations, apperrors.FieldViolation{Field: "reporter_email", Description: "not a valid email address"})
+		} else {
+			sighting.ReporterEmail = strings.ToLower(addr.Address)
+		}
 	}
-	return nil
+	return apperrors.NewValidationError(violations...)
 }
 
-func resizeBase64Image(in string) (resizedImageBase64 string, err error) {
-	const (
-		jpegPrefix = "data:image/jpeg;base64,"
-		pngPrefix  = "data:image/png;base64,"
-	)
-	coI := strings.Index(in, ",")
-	var base64Image string
-	if strings.HasPrefix(in, pngPrefix) || strings.HasPrefix(in, jpegPrefix) {
-		base64Image = in[coI+1:]
-	} else { // case no prefix
-		base64Image = in
+// movementFrom returns the move of a tiger between the waypoint and a sighting seen at seenAt.
+// Speed is null if the tiger moved and seenAt equals the seen time of the waypoint.
+func movementFrom(waypoint *entity.Waypoint, seenAt time.Time) *entity.Movement {
+	movement := &entity.Movement{Distance: waypoint.Distance}
+	elapsed := seenAt.Sub(waypoint.SeenAt)
+	if elapsed < 0 {
+		elapsed = -elapsed
+	}
+	if elapsed == 0 {
+		movement.Speed = sql.NullFloat64{Valid: waypoint.Distance == 0}
+		return movement
 	}
+	movement.Speed = sql.NullFloat64{Float64: waypoint.Distance / 1000 / elapsed.Hours(), Valid: true}
+	return movement
+}
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
 
-	unbased, err := base64.StdEncoding.DecodeString(base64Image)
+// greatCircleDistance returns the distance in meters between two points on a spherical earth, using the haversine formula.
+// It differs by less than 0.5% from the distance on the spheroid computed by the database.
+func greatCircleDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := math.Pi / 180
+	dLat := (lat2 - lat1) * toRadians
+	dLng := (lng2 - lng1) * toRadians
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*toRadians)*math.Cos(lat2*toRadians)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(math.Min(h, 1)))
+}
+
+// WGS 84 spheroid, the one of the geography type of the database
+const (
+	spheroidSemiMajorAxis = 6378137.0
+	spheroidFlattening    = 1 / 298.257223563
+	spheroidSemiMinorAxis = spheroidSemiMajorAxis * (1 - spheroidFlattening)
+)
+
+// geodesicDistance returns the distance in meters between two points on the WGS 84 spheroid using the inverse formula
+// of Vincenty, which agrees with the distance computed by the database to less than a millimeter.
+// It falls back to greatCircleDistance for nearly antipodal points, for which the formula doesn't converge.
+func geodesicDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := math.Pi / 180
+	l := (lng2 - lng1) * toRadians
+	u1 := math.Atan((1 - spheroidFlattening) * math.Tan(lat1*toRadians))
+	u2 := math.Atan((1 - spheroidFlattening) * math.Tan(lat2*toRadians))
+	sinU1, cosU1 := math.Sin(u1), math.Cos(u1)
+	sinU2, cosU2 := math.Sin(u2), math.Cos(u2)
+
+	lambda := l
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sin(lambda), math.Cos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+		// both points on the equator
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := spheroidFlattening / 16 * cosSqAlpha * (4 + spheroidFlattening*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*spheroidFlattening*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) > 1e-12 {
+			continue
+		}
+
+		uSq := cosSqAlpha * (spheroidSemiMajorAxis*spheroidSemiMajorAxis - spheroidSemiMinorAxis*spheroidSemiMinorAxis) /
+			(spheroidSemiMinorAxis * spheroidSemiMinorAxis)
+		a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+		b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+		deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		return spheroidSemiMinorAxis * a * (sigma - deltaSigma)
+	}
+	return greatCircleDistance(lat1, lng1, lat2, lng2)
+}
+
+// decodeImage decodes the original image within the limits, turned upright by the orientation of its EXIF
+// since renditions have no EXIF. An EXIF which can't be read is ignored.
+func decodeImage(original *imagestore.Image, limits ImageLimits) (image.Image, error) {
+	// check the dimensions in the header before decoding the pixels
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(original.Data))
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("image can't be decoded: %w", err)
+	}
+	if cfg.Width > limits.MaxWidth || cfg.Height > limits.MaxHeight {
+		return nil, fmt.Errorf("image is %dx%d pixels, larger than %dx%d", cfg.Width, cfg.Height, limits.MaxWidth, limits.MaxHeight)
+	}
+	// gif images are decoded to their first frame
+	im, _, err := image.Decode(bytes.NewReader(original.Data))
+	if err != nil {
+		return nil, fmt.Errorf("image can't be decoded: %w", err)
+	}
+	if original.ContentType == "image/jpeg" {
+		if exif, _ := readJPEGExif(original.Data); exif != nil {
+			im = orientImage(im, exif.Orientation)
+		}
 	}
+	return im, nil
+}
 
-	r := bytes.NewReader(unbased)
-	var im image.Image
-	if strings.HasPrefix(in, jpegPrefix) { // case jpeg
-		im, err = jpeg.Decode(r)
-		if err != nil {
-			return "", err
+// renderImage returns the renditions of the decoded image whose original media type is contentType,
+// in the order of the given renditions. Their media type is given by renditionContentType.
+func renderImage(im image.Image, contentType string, renditions []ImageRendition) (rendered []*imagestore.Image, err error) {
+	for _, rendition := range renditions {
+		var scaled image.Image
+		if rendition.Fill {
+			scaled = fillImage(im, rendition.Width, rendition.Height)
+		} else {
+			// Thumbnail keeps the aspect ratio and returns images already fitting as is
+			scaled = resize.Thumbnail(rendition.Width, rendition.Height, im, resize.Lanczos3)
 		}
-	} else { // default case we treat as png
-		im, err = png.Decode(r)
+		encoded, err := encodeImage(scaled, renditionContentType(contentType))
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		rendered = append(rendered, encoded)
+	}
+	return rendered, nil
+}
+
+// imageHash returns the difference hash (dHash) of the image, a perceptual hash of 64 bits.
+// The image is scaled down to 9x8 gray pixels, and each bit tells whether a pixel is brighter than its right neighbour.
+// Hashes of the same picture differ by a few bits at most, even if it was scaled, re-encoded or slightly edited.
+func imageHash(im image.Image) uint64 {
+	small := resize.Resize(9, 8, im, resize.Bilinear)
+	b := small.Bounds()
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			left := color.GrayModel.Convert(small.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y
+			right := color.GrayModel.Convert(small.At(b.Min.X+x+1, b.Min.Y+y)).(color.Gray).Y
+			hash <<= 1
+			if left > right {
+				hash |= 1
+			}
 		}
 	}
+	return hash
+}
+
+// isPlainImageHash tells whether the image hash has too few bits set, or too few unset, for the image to be compared,
+// e.g. the hash of a blank or evenly shaded image. Such hashes are close whatever the picture, so they would all be duplicates.
+func isPlainImageHash(hash uint64) bool {
+	n := bits.OnesCount64(hash)
+	return n < minImageHashBits || n > 64-minImageHashBits
+}
+
+// orientImage returns the image turned upright according to its EXIF orientation, from 1 to 8.
+// Orientations 2 to 8 mean the camera stored the upright image mirrored and/or rotated, e.g. 6 means rotated 90° counterclockwise.
+func orientImage(im image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return im
+	}
+	b := im.Bounds()
+	w, h := b.Dx(), b.Dy()
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), im, b.Min, draw.Src)
+
+	// orientations from 5 swap the width and the height
+	dstWidth, dstHeight := w, h
+	if orientation >= 5 {
+		dstWidth, dstHeight = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed, i.e. mirrored along the top-left to bottom-right diagonal
+				dx, dy = y, x
+			case 6: // rotated 90° counterclockwise, turned 90° clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed, i.e. mirrored along the top-right to bottom-left diagonal
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 90° clockwise, turned 90° counterclockwise
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	return dst
+}
+
+// fillImage crops the center of the image to the aspect ratio of width x height, and scales it down to width x height.
+// An image smaller than width x height is only cropped.
+func fillImage(im image.Image, width, height uint) image.Image {
+	b := im.Bounds()
+	// the largest rectangle with the aspect ratio of the rendition fitting in the image
+	cropWidth, cropHeight := b.Dx(), b.Dx()*int(height)/int(width)
+	if cropHeight > b.Dy() {
+		cropWidth, cropHeight = b.Dy()*int(width)/int(height), b.Dy()
+	}
+	if cropWidth < 1 {
+		cropWidth = 1
+	}
+	if cropHeight < 1 {
+		cropHeight = 1
+	}
 
-	resizedImage := resize.Resize(250, 200, im, resize.Lanczos3)
-	var resizedImageBuf bytes.Buffer
+	cropped := image.NewNRGBA(image.Rect(0, 0, cropWidth, cropHeight))
+	origin := b.Min.Add(image.Pt((b.Dx()-cropWidth)/2, (b.Dy()-cropHeight)/2))
+	draw.Draw(cropped, cropped.Bounds(), im, origin, draw.Src)
+	if cropWidth <= int(width) && cropHeight <= int(height) {
+		return cropped
+	}
+	return resize.Resize(width, height, cropped, resize.Lanczos3)
+}
 
-	if strings.HasPrefix(in, jpegPrefix) { // case jpeg
-		if err = jpeg.Encode(&resizedImageBuf, resizedImage, &jpeg.Options{Quality: 80}); err != nil {
-			return "", err
+// encodeImage encodes the image as jpeg or png, depending on the media type.
+func encodeImage(im image.Image, contentType string) (*imagestore.Image, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" { // case jpeg
+		if err := jpeg.Encode(&buf, im, &jpeg.Options{Quality: 80}); err != nil {
+			return nil, err
 		}
-		resizedImageBase64 = jpegPrefix
-		resizedImageBase64 += base64.StdEncoding.EncodeToString(resizedImageBuf.Bytes())
 	} else { // default case we treat as png
-		if err = png.Encode(&resizedImageBuf, resizedImage); err != nil {
-			return "", err
+		if err := png.Encode(&buf, im); err != nil {
+			return nil, err
 		}
-		resizedImageBase64 = pngPrefix
-		resizedImageBase64 += base64.StdEncoding.EncodeToString(resizedImageBuf.Bytes())
 	}
-	return resizedImageBase64, nil
+	return &imagestore.Image{Data: buf.Bytes(), ContentType: contentType}, nil
 }
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+const (
+	// SightingImageKey is the image store key of a sighting image, formatted with tiger ID, a name unique for the tiger
+	// and the extension of the image
+	SightingImageKey = "sighting/%d/%s%s"
+	// DefaultImageMigrationBatchSize is the number of images MoveImagesToStore reads at once if batch size is not specified
+	DefaultImageMigrationBatchSize = 100
+	// ImageRenditionThumbnail is the smallest rendition of sighting images, to list them
+	ImageRenditionThumbnail = "thumbnail"
+	// ImageRenditionMedium is the rendition of sighting images returned as image data of sightings
+	ImageRenditionMedium = "medium"
+	// ImageRenditionLarge is the largest rendition of sighting images, to view one of them
+	ImageRenditionLarge = "large"
+)
+
+// ImageLimits bounds the images of new sightings, so that decoding one can't exhaust memory.
+type ImageLimits struct {
+	// MaxBytes is the maximum size of an image, once base64 decoded
+	MaxBytes int
+	// MaxWidth and MaxHeight are the maximum dimensions of an image, read before it is decoded
+	MaxWidth  int
+	MaxHeight int
+}
+
+// ImageRendition is a size sighting images are scaled down to, the rendition is stored next to the original image.
+type ImageRendition struct {
+	// Name is one of ImageRenditionThumbnail, ImageRenditionMedium and ImageRenditionLarge
+	Name   string
+	Width  uint
+	Height uint
+	// Fill crops the center of the image to the aspect ratio of the rendition, otherwise the whole image fits in it
+	Fill bool
+}
+
+// DuplicateImages decides what happens to a new sighting whose image is a near-duplicate of the image of another sighting,
+// e.g. the same photo submitted twice, or the same camera trap frame submitted for two tigers.
+type DuplicateImages struct {
+	// MaxDistance is the number of bits, out of 64, by which the perceptual hashes of near-duplicate images may differ,
+	// at most MaxSimilarImageDistance
+	MaxDistance int32
+	// Review accepts the sighting and flags it as needs review, otherwise it is rejected
+	Review bool
+}
+
+// SightingImage defines the interface to read the images of sightings.
+type SightingImage interface {
+	// OpenSightingImage returns a reader of the image rendition of the sighting of given tiger ID.
+	// Empty rendition means the original image.
+	// It returns entity.ErrSightingNotFound if there is no such sighting and entity.ErrSightingImageNotFound if it has no image.
+	OpenSightingImage(ctx context.Context, tigerID, sightingID int32, rendition string) (*imagestore.ImageReader, error)
+}
+
+// SightingImageRepository defines the interface to the sightings whose images are read.
+type SightingImageRepository interface {
+	// GetSightingByID get sighting by ID of given tiger ID from database
+	GetSightingByID(ctx context.Context, tigerID, sightingID int32) (*entity.Sighting, error)
+}
+
+// SightingImageService is responsible for hold dependencies related to sighting image service.
+type SightingImageService struct {
+	repo       SightingImageRepository
+	imageStore imagestore.ImageStore
+}
+
+// NewSightingImageService creates an instance of SightingImageService.
+func NewSightingImageService(repo SightingImageRepository, imageStore imagestore.ImageStore) *SightingImageService {
+	return &SightingImageService{repo: repo, imageStore: imageStore}
+}
+
+// OpenSightingImage returns a reader of the image rendition of the sighting of given tiger ID
+// Sightings whose image was stored in database have no renditions, the image itself is returned instead.
+func (s *SightingImageService) OpenSightingImage(ctx context.Context, tigerID, sightingID int32, rendition string) (*imagestore.ImageReader, error) {
+	logger := logging.NewServiceLogger(ctx, "OpenSightingImage", logrus.Fields{})
+
+	// validate input
+	if !isValidImageRendition(rendition) {
+		err := apperrors.NewValidationError(apperrors.FieldViolation{Field: "rendition", Description: "unknown image rendition " + rendition})
+		logging.WithError(err, logger).Warn("Error when get from validate image rendition")
+		return nil, err
+	}
+
+	sighting, err := s.repo.GetSightingByID(ctx, tigerID, sightingID)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetSightingByID")
+		return nil, err
+	}
+
+	// sightings recorded before the image store, whose image is not moved yet
+	if sighting.ImageKey == "" {
+		if sighting.ImageData == "" {
+			return nil, entity.ErrSightingImageNotFound
+		}
+		image, err := decodeImageData(sighting.ImageData, 0)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get from decodeImageData")
+			return nil, entity.ErrSightingImageNotFound
+		}
+		return imagestore.NewImageReader(image), nil
+	}
+
+	reader, err := s.imageStore.Open(ctx, imageRenditionKey(sighting.ImageKey, rendition))
+	if errors.Is(err, imagestore.ErrNotFound) && rendition != "" {
+		reader, err = s.imageStore.Open(ctx, sighting.ImageKey)
+	}
+	if errors.Is(err, imagestore.ErrNotFound) {
+		return nil, entity.ErrSightingImageNotFound
+	}
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from imageStore.Open")
+		return nil, err
+	}
+	return reader, nil
+}
+
+// ImageMigration defines the interface to move the images of sightings recorded before the image store out of database.
+type ImageMigration interface {
+	// MoveImagesToStore moves every sighting image stored in database to the image store, reading batchSize images at once,
+	// and returns the number of images moved. Images which can't be decoded are left in database and logged.
+	// It is safe to run again, e.g. after it was interrupted, and while new sightings are recorded.
+	MoveImagesToStore(ctx context.Context, batchSize int32) (moved int, err error)
+}
+
+// ImageMigrationRepository defines the interface to the sighting images stored in database.
+type ImageMigrationRepository interface {
+	// GetSightingsWithImageData get at most limit sightings whose image is stored in database and whose ID is greater
+	// than afterID, order by ID
+	GetSightingsWithImageData(ctx context.Context, afterID int32, limit int32) ([]*entity.Sighting, error)
+	// UpdateSightingImageKey set the image key of a sighting whose image is stored in database, and remove the image from database
+	UpdateSightingImageKey(ctx context.Context, sightingID int32, imageKey string) error
+}
+
+// ImageMigrationService is responsible for hold dependencies related to image migration service.
+type ImageMigrationService struct {
+	repo       ImageMigrationRepository
+	imageStore imagestore.ImageStore
+}
+
+// NewImageMigrationService creates an instance of ImageMigrationService.
+func NewImageMigrationService(repo ImageMigrationRepository, imageStore imagestore.ImageStore) *ImageMigrationService {
+	return &ImageMigrationService{repo: repo, imageStore: imageStore}
+}
+
+// MoveImagesToStore moves every sighting image stored in database to the image store and returns the number of images moved
+// An image is stored before its key is saved, so that an interrupted run never loses an image.
+func (m *ImageMigrationService) MoveImagesToStore(ctx context.Context, batchSize int32) (moved int, err error) {
+	logger := logging.NewServiceLogger(ctx, "MoveImagesToStore", logrus.Fields{})
+
+	if batchSize <= 0 {
+		batchSize = DefaultImageMigrationBatchSize
+	}
+
+	// images which can't be decoded stay in database, so the next batch starts after the last sighting read
+	var afterID int32
+	for {
+		sightings, err := m.repo.GetSightingsWithImageData(ctx, afterID, batchSize)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.GetSightingsWithImageData")
+			return moved, err
+		}
+
+		for _, sighting := range sightings {
+			afterID = sighting.ID
+			image, err := decodeImageData(sighting.ImageData, 0)
+			if err != nil {
+				logging.WithError(err, logger).WithField("sighting_id", sighting.ID).Warn("Image of sighting is left in database")
+				continue
+			}
+
+			// the name is the sighting ID, so that runs moving the same image concurrently store it under the same key
+			key := fmt.Sprintf(SightingImageKey, sighting.TigerID, strconv.Itoa(int(sighting.ID)), imageExtension(image.ContentType))
+			if err = m.imageStore.Put(ctx, key, image); err != nil {
+				logging.WithError(err, logger).Warn("Error when get from imageStore.Put")
+				return moved, err
+			}
+			if err = m.repo.UpdateSightingImageKey(ctx, sighting.ID, key); err != nil {
+				logging.WithError(err, logger).Warn("Error when get from repo.UpdateSightingImageKey")
+				return moved, err
+			}
+			moved++
+		}
+
+		if len(sightings) < int(batchSize) {
+			return moved, nil
+		}
+	}
+}
+
+// decodeImageData decodes an image sent as a base64 data URI, or as plain base64, of at most maxBytes bytes.
+// Zero maxBytes means no limit. The media type is detected from the magic bytes of the image,
+// the one of the data URI is ignored since clients often send a wrong one, e.g. image/jpg or image/png for any image.
+func decodeImageData(in string, maxBytes int) (*imagestore.Image, error) {
+	data := in
+	if strings.HasPrefix(in, "data:") {
+		comma := strings.Index(in, ",")
+		if comma < 0 {
+			return nil, errors.New("image data is not a valid data URI")
+		}
+		if !strings.HasSuffix(in[:comma], ";base64") {
+			return nil, errors.New("image data is not base64 encoded")
+		}
+		data = in[comma+1:]
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, errors.New("image data is not valid base64")
+	}
+	if maxBytes > 0 && len(decoded) > maxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", maxBytes)
+	}
+
+	contentType := http.DetectContentType(decoded)
+	if imageExtension(contentType) == "" {
+		return nil, errors.New("image format is not supported, only jpeg, png, gif and webp are")
+	}
+	return &imagestore.Image{Data: decoded, ContentType: contentType}, nil
+}
+
+// imageDataURI encodes an image as a base64 data URI.
+func imageDataURI(image *imagestore.Image) string {
+	return "data:" + image.ContentType + ";base64," + base64.StdEncoding.EncodeToString(image.Data)
+}
+
+// isValidImageRendition tells whether the rendition is a rendition name or empty, meaning the original image.
+func isValidImageRendition(rendition string) bool {
+	switch rendition {
+	case "", ImageRenditionThumbnail, ImageRenditionMedium, ImageRenditionLarge:
+		return true
+	default:
+		return false
+	}
+}
+
+// imageRenditionKey returns the image store key of the rendition of the image stored under the key,
+// e.g. sighting/1/a_thumbnail.png. Empty rendition means the original image.
+// The extension is the one of the media type of the renditions, see renditionContentType.
+func imageRenditionKey(key, rendition string) string {
+	if rendition == "" {
+		return key
+	}
+	ext := path.Ext(key)
+	renditionExt := imageExtension("image/png")
+	if ext == imageExtension("image/jpeg") {
+		renditionExt = ext
+	}
+	return strings.TrimSuffix(key, ext) + "_" + rendition + renditionExt
+}
+
+// renditionContentType returns the media type of the renditions of an image of the media type.
+// Renditions of jpeg images are jpeg, the others are png: only the first frame of gif images is kept,
+// and webp images can't be encoded.
+func renditionContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return contentType
+	}
+	return "image/png"
+}
+
+// imageExtension returns the file extension of an image of the media type, e.g. .png.
+func imageExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpeg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ""
+	}
+}
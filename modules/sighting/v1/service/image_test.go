@@ -0,0 +1,315 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	mockImageStore "github.com/ibrahimker/tigerhall-kittens/test/mock/driver/imagestore"
+	mockRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/service"
+)
+
+func ImageMigrationServiceTestSuite(t *testing.T) (*service.ImageMigrationService, *mockRepo.MockImageMigrationRepository, *mockImageStore.MockImageStore) {
+	ctrl := gomock.NewController(t)
+	repo := mockRepo.NewMockImageMigrationRepository(ctrl)
+	imageStore := mockImageStore.NewMockImageStore(ctrl)
+	return service.NewImageMigrationService(repo, imageStore), repo, imageStore
+}
+
+func TestImageMigrationService_MoveImagesToStore(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	png := &imagestore.Image{Data: []byte("\x89PNG\r\n\x1a\n"), ContentType: "image/png"}
+	jpeg := &imagestore.Image{Data: []byte("\xff\xd8\xff"), ContentType: "image/jpeg"}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "successfully move images in batches",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := ImageMigrationServiceTestSuite(t)
+
+				gomock.InOrder(
+					repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(2)).Return([]*entity.Sighting{
+						{ID: 1, TigerID: 7, ImageData: "data:image/png;base64,iVBORw0KGgo="},
+						{ID: 3, TigerID: 8, ImageData: "data:image/png;base64,/9j/"},
+					}, nil),
+					imageStore.EXPECT().Put(mockCtx, "sighting/7/1.png", png).Return(nil),
+					repo.EXPECT().UpdateSightingImageKey(mockCtx, int32(1), "sighting/7/1.png").Return(nil),
+					imageStore.EXPECT().Put(mockCtx, "sighting/8/3.jpeg", jpeg).Return(nil),
+					repo.EXPECT().UpdateSightingImageKey(mockCtx, int32(3), "sighting/8/3.jpeg").Return(nil),
+					repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(3), int32(2)).Return([]*entity.Sighting{
+						{ID: 4, TigerID: 7, ImageData: "iVBORw0KGgo="},
+					}, nil),
+					imageStore.EXPECT().Put(mockCtx, "sighting/7/4.png", png).Return(nil),
+					repo.EXPECT().UpdateSightingImageKey(mockCtx, int32(4), "sighting/7/4.png").Return(nil),
+				)
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 2)
+				require.NoError(t, err)
+				require.Equal(t, 3, moved)
+			},
+		},
+		{
+			testcaseName: "successfully skip images which can't be decoded",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := ImageMigrationServiceTestSuite(t)
+
+				gomock.InOrder(
+					repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(3)).Return([]*entity.Sighting{
+						{ID: 1, TigerID: 7, ImageData: "data:image/gif;base64,dGV4dA=="},
+						{ID: 2, TigerID: 7, ImageData: "not base64"},
+						{ID: 5, TigerID: 7, ImageData: "data:image/png,iVBORw0KGgo="},
+					}, nil),
+					repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(5), int32(3)).Return(nil, nil),
+				)
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 3)
+				require.NoError(t, err)
+				require.Zero(t, moved)
+			},
+		},
+		{
+			testcaseName: "successfully use default batch size",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := ImageMigrationServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(service.DefaultImageMigrationBatchSize)).Return(nil, nil)
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 0)
+				require.NoError(t, err)
+				require.Zero(t, moved)
+			},
+		},
+		{
+			testcaseName: "Error when get sightings",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := ImageMigrationServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(10)).Return(nil, errors.New("db error"))
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 10)
+				require.EqualError(t, err, "db error")
+				require.Zero(t, moved)
+			},
+		},
+		{
+			testcaseName: "Error when store image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := ImageMigrationServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(10)).Return([]*entity.Sighting{
+					{ID: 1, TigerID: 7, ImageData: "data:image/png;base64,iVBORw0KGgo="},
+				}, nil)
+				imageStore.EXPECT().Put(mockCtx, "sighting/7/1.png", png).Return(errors.New("s3 error"))
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 10)
+				require.EqualError(t, err, "s3 error")
+				require.Zero(t, moved)
+			},
+		},
+		{
+			testcaseName: "Error when update image key",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := ImageMigrationServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingsWithImageData(mockCtx, int32(0), int32(10)).Return([]*entity.Sighting{
+					{ID: 1, TigerID: 7, ImageData: "data:image/png;base64,iVBORw0KGgo="},
+					{ID: 2, TigerID: 7, ImageData: "data:image/png;base64,iVBORw0KGgo="},
+				}, nil)
+				imageStore.EXPECT().Put(mockCtx, "sighting/7/1.png", png).Return(nil)
+				repo.EXPECT().UpdateSightingImageKey(mockCtx, int32(1), "sighting/7/1.png").Return(nil)
+				imageStore.EXPECT().Put(mockCtx, "sighting/7/2.png", png).Return(nil)
+				repo.EXPECT().UpdateSightingImageKey(mockCtx, int32(2), "sighting/7/2.png").Return(errors.New("db error"))
+
+				moved, err := svc.MoveImagesToStore(mockCtx, 10)
+				require.EqualError(t, err, "db error")
+				require.Equal(t, 1, moved)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func SightingImageServiceTestSuite(t *testing.T) (*service.SightingImageService, *mockRepo.MockSightingImageRepository, *mockImageStore.MockImageStore) {
+	ctrl := gomock.NewController(t)
+	repo := mockRepo.NewMockSightingImageRepository(ctrl)
+	imageStore := mockImageStore.NewMockImageStore(ctrl)
+	return service.NewSightingImageService(repo, imageStore), repo, imageStore
+}
+
+func TestSightingImageService_OpenSightingImage(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	stored := &entity.Sighting{ID: 3, TigerID: 7, ImageKey: "sighting/7/a.png"}
+	reader := imagestore.NewImageReader(&imagestore.Image{Data: []byte("png"), ContentType: "image/png"})
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "successfully open image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(stored, nil)
+				imageStore.EXPECT().Open(mockCtx, "sighting/7/a.png").Return(reader, nil)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "")
+				require.NoError(t, err)
+				require.Equal(t, reader, res)
+			},
+		},
+		{
+			testcaseName: "successfully open rendition",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(stored, nil)
+				imageStore.EXPECT().Open(mockCtx, "sighting/7/a_thumbnail.png").Return(reader, nil)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, service.ImageRenditionThumbnail)
+				require.NoError(t, err)
+				require.Equal(t, reader, res)
+			},
+		},
+		{
+			testcaseName: "successfully open original image when rendition is missing",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(stored, nil)
+				gomock.InOrder(
+					imageStore.EXPECT().Open(mockCtx, "sighting/7/a_thumbnail.png").Return(nil, imagestore.ErrNotFound),
+					imageStore.EXPECT().Open(mockCtx, "sighting/7/a.png").Return(reader, nil),
+				)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, service.ImageRenditionThumbnail)
+				require.NoError(t, err)
+				require.Equal(t, reader, res)
+			},
+		},
+		{
+			testcaseName: "successfully open image stored in database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).
+					Return(&entity.Sighting{ID: 3, TigerID: 7, ImageData: "data:image/png;base64,iVBORw0KGgo="}, nil)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, service.ImageRenditionThumbnail)
+				require.NoError(t, err)
+				defer res.Close()
+				require.Equal(t, "image/png", res.ContentType)
+				require.Equal(t, int64(8), res.Size)
+				data, err := io.ReadAll(res)
+				require.NoError(t, err)
+				require.Equal(t, "\x89PNG\r\n\x1a\n", string(data))
+			},
+		},
+		{
+			testcaseName: "Error unknown rendition",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, _, _ := SightingImageServiceTestSuite(t)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "huge")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error when get sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(nil, entity.ErrSightingNotFound)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "")
+				require.ErrorIs(t, err, entity.ErrSightingNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error sighting without image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(&entity.Sighting{ID: 3, TigerID: 7}, nil)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "")
+				require.ErrorIs(t, err, entity.ErrSightingImageNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error image stored in database can't be decoded",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, _ := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).
+					Return(&entity.Sighting{ID: 3, TigerID: 7, ImageData: "data:image/gif;base64,dGV4dA=="}, nil)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "")
+				require.ErrorIs(t, err, entity.ErrSightingImageNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error image missing from image store",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(stored, nil)
+				gomock.InOrder(
+					imageStore.EXPECT().Open(mockCtx, "sighting/7/a_thumbnail.png").Return(nil, imagestore.ErrNotFound),
+					imageStore.EXPECT().Open(mockCtx, "sighting/7/a.png").Return(nil, imagestore.ErrNotFound),
+				)
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, service.ImageRenditionThumbnail)
+				require.ErrorIs(t, err, entity.ErrSightingImageNotFound)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error when open image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo, imageStore := SightingImageServiceTestSuite(t)
+
+				repo.EXPECT().GetSightingByID(mockCtx, int32(7), int32(3)).Return(stored, nil)
+				imageStore.EXPECT().Open(mockCtx, "sighting/7/a.png").Return(nil, errors.New("s3 error"))
+
+				res, err := svc.OpenSightingImage(mockCtx, 7, 3, "")
+				require.EqualError(t, err, "s3 error")
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// pngSignature starts a png image.
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	// pngMetadataChunks are the chunks of a png image holding its EXIF or texts, e.g. an XMP.
+	pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true}
+	// errTruncatedImage is returned when the chunks or blocks of an image overrun its data.
+	errTruncatedImage = errors.New("image is truncated")
+)
+
+// VP8X flags of a webp image telling whether it has an EXIF or an XMP chunk.
+const (
+	webpFlagExif = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripImageMetadata returns the image of the media type without the metadata which may hold the location
+// of the reporter, i.e. the EXIF, XMP and texts. A jpeg image keeps its orientation as for stripJPEGMetadata.
+func stripImageMetadata(data []byte, contentType string, orientation int) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEGMetadata(data, orientation)
+	case "image/png":
+		return stripPNGMetadata(data)
+	case "image/gif":
+		return stripGIFMetadata(data)
+	case "image/webp":
+		return stripWebPMetadata(data)
+	default:
+		return nil, fmt.Errorf("image of type %s is not supported", contentType)
+	}
+}
+
+// stripPNGMetadata returns the png image without its EXIF and text chunks, nor any data after its end.
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errors.New("image is not a png image")
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	for i := len(pngSignature); ; {
+		// length, type, data and CRC
+		if i+12 > len(data) {
+			return nil, errTruncatedImage
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i+12 {
+			return nil, errTruncatedImage
+		}
+		chunkType := string(data[i+4 : i+8])
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[i:end]...)
+		}
+		if chunkType == "IEND" {
+			return out, nil
+		}
+		i = end
+	}
+}
+
+// stripWebPMetadata returns the webp image without its EXIF and XMP chunks, nor any data after its RIFF container.
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errors.New("image is not a webp image")
+	}
+	size := 8 + int(binary.LittleEndian.Uint32(data[4:]))
+	if size > len(data) || size < 12 {
+		return nil, errTruncatedImage
+	}
+	data = data[:size]
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+	for i := 12; i < len(data); {
+		// FourCC, size and data, padded to an even size
+		if i+8 > len(data) {
+			return nil, errTruncatedImage
+		}
+		end := i + 8 + int(binary.LittleEndian.Uint32(data[i+4:]))
+		if end > len(data) || end < i+8 {
+			return nil, errTruncatedImage
+		}
+		chunk := data[i:end]
+		if end%2 == 1 && end < len(data) {
+			chunk = data[i : end+1]
+		}
+		i += len(chunk)
+
+		switch string(chunk[:4]) {
+		case "EXIF", "XMP ":
+			continue
+		case "VP8X":
+			if len(chunk) > 8 {
+				start := len(out)
+				out = append(out, chunk...)
+				out[start+8] &^= webpFlagExif | webpFlagXMP
+				continue
+			}
+		}
+		out = append(out, chunk...)
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// stripGIFMetadata returns the gif image without its comments and application extensions, e.g. an XMP,
+// but the ones looping animations, nor any data after its trailer.
+func stripGIFMetadata(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, errors.New("image is not a gif image")
+	}
+	// header, logical screen descriptor and global color table
+	i := 13
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	if i > len(data) {
+		return nil, errTruncatedImage
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+
+	for {
+		if i >= len(data) {
+			return nil, errTruncatedImage
+		}
+		switch data[i] {
+		case 0x3b:
+			// trailer
+			return append(out, 0x3b), nil
+		case 0x21:
+			// extension, its label then its sub-blocks
+			end, err := skipGIFSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			label := data[i+1]
+			if label != 0xfe && (label != 0xff || isGIFLoop(data[i+2:end])) {
+				out = append(out, data[i:end]...)
+			}
+			i = end
+		case 0x2c:
+			// image descriptor, local color table, LZW minimum code size then the sub-blocks of the image data
+			start := i + 10
+			if start > len(data) {
+				return nil, errTruncatedImage
+			}
+			if data[i+9]&0x80 != 0 {
+				start += 3 << (data[i+9]&0x07 + 1)
+			}
+			end, err := skipGIFSubBlocks(data, start+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data[i:end]...)
+			i = end
+		default:
+			return nil, errors.New("gif image is not valid")
+		}
+	}
+}
+
+// skipGIFSubBlocks returns the index after the sub-blocks of a gif image starting at i, i.e. after their terminator.
+func skipGIFSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errTruncatedImage
+		}
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+}
+
+// isGIFLoop tells whether the sub-blocks of an application extension are the ones looping an animation.
+func isGIFLoop(subBlocks []byte) bool {
+	if len(subBlocks) < 12 || subBlocks[0] != 11 {
+		return false
+	}
+	identifier := string(subBlocks[1:12])
+	return identifier == "NETSCAPE2.0" || identifier == "ANIMEXTS1.0"
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// gradientImage returns a 16x8 image shaded from black on the left to white on the right.
+func gradientImage() image.Image {
+	im := image.NewGray(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			im.SetGray(x, y, color.Gray{Y: uint8(x * 17)})
+		}
+	}
+	return im
+}
+
+// pngChunk returns a chunk of a png image with its CRC.
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := appendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return appendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// webpChunk returns a chunk of a webp image, padded to an even size.
+func webpChunk(fourCC string, data []byte) []byte {
+	chunk := append([]byte(fourCC), byte(len(data)), byte(len(data)>>8), byte(len(data)>>16), byte(len(data)>>24))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// webpImage returns a webp image of the chunks.
+func webpImage(chunks ...[]byte) []byte {
+	var data []byte
+	for _, chunk := range chunks {
+		data = append(data, chunk...)
+	}
+	size := len(data) + 4
+	out := append([]byte("RIFF"), byte(size), byte(size>>8), byte(size>>16), byte(size>>24))
+	out = append(out, "WEBP"...)
+	return append(out, data...)
+}
+
+func TestCreateSighting_Metadata(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	seenAt := time.Now().Add(-time.Minute)
+
+	var plainPNG bytes.Buffer
+	require.NoError(t, png.Encode(&plainPNG, gradientImage()))
+	// the metadata chunks follow the IHDR chunk, i.e. the signature and 25 bytes
+	taggedPNG := append([]byte{}, plainPNG.Bytes()[:33]...)
+	taggedPNG = append(taggedPNG, pngChunk("eXIf", []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x00"))...)
+	taggedPNG = append(taggedPNG, pngChunk("tEXt", []byte("Comment\x00-6.18,106.82"))...)
+	taggedPNG = append(taggedPNG, pngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>"))...)
+	taggedPNG = append(taggedPNG, plainPNG.Bytes()[33:]...)
+	// data after the end of the image is dropped too
+	taggedPNG = append(taggedPNG, "-6.18,106.82"...)
+
+	var plainGIF bytes.Buffer
+	require.NoError(t, gif.Encode(&plainGIF, gradientImage(), nil))
+	// the extensions follow the header, the logical screen descriptor and the global color table
+	headerSize := 13 + 3<<(plainGIF.Bytes()[10]&0x07+1)
+	taggedGIF := append([]byte{}, plainGIF.Bytes()[:headerSize]...)
+	taggedGIF = append(taggedGIF, "\x21\xfe\x0c-6.18,106.82\x00"...)
+	taggedGIF = append(taggedGIF, "\x21\xff\x0bXMP DataXMP\x0c<x:xmpmeta/>\x00"...)
+	taggedGIF = append(taggedGIF, plainGIF.Bytes()[headerSize:]...)
+
+	// 1x1 lossy webp image, in the extended format to hold metadata
+	simpleWebP, err := base64.StdEncoding.DecodeString("UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA")
+	require.NoError(t, err)
+	vp8 := simpleWebP[12:]
+	vp8x := func(flags byte) []byte {
+		return webpChunk("VP8X", []byte{flags, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	}
+	taggedWebP := webpImage(vp8x(0x0c), webpChunk("EXIF", []byte("MM\x00\x2a\x00\x00\x00\x08\x00")), vp8,
+		webpChunk("XMP ", []byte("<x:xmpmeta/>")))
+	plainWebP := webpImage(vp8x(0), vp8)
+
+	testCases := []ServiceTestCase{}
+	for _, format := range []struct {
+		name, ext        string
+		tagged, stripped []byte
+	}{
+		{name: "png", ext: ".png", tagged: taggedPNG, stripped: plainPNG.Bytes()},
+		{name: "gif", ext: ".gif", tagged: taggedGIF, stripped: plainGIF.Bytes()},
+		{name: "webp", ext: ".webp", tagged: taggedWebP, stripped: plainWebP},
+	} {
+		format := format
+		testCases = append(testCases, ServiceTestCase{
+			testcaseName: "successfully insert to database a sighting whose " + format.name + " image has metadata",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := &entity.Tiger{ID: tigerID, Name: "tiger-1", DateOfBirth: time.Now(), LastSeenTimestamp: time.Now().Add(-time.Hour)}
+				sighting := &entity.Sighting{TigerID: tigerID, SeenAt: seenAt, Latitude: -6.18, Longitude: 106.82,
+					ImageData: base64.StdEncoding.EncodeToString(format.tagged)}
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.expectCreateSighting(mockCtx, tigerData, sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, sightingImageKey(tigerID, format.ext).Matches(sighting.ImageKey))
+				require.Equal(t, format.stripped, images[sighting.ImageKey].Data)
+				_, _, err := image.Decode(bytes.NewReader(images[sighting.ImageKey].Data))
+				require.NoError(t, err)
+			},
+		})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
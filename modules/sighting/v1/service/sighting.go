@@ -4,13 +4,18 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	geo "github.com/kellydunn/golang-geo"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
 	"github.com/ibrahimker/tigerhall-kittens/driver/redis"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
 )
@@ -20,8 +25,62 @@ const (
 	BaseKey = entity.ModuleName + ":" + entity.ModuleVersion + ":"
 	// GetTigersKey is a base key for caching GetTigers service
 	GetTigersKey = BaseKey + "sighting:get-tigers"
+	// GetTigersVersionKey stores the version of GetTigers cache. Increment it to invalidate every cached page.
+	GetTigersVersionKey = GetTigersKey + ":version"
+	// GetTigersPageKey is a key for caching a page of GetTigers service, formatted with cache version and page hash
+	GetTigersPageKey = GetTigersKey + ":%d:%s"
+	// SearchTigersNearbyKey is a base key for caching SearchTigersNearby service
+	SearchTigersNearbyKey = BaseKey + "sighting:search-tigers-nearby"
+	// SearchTigersNearbyCellKey is a key for caching the candidates of SearchTigersNearby service, formatted with GetTigers
+	// cache version, rounded latitude and longitude, radius, since and limit. Both services read the same tigers, hence share the version.
+	SearchTigersNearbyCellKey = SearchTigersNearbyKey + ":candidates:%d:%.2f:%.2f:%g:%d:%d"
+	// GetTigerByIDKey is a base key for caching GetTiger service
+	GetTigerByIDKey = BaseKey + "sighting:get-tiger:%d"
 	// GetSightingsByTigerIDKey is a base key for caching GetSightingsByTigerID service
 	GetSightingsByTigerIDKey = BaseKey + "sighting:get-sightings-by-tiger:%d"
+	// GetSightingsByTigerIDVersionKey stores the version of GetSightingsByTigerID cache of a tiger.
+	// Increment it to invalidate every cached page of the tiger.
+	GetSightingsByTigerIDVersionKey = GetSightingsByTigerIDKey + ":version"
+	// GetSightingsByTigerIDPageKey is a key for caching a page of GetSightingsByTigerID service,
+	// formatted with tiger ID, cache version and page hash
+	GetSightingsByTigerIDPageKey = GetSightingsByTigerIDKey + ":%d:%s"
+)
+
+const (
+	// DefaultTigersPageSize is the page size of GetTigers if it is not specified
+	DefaultTigersPageSize = 50
+	// MaxTigersPageSize is the maximum page size of GetTigers
+	MaxTigersPageSize = 100
+	// DefaultSightingsPageSize is the page size of GetSightingsByTigerID if it is not specified
+	DefaultSightingsPageSize = 50
+	// MaxSightingsPageSize is the maximum page size of GetSightingsByTigerID
+	MaxSightingsPageSize = 100
+	// loadImagesConcurrency is the number of images of a page of sightings read from the image store at the same time
+	loadImagesConcurrency = 8
+	// DefaultNearbyTigersLimit is the number of tigers returned by SearchTigersNearby if limit is not specified
+	DefaultNearbyTigersLimit = 50
+	// MaxNearbyTigersLimit is the maximum number of tigers returned by SearchTigersNearby
+	MaxNearbyTigersLimit = 100
+	// MaxNearbyRadiusKm is the maximum radius of SearchTigersNearby
+	MaxNearbyRadiusKm = 500
+	// NearbyCellSize is the size in degrees of a cell SearchTigersNearby rounds the searched point into, about 1.1 km
+	NearbyCellSize = 0.01
+	// nearbyCellMargin is an upper bound in meters of the distance between a point and the center of its cell,
+	// half a cell of latitude plus half a cell of longitude, of at most 112 km per degree each.
+	nearbyCellMargin = NearbyCellSize * 112000
+	// nearbyCandidatesFactor is the number of candidates fetched around the center of a cell for each tiger returned,
+	// so that a point far from the center still gets its nearest tigers from the cache
+	nearbyCandidatesFactor = 2
+	// DefaultSimilarImagesLimit is the number of sightings returned by FindSimilarImages if limit is not specified
+	DefaultSimilarImagesLimit = 50
+	// MaxSimilarImagesLimit is the maximum number of sightings returned by FindSimilarImages
+	MaxSimilarImagesLimit = 100
+	// MaxSimilarImageDistance is the maximum number of bits by which the perceptual hashes of similar images may differ,
+	// since hashes are searched by the bands of 8 bits they share, see TigerSightingRepository.GetSimilarSightings
+	MaxSimilarImageDistance = 7
+	// minImageHashBits is the number of bits of the perceptual hash of an image which must be set, and unset, to compare it.
+	// It is above MaxSimilarImageDistance so that the zero hash stored for a plain image is never found similar.
+	minImageHashBits = MaxSimilarImageDistance + 1
 )
 
 var (
@@ -31,68 +90,169 @@ var (
 
 // TigerSighting defines the interface to tiger sighting services.
 type TigerSighting interface {
-	// GetTigers get list of tigers from database order by last seen timestamp
-	GetTigers(ctx context.Context) ([]*entity.Tiger, error)
-	// CreateTiger store a new tiger in database
+	// GetTigers get a page of tigers matching the filter from database order by last seen timestamp
+	// Empty pageToken means the first page.
+	GetTigers(ctx context.Context, filter *entity.TigerFilter, pageSize int32, pageToken string) (*entity.TigerPage, error)
+	// CreateTiger store a new tiger in database with an EventTigerCreated event
 	CreateTiger(ctx context.Context, tiger *entity.Tiger) error
-	// GetSightingsByTigerID get list of sightings for given tiger ID order by latest sighting
-	GetSightingsByTigerID(ctx context.Context, tigerID int32) ([]*entity.Sighting, error)
-	// CreateSighting store a new sighting for given tiger ID in database if not within 5 km of previous sighting
+	// SearchTigersNearby get tigers last seen within the radius of the searched point order by distance, nearest first
+	SearchTigersNearby(ctx context.Context, query *entity.NearbyQuery) ([]*entity.NearbyTiger, error)
+	// GetTiger get tiger for given ID from database
+	// It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+	GetTiger(ctx context.Context, tigerID int32) (*entity.Tiger, error)
+	// UpdateTiger update fields of the tiger listed in paths, using proto field names
+	// (name, date_of_birth, max_sighting_distance). Name and date of birth are updated if paths is empty.
+	// An EventTigerUpdated event is stored with the change. It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+	UpdateTiger(ctx context.Context, tiger *entity.Tiger, paths []string) (*entity.Tiger, error)
+	// DeleteTiger soft delete tiger for given ID in database
+	// An EventTigerDeleted event is stored with the change. It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+	DeleteTiger(ctx context.Context, tigerID int32) error
+	// GetSightingsByTigerID get a page of sightings matching the filter for given tiger ID order by latest sighting
+	// Empty pageToken means the first page.
+	// It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+	GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, pageSize int32, pageToken string) (*entity.SightingPage, error)
+	// CreateSighting store a new sighting for given tiger ID in database if accepted by the sighting validator
+	// The last seen location of the tiger is moved to the sighting only if the sighting is newer.
+	// It sets Speed and may set NeedsReview of the sighting. It returns entity.ErrTigerNotFound if the tiger doesn't exist.
+	// An EventSightingRecorded event is stored with the sighting, e.g. to notify everyone who reported the tiger before.
+	// The sighting is then published to its watchers, see SightingWatch.
 	CreateSighting(ctx context.Context, sighting *entity.Sighting) error
+	// FindSimilarImages get the sightings whose image is a near-duplicate of the searched image, order by distance
+	// then latest sighting. ImageData of the returned sightings is left empty.
+	FindSimilarImages(ctx context.Context, query *entity.SimilarImageQuery) ([]*entity.SimilarSighting, error)
 }
 
 // TigerSightingRepository defines the interface to tiger sighting repository.
 type TigerSightingRepository interface {
-	// GetTigers get list of tigers from database order by last seen timestamp
-	GetTigers(ctx context.Context) ([]*entity.Tiger, error)
+	// GetTigers get at most limit tigers matching the filter and positioned after the cursor from database
+	// order by last seen timestamp. Nil cursor means from the beginning.
+	GetTigers(ctx context.Context, filter *entity.TigerFilter, cursor *entity.TigerCursor, limit int32) ([]*entity.Tiger, error)
 	// GetTigerByID get tiger by ID from database
 	GetTigerByID(ctx context.Context, tigerID int32) (*entity.Tiger, error)
+	// GetTigerByIDForUpdate get tiger by ID from database and lock it until the transaction ends
+	GetTigerByIDForUpdate(ctx context.Context, tigerID int32) (*entity.Tiger, error)
 	// CreateTiger store a new tiger in database
 	CreateTiger(ctx context.Context, tiger *entity.Tiger) error
 	// UpdateTiger update tiger data in database
 	UpdateTiger(ctx context.Context, tiger *entity.Tiger) error
+	// DeleteTiger soft delete tiger in database
+	DeleteTiger(ctx context.Context, tigerID int32) error
+	// GetTigersNearby get at most limit tigers last seen within radius meters of the given point and at or after since
+	// from database order by distance, nearest first. Zero since means no last seen timestamp filter.
+	GetTigersNearby(ctx context.Context, latitude, longitude, radius float64, since time.Time, limit int32) ([]*entity.NearbyTiger, error)
+	// GetDistanceFromLastSeen get distance in meters between last seen location of given tiger ID and the given point
+	GetDistanceFromLastSeen(ctx context.Context, tigerID int32, latitude, longitude float64) (float64, error)
 
-	// GetSightingsByTigerID get list of sightings for given tiger ID order by latest sighting
-	GetSightingsByTigerID(ctx context.Context, tigerID int32) ([]*entity.Sighting, error)
+	// GetAdjacentSightings get the latest sighting seen at or before seenAt and the earliest sighting seen after seenAt
+	// for given tiger ID with their distance in meters from the given point. Nil means there is no such sighting.
+	GetAdjacentSightings(ctx context.Context, tigerID int32, seenAt time.Time, latitude, longitude float64) (previous, next *entity.Waypoint, err error)
+	// GetSightingsByTigerID get at most limit sightings matching the filter and positioned after the cursor
+	// for given tiger ID order by latest sighting. Nil cursor means from the beginning.
+	GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, cursor *entity.SightingCursor, limit int32) ([]*entity.Sighting, error)
 	// CreateSighting store a new sighting for given tiger ID in database
 	CreateSighting(ctx context.Context, sighting *entity.Sighting) error
+	// GetSimilarSightings get at most limit sightings whose image hash differs from hash by at most maxDistance bits
+	// order by distance then latest sighting. maxDistance must be at most MaxSimilarImageDistance.
+	GetSimilarSightings(ctx context.Context, hash uint64, maxDistance, limit int32) ([]*entity.SimilarSighting, error)
+	// GetPriorReporters get distinct emails of everyone who reported a sighting of given tiger ID from database
+	// The exclude email and empty emails are left out.
+	GetPriorReporters(ctx context.Context, tigerID int32, exclude string) ([]string, error)
+	// CreateOutboxEvent store a new pending event in the outbox
+	// Call it with the context given to WithTransaction, so that the event is stored only if the change is.
+	CreateOutboxEvent(ctx context.Context, event *entity.OutboxEvent) error
+
+	// WithTransaction runs fn atomically. Repository calls made with the context given to fn join the transaction.
+	// The transaction is rolled back if fn returns an error.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // TigerSightingService is responsible for hold dependencies related to tiger sighting service.
 type TigerSightingService struct {
-	repo      TigerSightingRepository
-	redisRepo redis.Redis
+	repo       TigerSightingRepository
+	redisRepo  redis.Redis
+	imageStore imagestore.ImageStore
+	limits     ImageLimits
+	renditions []ImageRendition
+	exif       ExifTolerance
+	duplicates DuplicateImages
+	validator  SightingValidator
+	clockSkew  time.Duration
 }
 
 // NewTigerSightingService creates an instance of TigerSightingService.
-func NewTigerSightingService(repo TigerSightingRepository, redisRepo redis.Redis) *TigerSightingService {
+// imageStore keeps the images of sightings within the limits, with the renditions generated from each of them.
+// exif is how far a new sighting may be from the EXIF of its image before it needs review.
+// duplicates decides whether a new sighting whose image is a near-duplicate is rejected or needs review.
+// validator decides whether a new sighting is accepted.
+// clockSkew is how far in the future the seen time of a new sighting may be.
+func NewTigerSightingService(repo TigerSightingRepository, redisRepo redis.Redis, imageStore imagestore.ImageStore, limits ImageLimits,
+	renditions []ImageRendition, exif ExifTolerance, duplicates DuplicateImages, validator SightingValidator, clockSkew time.Duration) *TigerSightingService {
 	return &TigerSightingService{
-		repo:      repo,
-		redisRepo: redisRepo,
+		repo:       repo,
+		redisRepo:  redisRepo,
+		imageStore: imageStore,
+		limits:     limits,
+		renditions: renditions,
+		exif:       exif,
+		duplicates: duplicates,
+		validator:  validator,
+		clockSkew:  clockSkew,
 	}
 }
 
-// GetTigers get list of tigers from database order by last seen timestamp
-func (t *TigerSightingService) GetTigers(ctx context.Context) (tigers []*entity.Tiger, err error) {
+// GetTigers get a page of tigers matching the filter from database order by last seen timestamp
+func (t *TigerSightingService) GetTigers(ctx context.Context, filter *entity.TigerFilter, pageSize int32, pageToken string) (page *entity.TigerPage, err error) {
 	logger := logging.NewServiceLogger(ctx, "GetTigers", logrus.Fields{})
 
+	// validate input
+	if err = isValidTigerFilter(filter, pageSize); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate tiger filter")
+		return nil, err
+	}
+	if pageSize == 0 {
+		pageSize = DefaultTigersPageSize
+	}
+	cursor, err := decodeTigerPageToken(pageToken)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from decodeTigerPageToken")
+		return nil, err
+	}
+
+	// Every write increments the cache version, so pages cached before the write are never read again
+	var version int64
+	if err = t.redisRepo.Fetch(ctx, GetTigersVersionKey, &version, 0, func() (interface{}, error) {
+		return version, nil
+	}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch version")
+		return nil, err
+	}
+
 	// Get data cache from Redis, if data empty or not found then get tiger data from Database
-	if err = t.redisRepo.Fetch(ctx, GetTigersKey, &tigers, GetTigersRedisTTL, func() (interface{}, error) {
-		tigers, err = t.repo.GetTigers(ctx)
+	key := fmt.Sprintf(GetTigersPageKey, version, pageHash(filter, pageSize, pageToken))
+	if err = t.redisRepo.Fetch(ctx, key, &page, GetTigersRedisTTL, func() (interface{}, error) {
+		// fetch one more tiger to know whether there is a next page
+		tigers, err := t.repo.GetTigers(ctx, filter, cursor, pageSize+1)
 		if err != nil {
 			logging.WithError(err, logger).Warn("Error when get from repo.GetTigers")
 			return nil, err
 		}
-		return tigers, nil
+
+		page = &entity.TigerPage{Tigers: tigers}
+		if len(tigers) > int(pageSize) {
+			page.Tigers = tigers[:pageSize]
+			last := page.Tigers[pageSize-1]
+			page.NextPageToken = encodePageToken(&entity.TigerCursor{LastSeenTimestamp: last.LastSeenTimestamp, ID: last.ID})
+		}
+		return page, nil
 	}); err != nil {
 		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch")
 		return nil, err
 	}
 
-	return tigers, nil
+	return page, nil
 }
 
-// CreateTiger store a new tiger in database
+// CreateTiger store a new tiger in database with an EventTigerCreated event
 func (t *TigerSightingService) CreateTiger(ctx context.Context, tiger *entity.Tiger) error {
 	logger := logging.NewServiceLogger(ctx, "CreateTiger", logrus.Fields{})
 
@@ -102,88 +262,587 @@ func (t *TigerSightingService) CreateTiger(ctx context.Context, tiger *entity.Ti
 		return err
 	}
 
-	// insert to repo
-	if err := t.repo.CreateTiger(ctx, tiger); err != nil {
-		logging.WithError(err, logger).Warn("Error when get from repo.CreateTiger")
+	// insert to repo with its event
+	if err := t.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.CreateTiger(ctx, tiger); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.CreateTiger")
+			return err
+		}
+		return t.createOutboxEvent(ctx, entity.EventTigerCreated, tiger.ID, &entity.TigerEvent{TigerID: tiger.ID, Name: tiger.Name})
+	}); err != nil {
 		return err
 	}
 
-	// invalidate cache
-	_ = t.redisRepo.Del(ctx, GetTigersKey)
+	// invalidate cache right away, the event handler invalidates it again if this fails
+	_, _ = t.redisRepo.Incr(ctx, GetTigersVersionKey)
 
 	return nil
 }
 
-// GetSightingsByTigerID get list of sightings for given tiger ID order by latest sighting
-func (t *TigerSightingService) GetSightingsByTigerID(ctx context.Context, tigerID int32) (sightings []*entity.Sighting, err error) {
-	logger := logging.NewServiceLogger(ctx, "GetSightingsByTigerID", logrus.Fields{})
+// SearchTigersNearby get tigers last seen within the radius of the searched point order by distance, nearest first
+// The searched point is rounded into a cell of NearbyCellSize degrees so that rangers close to each other share the cache
+// of the candidates, the tigers near any point of the cell. Candidates are then filtered, measured and sorted from the searched point.
+func (t *TigerSightingService) SearchTigersNearby(ctx context.Context, query *entity.NearbyQuery) (tigers []*entity.NearbyTiger, err error) {
+	logger := logging.NewServiceLogger(ctx, "SearchTigersNearby", logrus.Fields{})
+
+	// validate input
+	if err = isValidNearbyQuery(query); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate nearby query")
+		return nil, err
+	}
+	cell := roundNearbyQuery(query)
+
+	// Every write increments the cache version, so results cached before the write are never read again
+	var version int64
+	if err = t.redisRepo.Fetch(ctx, GetTigersVersionKey, &version, 0, func() (interface{}, error) {
+		return version, nil
+	}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch version")
+		return nil, err
+	}
 
 	// Get data cache from Redis, if data empty or not found then get tiger data from Database
-	if err = t.redisRepo.Fetch(ctx, fmt.Sprintf(GetSightingsByTigerIDKey, tigerID), &sightings, GetTigersRedisTTL, func() (interface{}, error) {
-		sightings, err = t.repo.GetSightingsByTigerID(ctx, tigerID)
+	// The radius is widened by the margin, so that the candidates contain every tiger within the radius of any point of the cell.
+	var candidates []*entity.NearbyTiger
+	candidatesLimit := cell.Limit * nearbyCandidatesFactor
+	key := fmt.Sprintf(SearchTigersNearbyCellKey, version, cell.Latitude, cell.Longitude, cell.RadiusKm, cell.Since.Unix(), cell.Limit)
+	if err = t.redisRepo.Fetch(ctx, key, &candidates, GetTigersRedisTTL, func() (interface{}, error) {
+		return t.repo.GetTigersNearby(ctx, cell.Latitude, cell.Longitude, cell.RadiusKm*1000+nearbyCellMargin, cell.Since, candidatesLimit)
+	}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch")
+		return nil, err
+	}
+
+	tigers, complete := nearbyTigers(query, cell.Limit, candidates, candidatesLimit)
+	if complete {
+		return tigers, nil
+	}
+
+	// too many candidates to be sure they contain the nearest tigers of the point, search around the point itself
+	tigers, err = t.repo.GetTigersNearby(ctx, query.Latitude, query.Longitude, query.RadiusKm*1000, query.Since, cell.Limit)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetTigersNearby")
+		return nil, err
+	}
+	return tigers, nil
+}
+
+// GetTiger get tiger for given ID from database
+func (t *TigerSightingService) GetTiger(ctx context.Context, tigerID int32) (tiger *entity.Tiger, err error) {
+	logger := logging.NewServiceLogger(ctx, "GetTiger", logrus.Fields{})
+
+	// Get data cache from Redis, if data empty or not found then get tiger data from Database
+	if err = t.redisRepo.Fetch(ctx, fmt.Sprintf(GetTigerByIDKey, tigerID), &tiger, GetTigersRedisTTL, func() (interface{}, error) {
+		tiger, err = t.repo.GetTigerByID(ctx, tigerID)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.GetTigerByID")
+			return nil, err
+		}
+		return tiger, nil
+	}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch")
+		return nil, err
+	}
+
+	return tiger, nil
+}
+
+// UpdateTiger update fields of the tiger listed in paths with an EventTigerUpdated event
+func (t *TigerSightingService) UpdateTiger(ctx context.Context, tiger *entity.Tiger, paths []string) (*entity.Tiger, error) {
+	logger := logging.NewServiceLogger(ctx, "UpdateTiger", logrus.Fields{})
+
+	// validate field mask
+	if err := isValidTigerUpdatePaths(paths); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate update paths")
+		return nil, err
+	}
+	if len(paths) == 0 {
+		paths = defaultTigerUpdatePaths(tiger)
+	}
+
+	var updated *entity.Tiger
+	if err := t.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := t.repo.GetTigerByIDForUpdate(ctx, tiger.ID)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get repo.GetTigerByIDForUpdate")
+			return err
+		}
+
+		applyTigerUpdate(current, tiger, paths)
+		if err = isValidTiger(current); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from validate tiger")
+			return err
+		}
+
+		if err = t.repo.UpdateTiger(ctx, current); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.UpdateTiger")
+			return err
+		}
+		updated = current
+		return t.createOutboxEvent(ctx, entity.EventTigerUpdated, current.ID, &entity.TigerEvent{TigerID: current.ID, Name: current.Name})
+	}); err != nil {
+		return nil, err
+	}
+
+	t.invalidateTigerCache(ctx, tiger.ID)
+
+	return updated, nil
+}
+
+// DeleteTiger soft delete tiger for given ID in database with an EventTigerDeleted event
+func (t *TigerSightingService) DeleteTiger(ctx context.Context, tigerID int32) error {
+	logger := logging.NewServiceLogger(ctx, "DeleteTiger", logrus.Fields{})
+
+	if err := t.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.DeleteTiger(ctx, tigerID); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.DeleteTiger")
+			return err
+		}
+		return t.createOutboxEvent(ctx, entity.EventTigerDeleted, tigerID, &entity.TigerEvent{TigerID: tigerID})
+	}); err != nil {
+		return err
+	}
+
+	t.invalidateTigerCache(ctx, tigerID)
+
+	return nil
+}
+
+// GetSightingsByTigerID get a page of sightings matching the filter for given tiger ID order by latest sighting
+func (t *TigerSightingService) GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, pageSize int32, pageToken string) (page *entity.SightingPage, err error) {
+	logger := logging.NewServiceLogger(ctx, "GetSightingsByTigerID", logrus.Fields{})
+
+	// validate input
+	if err = isValidSightingFilter(filter, pageSize); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate sighting filter")
+		return nil, err
+	}
+	if pageSize == 0 {
+		pageSize = DefaultSightingsPageSize
+	}
+	cursor, err := decodeSightingPageToken(pageToken)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from decodeSightingPageToken")
+		return nil, err
+	}
+
+	// Every write on the tiger increments the cache version, so pages cached before the write are never read again
+	var version int64
+	if err = t.redisRepo.Fetch(ctx, fmt.Sprintf(GetSightingsByTigerIDVersionKey, tigerID), &version, 0, func() (interface{}, error) {
+		return version, nil
+	}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch version")
+		return nil, err
+	}
+
+	// Get data cache from Redis, if data empty or not found then get sighting data from Database
+	key := fmt.Sprintf(GetSightingsByTigerIDPageKey, tigerID, version, pageHash(filter, pageSize, pageToken))
+	if err = t.redisRepo.Fetch(ctx, key, &page, GetTigersRedisTTL, func() (interface{}, error) {
+		// make sure the tiger exists, so unknown tiger is not reported as a tiger without sightings
+		if _, err = t.repo.GetTigerByID(ctx, tigerID); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.GetTigerByID")
+			return nil, err
+		}
+
+		// fetch one more sighting to know whether there is a next page
+		sightings, err := t.repo.GetSightingsByTigerID(ctx, tigerID, filter, cursor, pageSize+1)
 		if err != nil {
 			logging.WithError(err, logger).Warn("Error when get from repo.GetSightingsByTigerID")
 			return nil, err
 		}
-		return sightings, nil
+
+		page = &entity.SightingPage{Sightings: sightings}
+		if len(sightings) > int(pageSize) {
+			page.Sightings = sightings[:pageSize]
+			last := page.Sightings[pageSize-1]
+			page.NextPageToken = encodePageToken(&entity.SightingCursor{SeenAt: last.SeenAt, ID: last.ID})
+		}
+		return page, nil
 	}); err != nil {
 		logging.WithError(err, logger).Warn("Error when get from redisRepo.Fetch")
 		return nil, err
 	}
 
-	return sightings, nil
+	// images are not cached with the page, they are read from the image store only if asked
+	if filter != nil && filter.IncludeImageData {
+		t.loadImages(ctx, page.Sightings)
+	}
+
+	return page, nil
 }
 
-// CreateSighting store a new sighting for given tiger ID in database if not within 5 km of previous sighting
-// It will also resize sighting image into 250x200
+// loadImages sets the image of the sightings whose image is kept in the image store to its medium rendition.
+// Images moved from database have no renditions, the original image is set instead.
+// An image which can't be read is logged and left empty, so that the sightings are still returned.
+// Up to loadImagesConcurrency images are read at the same time.
+func (t *TigerSightingService) loadImages(ctx context.Context, sightings []*entity.Sighting) {
+	logger := logging.NewServiceLogger(ctx, "loadImages", logrus.Fields{})
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, loadImagesConcurrency)
+	for _, sighting := range sightings {
+		if sighting.ImageKey == "" {
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(sighting *entity.Sighting) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			image, err := t.imageStore.Get(ctx, imageRenditionKey(sighting.ImageKey, ImageRenditionMedium))
+			if errors.Is(err, imagestore.ErrNotFound) {
+				image, err = t.imageStore.Get(ctx, sighting.ImageKey)
+			}
+			if err != nil {
+				logging.WithError(err, logger).WithField("sighting_id", sighting.ID).Warn("Error when get from imageStore.Get")
+				return
+			}
+			sighting.ImageData = imageDataURI(image)
+		}(sighting)
+	}
+	wg.Wait()
+}
+
+// CreateSighting store a new sighting for given tiger ID in database if accepted by the sighting validator
+// The sighting is validated against the chronologically adjacent sightings, so backdated sightings are supported.
+// The seen time and location are read from the EXIF of a jpeg image if omitted, otherwise the sighting needs review
+// if they disagree with it. It will also keep the image, without metadata but its orientation, with its renditions in the image store.
+// A sighting whose image is a near-duplicate of the image of another sighting, of any tiger, is rejected or needs review.
+// Concurrent sightings of the same tiger are serialized by locking the tiger row.
+// An EventSightingRecorded event listing the distinct previous reporters of the tiger is stored with the sighting.
 func (t *TigerSightingService) CreateSighting(ctx context.Context, sighting *entity.Sighting) error {
-	logger := logging.NewServiceLogger(ctx, "CreateTiger", logrus.Fields{})
+	logger := logging.NewServiceLogger(ctx, "CreateSighting", logrus.Fields{})
+
+	// decode the image before validating the sighting, since its EXIF may complete the sighting
+	var original *imagestore.Image
+	if sighting.ImageData != "" {
+		var err error
+		if original, err = decodeImageData(sighting.ImageData, t.limits.MaxBytes); err != nil {
+			logging.WithError(err, logger).Warn("Error when get decodeImageData")
+			return apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
+		}
+		if err = t.applyImageExif(ctx, sighting, original); err != nil {
+			return apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
+		}
+	}
 
 	// validate input
-	if err := isValidSighting(sighting); err != nil {
+	if err := isValidSighting(sighting, time.Now().Add(t.clockSkew)); err != nil {
 		logging.WithError(err, logger).Warn("Error when get from validate sighting")
 		return err
 	}
 
-	// validate is new lat/long in 5km radius
-	tiger, err := t.repo.GetTigerByID(ctx, sighting.TigerID)
+	// render image before taking the lock to keep the transaction short
+	im, err := decodeImage(original, t.limits)
 	if err != nil {
-		logging.WithError(err, logger).Warn("Error when get repo.GetSightingsByTigerID")
-		return err
+		logging.WithError(err, logger).Warn("Error when get decodeImage")
+		return apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
 	}
-	dist := geo.NewPoint(tiger.LastSeenLatitude, tiger.LastSeenLongitude).GreatCircleDistance(geo.NewPoint(sighting.Latitude, sighting.Longitude))
-	if dist > 5.00 {
-		err = fmt.Errorf("distance exceed 5000. Distance: %.2f", dist)
-		logging.WithError(err, logger).Warn("Error when get validate distance")
+	rendered, err := renderImage(im, original.ContentType, t.renditions)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get renderImage")
+		return apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
+	}
+
+	// look for near-duplicates before storing the images, which a rejected sighting doesn't need
+	sighting.ImageHash = imageHash(im)
+	if isPlainImageHash(sighting.ImageHash) {
+		logger.Info("Image of sighting is too plain to look for near-duplicates")
+		sighting.ImageHash = 0
+	} else if err = t.checkDuplicateImage(ctx, sighting); err != nil {
 		return err
 	}
 
-	// resize image into 250x200
-	resizedBase64, err := resizeBase64Image(sighting.ImageData)
-	if err != nil {
-		logging.WithError(err, logger).Warn("Error when get resizeBase64Image")
+	// store the images before the sighting, so that a stored sighting always has its images
+	sighting.ImageKey = fmt.Sprintf(SightingImageKey, sighting.TigerID, uuid.NewString(), imageExtension(original.ContentType))
+	if err = t.imageStore.Put(ctx, sighting.ImageKey, original); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from imageStore.Put")
 		return err
 	}
-	sighting.ImageData = resizedBase64
+	imageKeys := []string{sighting.ImageKey}
+	for i, rendition := range t.renditions {
+		key := imageRenditionKey(sighting.ImageKey, rendition.Name)
+		if err = t.imageStore.Put(ctx, key, rendered[i]); err != nil {
+			logging.WithError(err, logger).WithField("rendition", rendition.Name).Warn("Error when get from imageStore.Put")
+			t.deleteImages(ctx, imageKeys...)
+			return err
+		}
+		imageKeys = append(imageKeys, key)
+	}
+
+	var tigerName string
+	if err = t.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		// lock the tiger so that concurrent sightings are validated against the latest position
+		tiger, err := t.repo.GetTigerByIDForUpdate(ctx, sighting.TigerID)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get repo.GetTigerByIDForUpdate")
+			return err
+		}
+		tigerName = tiger.Name
+
+		// validate the sighting against the policy, e.g. the distance and speed from the adjacent positions
+		previous, next, err := t.adjacentWaypoints(ctx, tiger, sighting)
+		if err != nil {
+			return err
+		}
+		for _, waypoint := range []*entity.Waypoint{previous, next} {
+			if waypoint == nil {
+				continue
+			}
+			if err = t.validator.Validate(ctx, tiger, sighting, movementFrom(waypoint, sighting.SeenAt)); err != nil {
+				logging.WithError(err, logger).Warn("Error when get validate sighting policy")
+				return err
+			}
+		}
+		if previous != nil {
+			sighting.Speed = movementFrom(previous, sighting.SeenAt).Speed
+		}
+
+		if err = t.repo.CreateSighting(ctx, sighting); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.CreateSighting")
+			return err
+		}
 
-	// insert to repo
-	if err = t.repo.CreateSighting(ctx, sighting); err != nil {
-		logging.WithError(err, logger).Warn("Error when get from repo.CreateSighting")
+		// read the reporters while the tiger is locked, so that each one is notified by exactly one of concurrent sightings
+		reporters, err := t.repo.GetPriorReporters(ctx, sighting.TigerID, sighting.ReporterEmail)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.GetPriorReporters")
+			return err
+		}
+		if err = t.createOutboxEvent(ctx, entity.EventSightingRecorded, sighting.TigerID, &entity.SightingRecordedEvent{
+			SightingID:     sighting.ID,
+			TigerID:        sighting.TigerID,
+			TigerName:      tiger.Name,
+			SeenAt:         sighting.SeenAt,
+			Latitude:       sighting.Latitude,
+			Longitude:      sighting.Longitude,
+			ReporterName:   sighting.ReporterName,
+			PriorReporters: reporters,
+		}); err != nil {
+			return err
+		}
+
+		// a backdated sighting doesn't move the tiger back in time
+		if !sighting.SeenAt.After(tiger.LastSeenTimestamp) {
+			return nil
+		}
+		tiger.LastSeenTimestamp = sighting.SeenAt
+		tiger.LastSeenLatitude = sighting.Latitude
+		tiger.LastSeenLongitude = sighting.Longitude
+		if err = t.repo.UpdateTiger(ctx, tiger); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.UpdateTiger")
+			return err
+		}
+		return nil
+	}); err != nil {
+		// the sighting is not stored, so its images are not needed
+		t.deleteImages(ctx, imageKeys...)
 		return err
 	}
 
-	// update tiger data
-	tiger.LastSeenTimestamp = sighting.SeenAt
-	tiger.LastSeenLatitude = sighting.Latitude
-	tiger.LastSeenLongitude = sighting.Longitude
-	if err = t.repo.UpdateTiger(ctx, tiger); err != nil {
-		logging.WithError(err, logger).Warn("Error when get from repo.UpdateTiger")
+	t.invalidateTigerCache(ctx, sighting.TigerID)
+
+	// the image and the email of the reporter are not sent to the watchers
+	watched := &entity.WatchedSighting{Sighting: *sighting, TigerName: tigerName}
+	watched.ImageData, watched.ReporterEmail = "", ""
+	publishSighting(ctx, t.redisRepo, watched)
+
+	return nil
+}
+
+// applyImageExif fills the omitted seen time and location of the sighting from the EXIF of its jpeg image,
+// or flags the sighting as needs review if they are farther from the EXIF than the tolerance.
+// The metadata of any image is stripped, but the orientation of a jpeg image. An EXIF which can't be read is stripped too,
+// while an image whose metadata can't be stripped is rejected.
+func (t *TigerSightingService) applyImageExif(ctx context.Context, sighting *entity.Sighting, img *imagestore.Image) error {
+	logger := logging.NewServiceLogger(ctx, "applyImageExif", logrus.Fields{})
+
+	var exif *imageExif
+	if img.ContentType == "image/jpeg" {
+		var err error
+		if exif, err = readJPEGExif(img.Data); err != nil {
+			logging.WithError(err, logger).Warn("Error when get readJPEGExif")
+		}
+	}
+	orientation := 0
+	if exif != nil {
+		orientation = exif.Orientation
+	}
+	stripped, err := stripImageMetadata(img.Data, img.ContentType, orientation)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get stripImageMetadata")
+		return fmt.Errorf("image metadata can't be removed: %w", err)
+	}
+	img.Data = stripped
+	if exif == nil {
+		return nil
+	}
+
+	if !exif.SeenAt.IsZero() {
+		if validateTime(sighting.SeenAt) {
+			sighting.SeenAt = exif.SeenAt
+		} else if diff := sighting.SeenAt.Sub(exif.SeenAt); diff > t.exif.Time || diff < -t.exif.Time {
+			logger.WithField("difference", diff.String()).Info("Seen time of sighting disagrees with image EXIF")
+			sighting.NeedsReview = true
+		}
+	}
+	if exif.HasLocation {
+		if sighting.LocationOmitted {
+			sighting.Latitude, sighting.Longitude, sighting.LocationOmitted = exif.Latitude, exif.Longitude, false
+		} else if distance := greatCircleDistance(sighting.Latitude, sighting.Longitude, exif.Latitude, exif.Longitude); distance > t.exif.Distance {
+			logger.WithField("distance", distance).Info("Location of sighting disagrees with image EXIF")
+			sighting.NeedsReview = true
+		}
+	}
+	return nil
+}
+
+// checkDuplicateImage returns an error if the image of the sighting is a near-duplicate of the image of another sighting,
+// or flags the sighting as needs review if duplicates are reviewed.
+// Concurrent sightings with the same image are not serialized, so both may be accepted.
+func (t *TigerSightingService) checkDuplicateImage(ctx context.Context, sighting *entity.Sighting) error {
+	logger := logging.NewServiceLogger(ctx, "checkDuplicateImage", logrus.Fields{})
+
+	similar, err := t.repo.GetSimilarSightings(ctx, sighting.ImageHash, t.duplicates.MaxDistance, 1)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetSimilarSightings")
 		return err
 	}
+	if len(similar) == 0 {
+		return nil
+	}
+	duplicate := similar[0]
+	if t.duplicates.Review {
+		logger.WithFields(logrus.Fields{"tiger_id": duplicate.TigerID, "sighting_id": duplicate.ID}).Info("Image of sighting is a near-duplicate")
+		sighting.NeedsReview = true
+		return nil
+	}
+	return apperrors.NewPreconditionError("image is a near-duplicate of the image of sighting %d of tiger %d", duplicate.ID, duplicate.TigerID)
+}
+
+// FindSimilarImages get the sightings whose image is a near-duplicate of the searched image, order by distance then latest sighting
+// The image is decoded within the limits of the images of new sightings. Null max distance means the one of duplicates.
+// A plain image, e.g. blank, is not compared since all of them look alike.
+func (t *TigerSightingService) FindSimilarImages(ctx context.Context, query *entity.SimilarImageQuery) ([]*entity.SimilarSighting, error) {
+	logger := logging.NewServiceLogger(ctx, "FindSimilarImages", logrus.Fields{})
+
+	// validate input
+	if err := isValidSimilarImageQuery(query); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate similar image query")
+		return nil, err
+	}
+	maxDistance := t.duplicates.MaxDistance
+	if query.MaxDistance.Valid {
+		maxDistance = query.MaxDistance.Int32
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = DefaultSimilarImagesLimit
+	}
+
+	original, err := decodeImageData(query.ImageData, t.limits.MaxBytes)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get decodeImageData")
+		return nil, apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
+	}
+	im, err := decodeImage(original, t.limits)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get decodeImage")
+		return nil, apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: err.Error()})
+	}
+
+	hash := imageHash(im)
+	if isPlainImageHash(hash) {
+		logger.Info("Image is too plain to look for similar images")
+		return nil, apperrors.NewValidationError(apperrors.FieldViolation{Field: "image_data", Description: "image is too plain to be compared"})
+	}
+
+	sightings, err := t.repo.GetSimilarSightings(ctx, hash, maxDistance, limit)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetSimilarSightings")
+		return nil, err
+	}
+	return sightings, nil
+}
+
+// deleteImages removes the images stored under the keys. Errors are logged, an image left behind is only unused space.
+func (t *TigerSightingService) deleteImages(ctx context.Context, keys ...string) {
+	logger := logging.NewServiceLogger(ctx, "deleteImages", logrus.Fields{})
+
+	for _, key := range keys {
+		if err := t.imageStore.Delete(ctx, key); err != nil {
+			logging.WithError(err, logger).WithField("key", key).Warn("Error when get from imageStore.Delete")
+		}
+	}
+}
 
-	// invalidate cache
-	_ = t.redisRepo.Del(ctx, fmt.Sprintf(GetSightingsByTigerIDKey, sighting.TigerID))
-	_ = t.redisRepo.Del(ctx, GetTigersKey)
+// createOutboxEvent stores an event of the given type with the JSON encoded payload in the outbox.
+func (t *TigerSightingService) createOutboxEvent(ctx context.Context, eventType string, aggregateID int32, payload interface{}) error {
+	logger := logging.NewServiceLogger(ctx, "createOutboxEvent", logrus.Fields{"event_type": eventType})
 
+	b, err := json.Marshal(payload)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when marshal event payload")
+		return err
+	}
+	if err = t.repo.CreateOutboxEvent(ctx, &entity.OutboxEvent{Type: eventType, AggregateID: aggregateID, Payload: b}); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.CreateOutboxEvent")
+		return err
+	}
 	return nil
 }
+
+// adjacentWaypoints returns the known positions of the tiger right before and right after the sighting.
+// Nil means there is no such position. The last seen location is the previous one unless the sighting is older.
+func (t *TigerSightingService) adjacentWaypoints(ctx context.Context, tiger *entity.Tiger, sighting *entity.Sighting) (previous, next *entity.Waypoint, err error) {
+	logger := logging.NewServiceLogger(ctx, "adjacentWaypoints", logrus.Fields{})
+
+	backdated := sighting.SeenAt.Before(tiger.LastSeenTimestamp)
+	if backdated {
+		previous, next, err = t.repo.GetAdjacentSightings(ctx, sighting.TigerID, sighting.SeenAt, sighting.Latitude, sighting.Longitude)
+		if err != nil {
+			logging.WithError(err, logger).Warn("Error when get repo.GetAdjacentSightings")
+			return nil, nil, err
+		}
+		if next != nil {
+			return previous, next, nil
+		}
+		// no newer sighting, so the last seen location comes from the tiger creation and is the next one
+	}
+
+	dist, err := t.repo.GetDistanceFromLastSeen(ctx, sighting.TigerID, sighting.Latitude, sighting.Longitude)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get repo.GetDistanceFromLastSeen")
+		return nil, nil, err
+	}
+	lastSeen := &entity.Waypoint{SeenAt: tiger.LastSeenTimestamp, Distance: dist}
+	if backdated {
+		return previous, lastSeen, nil
+	}
+	return lastSeen, nil, nil
+}
+
+// invalidateTigerCache removes every cache entry that contains data of the given tiger.
+// Errors are logged only, the handler of the event stored with the change invalidates the cache again.
+func (t *TigerSightingService) invalidateTigerCache(ctx context.Context, tigerID int32) {
+	logger := logging.NewServiceLogger(ctx, "invalidateTigerCache", logrus.Fields{"tiger_id": tigerID})
+
+	if err := invalidateTigerCache(ctx, t.redisRepo, tigerID); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from invalidateTigerCache")
+	}
+}
+
+// invalidateTigerCache removes every cache entry that contains data of the given tiger.
+// Every entry is removed even if removing a previous one fails. It returns the first error.
+func invalidateTigerCache(ctx context.Context, redisRepo redis.Redis, tigerID int32) error {
+	_, err := redisRepo.Incr(ctx, fmt.Sprintf(GetSightingsByTigerIDVersionKey, tigerID))
+	if derr := redisRepo.Del(ctx, fmt.Sprintf(GetTigerByIDKey, tigerID)); err == nil {
+		err = derr
+	}
+	if _, ierr := redisRepo.Incr(ctx, GetTigersVersionKey); err == nil {
+		err = ierr
+	}
+	return err
+}
@@ -1,9 +1,24 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"math/bits"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,10 +26,13 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
 	mockRedisRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/common/redis"
+	mockImageStore "github.com/ibrahimker/tigerhall-kittens/test/mock/driver/imagestore"
 	mockRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/service"
 )
 
@@ -23,6 +41,8 @@ type SightingTestSuite struct {
 	sightingSvc  service.TigerSighting
 	redisRepo    *mockRedisRepo.MockRedis
 	sightingRepo *mockRepo.MockTigerSightingRepository
+	imageStore   *mockImageStore.MockImageStore
+	validator    *mockRepo.MockSightingValidator
 }
 
 type ServiceTestCase struct {
@@ -30,18 +50,116 @@ type ServiceTestCase struct {
 	testcaseFunction func(t *testing.T)
 }
 
+// outboxEventMatcher matches an outbox event of the given type and aggregate ID.
+type outboxEventMatcher struct {
+	eventType   string
+	aggregateID int32
+}
+
+func outboxEvent(eventType string, aggregateID int32) gomock.Matcher {
+	return outboxEventMatcher{eventType: eventType, aggregateID: aggregateID}
+}
+
+func (m outboxEventMatcher) Matches(x interface{}) bool {
+	event, ok := x.(*entity.OutboxEvent)
+	return ok && event.Type == m.eventType && event.AggregateID == m.aggregateID && json.Valid(event.Payload)
+}
+
+func (m outboxEventMatcher) String() string {
+	return fmt.Sprintf("is a %s event of aggregate %d", m.eventType, m.aggregateID)
+}
+
+// sightingImageKeyMatcher matches a new image key of a sighting of the given tiger ID with the given extension.
+// The extension of a rendition includes its name, e.g. _thumbnail.png.
+type sightingImageKeyMatcher struct {
+	tigerID int32
+	ext     string
+}
+
+func sightingImageKey(tigerID int32, ext string) gomock.Matcher {
+	return sightingImageKeyMatcher{tigerID: tigerID, ext: ext}
+}
+
+func (m sightingImageKeyMatcher) Matches(x interface{}) bool {
+	key, ok := x.(string)
+	prefix := fmt.Sprintf(service.SightingImageKey, m.tigerID, "", "")
+	if !ok || !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, m.ext) || len(key) <= len(prefix)+len(m.ext) {
+		return false
+	}
+	id := key[len(prefix) : len(key)-len(m.ext)]
+	return !strings.ContainsAny(id, "_/")
+}
+
+func (m sightingImageKeyMatcher) String() string {
+	return fmt.Sprintf("is a %s image key of a sighting of tiger %d", m.ext, m.tigerID)
+}
+
+// imageLimits are the limits of the image of a new sighting.
+var imageLimits = service.ImageLimits{MaxBytes: 1 << 20, MaxWidth: 1000, MaxHeight: 1000}
+
+// exifTolerance is how far a new sighting may be from the EXIF of its image before it needs review.
+var exifTolerance = service.ExifTolerance{Time: 10 * time.Minute, Distance: 1000}
+
+// duplicateImages rejects a new sighting whose image hash differs by at most 3 bits from the one of another sighting.
+var duplicateImages = service.DuplicateImages{MaxDistance: 3}
+
+// imageRenditions are the renditions stored with the image of a new sighting.
+var imageRenditions = []service.ImageRendition{
+	{Name: service.ImageRenditionThumbnail, Width: 100, Height: 80, Fill: true},
+	{Name: service.ImageRenditionMedium, Width: 250, Height: 200},
+	{Name: service.ImageRenditionLarge, Width: 1024, Height: 768},
+}
+
+// renditionExt returns the extension of the renditions of an image with the extension, only jpeg images keep theirs.
+func renditionExt(ext string) string {
+	if ext == ".jpeg" {
+		return ext
+	}
+	return ".png"
+}
+
+// expectStoreImages expects the image of a new sighting of the tiger with the extension to be stored with its renditions.
+func (s *SightingTestSuite) expectStoreImages(ctx context.Context, tigerID int32, ext string) {
+	s.imageStore.EXPECT().Put(ctx, sightingImageKey(tigerID, ext), gomock.Any()).Return(nil)
+	for _, rendition := range imageRenditions {
+		s.imageStore.EXPECT().Put(ctx, sightingImageKey(tigerID, "_"+rendition.Name+renditionExt(ext)), gomock.Any()).Return(nil)
+	}
+}
+
+// expectNoDuplicateImage expects the image of a new sighting to be compared with the images of the other sightings, finding none.
+func (s *SightingTestSuite) expectNoDuplicateImage(ctx context.Context) {
+	s.sightingRepo.EXPECT().GetSimilarSightings(ctx, gomock.Any(), duplicateImages.MaxDistance, int32(1)).Return(nil, nil)
+}
+
+// expectDeleteImages expects the image of a new sighting of the tiger with the extension to be deleted with its renditions.
+func (s *SightingTestSuite) expectDeleteImages(ctx context.Context, tigerID int32, ext string) {
+	s.imageStore.EXPECT().Delete(ctx, sightingImageKey(tigerID, ext)).Return(nil)
+	for _, rendition := range imageRenditions {
+		s.imageStore.EXPECT().Delete(ctx, sightingImageKey(tigerID, "_"+rendition.Name+renditionExt(ext))).Return(nil)
+	}
+}
+
+func runInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TigerSightingServiceTestSuite(ctrl *gomock.Controller) *SightingTestSuite {
 	logger := logging.NewTestLogger()
 	mockRedisRepo := mockRedisRepo.NewMockRedis(ctrl)
 	mockSightingRepo := mockRepo.NewMockTigerSightingRepository(ctrl)
+	mockImageStore := mockImageStore.NewMockImageStore(ctrl)
+	mockValidator := mockRepo.NewMockSightingValidator(ctrl)
 
-	tigerSightingService := service.NewTigerSightingService(mockSightingRepo, mockRedisRepo)
+	tigerSightingService := service.NewTigerSightingService(mockSightingRepo, mockRedisRepo, mockImageStore, imageLimits, imageRenditions, exifTolerance,
+		duplicateImages, mockValidator, 5*time.Minute)
 
 	return &SightingTestSuite{
 		logger:       logger,
 		sightingSvc:  tigerSightingService,
 		redisRepo:    mockRedisRepo,
 		sightingRepo: mockSightingRepo,
+		imageStore:   mockImageStore,
+		validator:    mockValidator,
 	}
 }
 
@@ -67,9 +185,18 @@ func TestGetTigers(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCtx := context.Background()
-	var emptyTiger []*entity.Tiger
-	tigerData := []*entity.Tiger{{ID: 1}}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tigerData := []*entity.Tiger{
+		{ID: 3, LastSeenTimestamp: now},
+		{ID: 2, LastSeenTimestamp: now.Add(-time.Hour)},
+		{ID: 1, LastSeenTimestamp: now.Add(-2 * time.Hour)},
+	}
+	filter := &entity.TigerFilter{NamePrefix: "tiger"}
 	mockTTL := 1 * time.Minute
+	expectVersion := func(suite *SightingTestSuite) {
+		suite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersVersionKey, gomock.Any(), time.Duration(0), gomock.Any()).
+			SetArg(2, int64(7)).Return(nil)
+	}
 
 	testCases := []ServiceTestCase{
 		{
@@ -78,14 +205,65 @@ func TestGetTigers(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersKey, &emptyTiger, mockTTL, gomock.Any()).
-					SetArg(2, tigerData).Return(nil)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, &entity.TigerPage{Tigers: tigerData}).Return(nil)
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx)
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 0, "")
 
 				require.NoError(t, resErr)
-				require.NotNil(t, resData)
-				require.Equal(t, tigerData, resData)
+				require.Equal(t, tigerData, resData.Tigers)
+			},
+		},
+		{
+			testcaseName: "Error invalid page size",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, service.MaxTigersPageSize+1, "")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error invalid bounding box",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				invalidFilter := &entity.TigerFilter{BoundingBox: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10}}
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, invalidFilter, 0, "")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error invalid page token",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 0, "not-a-token")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error when get cache version",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersVersionKey, gomock.Any(), time.Duration(0), gomock.Any()).
+					Return(errors.New("redis error"))
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 0, "")
+				require.Error(t, resErr)
+				require.Nil(t, resData)
 			},
 		},
 		{
@@ -94,34 +272,59 @@ func TestGetTigers(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				callbackFunc := func(ctx context.Context, key string, anyTigers *[]*entity.Tiger, ttl time.Duration, callback func() (interface{}, error)) {
-					serviceTestSuite.sightingRepo.EXPECT().GetTigers(mockCtx).Return(nil, errors.New("db error"))
+				callbackFunc := func(ctx context.Context, key string, anyPage **entity.TigerPage, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigers(mockCtx, filter, nil, int32(service.DefaultTigersPageSize+1)).Return(nil, errors.New("db error"))
 					_, _ = callback()
 				}
 
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersKey, &emptyTiger, mockTTL, gomock.Any()).Do(callbackFunc).Return(errors.New("db error"))
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(errors.New("db error"))
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx)
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 0, "")
 				require.Equal(t, errors.New("db error"), resErr)
 				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "successfully get all the data from database",
+			testcaseName: "successfully get pages from database",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				callbackFunc := func(ctx context.Context, key string, anyTigers *[]*entity.Tiger, ttl time.Duration, callback func() (interface{}, error)) {
-					serviceTestSuite.sightingRepo.EXPECT().GetTigers(mockCtx).Return(tigerData, nil)
+				var keys []string
+				firstPage := func(ctx context.Context, key string, anyPage **entity.TigerPage, ttl time.Duration, callback func() (interface{}, error)) {
+					keys = append(keys, key)
+					serviceTestSuite.sightingRepo.EXPECT().GetTigers(mockCtx, filter, nil, int32(3)).Return(tigerData, nil)
 					_, _ = callback()
 				}
+				secondPage := func(ctx context.Context, key string, anyPage **entity.TigerPage, ttl time.Duration, callback func() (interface{}, error)) {
+					keys = append(keys, key)
+					cursor := &entity.TigerCursor{LastSeenTimestamp: tigerData[1].LastSeenTimestamp, ID: tigerData[1].ID}
+					serviceTestSuite.sightingRepo.EXPECT().GetTigers(mockCtx, filter, cursor, int32(3)).Return(tigerData[2:], nil)
+					_, _ = callback()
+				}
+
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(firstPage).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 2, "")
+				require.NoError(t, resErr)
+				require.Equal(t, tigerData[:2], resData.Tigers)
+				require.NotEmpty(t, resData.NextPageToken)
 
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersKey, &emptyTiger, mockTTL, gomock.Any()).Do(callbackFunc).Return(nil)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(secondPage).Return(nil)
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetTigers(mockCtx)
+				resData, resErr = serviceTestSuite.sightingSvc.GetTigers(mockCtx, filter, 2, resData.NextPageToken)
 				require.NoError(t, resErr)
-				require.NotNil(t, resData)
+				require.Equal(t, tigerData[2:], resData.Tigers)
+				require.Empty(t, resData.NextPageToken)
+
+				require.Len(t, keys, 2)
+				require.NotEqual(t, keys[0], keys[1])
+				for _, key := range keys {
+					require.True(t, strings.HasPrefix(key, service.GetTigersKey+":7:"))
+				}
 			},
 		},
 	}
@@ -207,20 +410,37 @@ func TestCreateTiger(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
 				serviceTestSuite.sightingRepo.EXPECT().CreateTiger(mockCtx, tigerData).Return(errors.New("db error"))
 
 				resErr := serviceTestSuite.sightingSvc.CreateTiger(mockCtx, tigerData)
 				require.Equal(t, errors.New("db error"), resErr)
 			},
 		},
+		{
+			testcaseName: "Error when store outbox event",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().CreateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, gomock.Any()).Return(errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateTiger(mockCtx, tigerData)
+				require.Error(t, resErr)
+			},
+		},
 		{
 			testcaseName: "successfully insert to database",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
 				serviceTestSuite.sightingRepo.EXPECT().CreateTiger(mockCtx, tigerData).Return(nil)
-				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, service.GetTigersKey)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerCreated, 0)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
 
 				resErr := serviceTestSuite.sightingSvc.CreateTiger(mockCtx, tigerData)
 				require.NoError(t, resErr)
@@ -233,32 +453,155 @@ func TestCreateTiger(t *testing.T) {
 	}
 }
 
-func TestGetSightingsByTigerID(t *testing.T) {
+// geodesicCellHalfDiagonal is the distance in meters between the corner and the center of the cell -6.18, 106.83
+const geodesicCellHalfDiagonal = 785.0
+
+func TestSearchTigersNearby(t *testing.T) {
 	t.Parallel()
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	mockCtx := context.Background()
-	var emptySighting []*entity.Sighting
-	sightingsData := []*entity.Sighting{{ID: 1}}
+	since := time.Date(2022, 1, 1, 10, 15, 42, 0, time.UTC)
+	query := &entity.NearbyQuery{Latitude: -6.1834, Longitude: 106.8251, RadiusKm: 5, Since: since}
+	seenAt := since.Add(time.Hour)
+	// candidates around the center of the cell of the query, -6.18, 106.83, with their distance from it
+	tigerData := []*entity.NearbyTiger{
+		// 0 m from the point
+		{Tiger: entity.Tiger{ID: 1, LastSeenLatitude: -6.1834, LastSeenLongitude: 106.8251, LastSeenTimestamp: seenAt}, Distance: 650},
+		// within the radius of the center only, 5269.8 m from the point
+		{Tiger: entity.Tiger{ID: 2, LastSeenLatitude: -6.136, LastSeenLongitude: 106.83, LastSeenTimestamp: seenAt}, Distance: 4865.8},
+		// seen a few seconds before since, within the same minute
+		{Tiger: entity.Tiger{ID: 3, LastSeenLatitude: -6.1834, LastSeenLongitude: 106.8251, LastSeenTimestamp: since.Add(-time.Second)}, Distance: 650},
+		// within the radius of the point only, 4865.8 m from the point
+		{Tiger: entity.Tiger{ID: 4, LastSeenLatitude: -6.2274, LastSeenLongitude: 106.8251, LastSeenTimestamp: seenAt}, Distance: 5269.8},
+	}
+	// requireNearbyTigers checks the tigers and their distance in meters from the point of the query
+	requireNearbyTigers := func(t *testing.T, want map[int32]float64, tigers []*entity.NearbyTiger) {
+		require.Len(t, tigers, len(want))
+		for i, tiger := range tigers {
+			require.Contains(t, want, tiger.ID)
+			require.InDelta(t, want[tiger.ID], tiger.Distance, 0.1)
+			if i > 0 {
+				require.LessOrEqual(t, tigers[i-1].Distance, tiger.Distance)
+			}
+		}
+	}
 	mockTTL := 1 * time.Minute
-	tigerID := int32(1)
+	expectVersion := func(suite *SightingTestSuite) {
+		suite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersVersionKey, gomock.Any(), time.Duration(0), gomock.Any()).
+			SetArg(2, int64(7)).Return(nil)
+	}
 
 	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid query",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				invalidQuery := &entity.NearbyQuery{Latitude: 91, Longitude: 181, RadiusKm: service.MaxNearbyRadiusKm + 1, Limit: -1}
+
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, invalidQuery)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error empty radius",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, &entity.NearbyQuery{Latitude: -6.18, Longitude: 106.82})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error when get cache version",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, service.GetTigersVersionKey, gomock.Any(), time.Duration(0), gomock.Any()).
+					Return(errors.New("redis error"))
+
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, query)
+				require.Error(t, resErr)
+				require.Nil(t, resData)
+			},
+		},
 		{
 			testcaseName: "successfully get the data from redis",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID), &emptySighting, mockTTL, gomock.Any()).
-					SetArg(2, sightingsData).Return(nil)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, tigerData).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, query)
+				require.NoError(t, resErr)
+				requireNearbyTigers(t, map[int32]float64{1: 0, 4: 4865.8}, resData)
+				require.Equal(t, 650.0, tigerData[0].Distance, "cached candidates are not changed")
+			},
+		},
+		{
+			testcaseName: "successfully limit the tigers nearest to the point",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID)
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, tigerData).Return(nil)
+
+				limitedQuery := *query
+				limitedQuery.Limit = 1
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, &limitedQuery)
+				require.NoError(t, resErr)
+				requireNearbyTigers(t, map[int32]float64{1: 0}, resData)
+			},
+		},
+		{
+			testcaseName: "successfully search around the point if candidates may miss nearer tigers",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				expectVersion(serviceTestSuite)
+				// the 2 candidates of a limit of 1 contain no tiger within the radius of the point
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, tigerData[1:3]).Return(nil)
+				nearest := []*entity.NearbyTiger{{Tiger: entity.Tiger{ID: 5}, Distance: 10}}
+				serviceTestSuite.sightingRepo.EXPECT().GetTigersNearby(mockCtx, -6.1834, 106.8251, 5000.0, since, int32(1)).Return(nearest, nil)
+
+				limitedQuery := *query
+				limitedQuery.Limit = 1
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, &limitedQuery)
 				require.NoError(t, resErr)
-				require.NotNil(t, resData)
-				require.Equal(t, sightingsData, resData)
+				require.Equal(t, nearest, resData)
+			},
+		},
+		{
+			testcaseName: "Error when search around the point",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, tigerData[1:3]).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigersNearby(mockCtx, -6.1834, 106.8251, 5000.0, since, int32(1)).Return(nil, errors.New("db error"))
+
+				limitedQuery := *query
+				limitedQuery.Limit = 1
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, &limitedQuery)
+				require.Error(t, resErr)
+				require.Nil(t, resData)
 			},
 		},
 		{
@@ -267,34 +610,61 @@ func TestGetSightingsByTigerID(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				callbackFunc := func(ctx context.Context, key string, anySightings *[]*entity.Sighting, ttl time.Duration, callback func() (interface{}, error)) {
-					serviceTestSuite.sightingRepo.EXPECT().GetSightingsByTigerID(mockCtx, tigerID).Return(nil, errors.New("db error"))
+				callbackFunc := func(ctx context.Context, key string, anyTigers *[]*entity.NearbyTiger, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigersNearby(mockCtx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("db error"))
 					_, _ = callback()
 				}
 
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID), &emptySighting, mockTTL, gomock.Any()).Do(callbackFunc).Return(errors.New("db error"))
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(errors.New("db error"))
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID)
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, query)
 				require.Equal(t, errors.New("db error"), resErr)
 				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "successfully get all the data from database",
+			testcaseName: "successfully get the data from database rounded into a cell",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				callbackFunc := func(ctx context.Context, key string, anySightings *[]*entity.Sighting, ttl time.Duration, callback func() (interface{}, error)) {
-					serviceTestSuite.sightingRepo.EXPECT().GetSightingsByTigerID(mockCtx, tigerID).Return(sightingsData, nil)
-					_, _ = callback()
+				var keys []string
+				callbackFunc := func(ctx context.Context, key string, anyTigers *[]*entity.NearbyTiger, ttl time.Duration, callback func() (interface{}, error)) {
+					keys = append(keys, key)
+					serviceTestSuite.sightingRepo.EXPECT().
+						GetTigersNearby(mockCtx, gomock.Any(), gomock.Any(), gomock.Any(), since.Truncate(time.Minute), int32(2*service.DefaultNearbyTigersLimit)).
+						DoAndReturn(func(_ context.Context, latitude, longitude, radius float64, _ time.Time, _ int32) ([]*entity.NearbyTiger, error) {
+							require.InDelta(t, -6.18, latitude, 1e-9)
+							require.InDelta(t, 106.83, longitude, 1e-9)
+							// widened by the distance of any point of the cell from its center
+							require.Greater(t, radius, 5000+geodesicCellHalfDiagonal)
+							return tigerData, nil
+						})
+					res, err := callback()
+					require.NoError(t, err)
+					require.Equal(t, tigerData, res)
+					*anyTigers = tigerData
 				}
 
-				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID), &emptySighting, mockTTL, gomock.Any()).Do(callbackFunc).Return(nil)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(nil)
+				resData, resErr := serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, query)
+				require.NoError(t, resErr)
+				requireNearbyTigers(t, map[int32]float64{1: 0, 4: 4865.8}, resData)
 
-				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID)
+				// a ranger a few meters away a few seconds later shares the cell, but not the distances
+				closeQuery := &entity.NearbyQuery{Latitude: -6.1811, Longitude: 106.8264, RadiusKm: 5, Since: since.Add(10 * time.Second)}
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(nil)
+				resData, resErr = serviceTestSuite.sightingSvc.SearchTigersNearby(mockCtx, closeQuery)
 				require.NoError(t, resErr)
-				require.NotNil(t, resData)
+				requireNearbyTigers(t, map[int32]float64{1: 292.2}, resData)
+
+				require.Len(t, keys, 2)
+				require.Equal(t, keys[0], keys[1])
+				require.True(t, strings.HasPrefix(keys[0], service.SearchTigersNearbyKey+":candidates:7:-6.18:106.83:5:"))
 			},
 		},
 	}
@@ -304,147 +674,1116 @@ func TestGetSightingsByTigerID(t *testing.T) {
 	}
 }
 
-func TestCreateSighting(t *testing.T) {
+func TestGetTiger(t *testing.T) {
 	t.Parallel()
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	mockCtx := context.Background()
+	var emptyTiger *entity.Tiger
 	tigerID := int32(1)
-	tigerData := &entity.Tiger{Name: "tiger-1",
-		DateOfBirth: time.Now(), LastSeenTimestamp: time.Now(), LastSeenLatitude: -6.18, LastSeenLongitude: 106.0}
-	imageData := "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAZAAAACWCAYAAADwkd5lAAAAAXNSR0IArs4c6QAAFFhJREFUeF7tnVloZEUXx08mo04cMoZJPkYaEURwwQ0XFBUXXFAQRURFRXFXRHBHUR8EUR8U3MB9Qdwe1AfFHRFxQ8GFuKEo+iC2iTMdF8Zxos5MPk59XzqddHeq7q3tVNW/YUDn3lt1zu+cqn+dczuZgfHx8ZmNGzfS0NAQLV++nJYuXUr4gAAIxCUwQ0QDcU3A7CDQRWDDhg20bt06Wr9+PQ0ODtJAs9mcWbVqFU1NTVGr1VIPjI2N0ejoqLohiw9WY+AwArhz4EDqHGm/AYF6PhkuMHrpw+rVq/8nII1Go/0EKwsLCT8wPDyshGRkZCRY8DBRQgSw0hIKFkwFgWoEfv/9d6UDa9euVTrAhQV3qmY/ExMT3QLSOYVugGrm4O6iCUBsooc/3RCka3n0oFc0oEoBoRWQ2bn7lTDZtLgqQpZ0u7SlJc0eSbGCLXMEkCe+s8GccN393VhAOl2tolC+EWH8PAmYp36e/sMrEAhBwLbDVEtAYre4st9csnewxtIAkxrQ8AgIdBNwWQBYCwhaXEhRPQHs/npGuAME/BGo26LSWeRMQNDi0qHGdRDoTwASu3h2gE+91WPbotLN6kVAYre4dE7jenkEem5A2JXKS4QCPHbZotLh8i4gaHHpQoDrICCNAJRVWkR09vhqUenmDSYgaHHNEsDi1CUlroMACJgR6NWiWjY0FOxX4EQRELS4zJIDd8kgAMmXEYdSrNDlW8gWlY55dAFBi0sXIlwHARAonUCsFpWOuxgBQYtLFypcBwEQKI2A729R2fIUKSBocdmGFc+DAAikSkBSi0rHULyAoMWlCyGugwAIpE5AaotKxzUZAUGLSxdKXAcBEEiNgPQWlY5nkgKCFpcurLgOAiAglUBKLSodw+QFBC0uXYhxHQRAIDYBly0q3dd8Q/qajYCgxRUybTAXCICACYHUW1Q6H7MUkLRbXJ7OF56G1SUYroNAkgQs1sv66fXUWlPGPwmevYCgxZXk8oXRXQQsdrT/j2U/QuCwJGSwyxZVYMpW0xUjIGhxWeUJHgYBEOhBIPcWlS7oRQpI2i0uXUhLvZ7QcbV2iErw0RBORBQ5fYvKFmPxAoIWl+GCxW0gkCoB212SiEptUelC7k5AHARJZ6yr6zpTrU4YusFdOdFjnIhTe/QKQysCCG6URCi9RaWD3ldApOVrLHuCJVAsB3UZgusgEJpA5LVgdYAMzSryfO4qkMiO+J4eJaxvwq7Gj7z7uHID4wQlEGJ955eZMzQxMUkDzWZzptFoBA1YypP5P6Hkl2opxxu250sgWIchU4SoQCwDiwS0BIjHQSAwAdsDII53cwFDBeIoea1KYGSkoyhgGBDoTcBqfQJqXwIFVCDhd2fbEw7yFQRAwA0BdAjccOw3SgEC4hOgXpyQwD75Y2wQ6CaAA1y4rICABGJdt4TWS5QHB6JM6sEPDFkMgbrrqxhAnhyFgHgCu9iwOCFFgI4psySACj9uWL0LCA6ziwcYCyDuAsDs6RHAAUxOzLwLiBxXZVuCElx2fGBdXAJYH3H5p/MSHSUL4YRlsViQPxbw5D2KCt1nTBYslhprBxWIz/g4GBsLyAFEDJEUAYkHqBp7qz/mgoyBgPgLs9ORUcI7xYnBhBEwz29Bu6cwhjHMgYDEoG45Z78TGpaWJVjJj2ca3FIq7EzDRxAQyZuGgW2lLEADFH5uyXXl+6FlNKrEFpWR4bipiwAEJJOkMG8BZOIw3EiKQN38hH7LDnMfAUHYZIdtcetwwqsfPWR+fXa9nkSF7JZn52gSchUViL/4ihgZC1hEGIoyAgeYcsINASkk1nVbCHo8Es5Beitxh18C/vLLr90Y3Y4ABMSOX5JP44SYZNicGe1S8l1UuFp7tDcsjsbycWfccxwIApJjVCv45GIDqDAdbs2AAA4gGQTRkQsQEEcgUx8GLYiEIxjgiI38SDg/PJouQ0ACLACPDLMbuuQTJlJxfjqjQs1ueTt1SIaAOHVJ2mBpb0nYQKTlk397Sj5A+KcraAYHWxMERFA8JZsSr4XhIMslgxViW9j4IqZCwm5tBgTEGmF5A+CEmk/MUWHmE8sYnkBAYlDPaE5sQOkFEweA9GIm1WIIiNTIJGZX2BZIYnAEmIv4CAhChiZAQDIMamyXcMKNHYG5+VEhyolFjpZAQFxGFe8Gu2hiA3OZYGZjQcDNOOEuewIQEHuGGMGAQNotFPkng7T5GiQQbhFJAAIiMix5GNVv2613Qpa/iceIWswKDxGJEXFZc0JAZMWjOGtiboCpwq4nwKl6C7slE4CASI5OQbahBbN4sMGnoMWQkKsTkxM00Gw2ZxqNhjuzbWpbm2fdeYCRIhLACXsOPiq0iImIqbUEUIFoEeGGmARK20D5/DS9fj21Wi2ampqi4eEVNDq6kkZGRmKGAXODQE8CwgUkxXIkRZvlr47cWzi5+yc/w2BhHQLCBaSOS3hGHAHHmppCi8vU5dIqLHG5CYOsCEBArPAt8rDpDuJrfttxE7E/xQ04BQG0TR88nzOBuc0BApJznAvyTXoLKJZ9iZwDCsrUvFyFgOQVT3hDRCFP+LoNOsUKCUkEAqYEICCmpHCfIQHdlmo4jKPbYmzgIQXMESYM44CArMx34JDBEBkLSInhNIh4obeoFlJrilpTLUVgbGyMRkdHaXBwsINI/ZyJ1aIqNJxw2wuB6vmfsYB4IYxBMyDgskKIUeFkEAK4kAmBcgWkuthmEnK40UmgjgC4FCBEoywCuW07bgUkNzpl5XbR3upaULrrXfCwForOp1KcdysgpVCDn1kT6Kwwli1bpnydnp5W70z43cnQ0FDW/sM5EOhNoPtUVJCAFHIkLMRNn0scAuKTLsbOiUBBAlI3bNiR65JL6Tldi0p3PSVfYWtkAoZbiuFtUZ2BgETFj8ljE0j7Jbr5FmN+Z+yIYP6UCFQTEGRhSrGFrX0IuPwWVR0Byj8w2Cjkx9hNjKoJiHwqYix0Ex4x7iRviO8WlO/xkw9ABQc6146cdSTHkgoovd8KAfGOGBPEJBCjQnBZ4cRkh7lBQEcAAqIjhOueCbg/2UnawGMImOeAYXgQaBOAgCAZsiAgvYUk3b4skgBOBCcAAQmOHBO6JJDiCV9SheQyFhirPAIQkPJinrzHOW3AKQpg8gmUhAPuW7s+3J6YmKSBZrM502g0fIyPMUHACYHcW0C5++ckCTCIOAKoQMSFBAZ1EijxhJ5ThRUsm9M4sFfHIdwvCEj1kOIJRcBfZmMDnUuxEgUUCywdAoYC4m+zSAdViZaGi3tJLZw6VIPwqWNYicuiIJ91KWEoIAURg6tBCcg/YeuWUFBcajJUaOGZY8beBCAgyIzgBLABukMuX4Dd+ZrbSPKOJtUJhxeQHKhV51z8E0FaMAVTBt9cgy97wwwvILnGGX71JIATcgcWy73A9PF0KjxTj7C4pBLIR0CQi2JyLJ0NTAwyb4ZAwL2hxcBEJERAhO/+ws2TkMnptlDKCG668ZGQ3b1t8J05vsd3QVaIgLhwBWPEIJDPCTeF5eomwqgQy4n14hljzwEC4mZNFjVK3A3IPumrBks7o/aGqjP6uL+3kX4OAEkA8QG5uDGzERCkrN/c9dMCQdT8Rs18dD/xNZkfOWBCSeo9E5MT+GWKiwan8Pw2OaEWjkjq2v6/XdWjE7fCFI4zkHnVoxbIsAXTZFOBxMHXOWsqIdeTwgaiZ1TKHSYHCN8sTFaWyT0+7Iw1rw9f6owJAalDLcNn4rUwMoS50KUMdhnkRwF5WsNFCEgNaDk9YnbCzGAHzClokX1BhRo5AIKmh4DU7hQLimJFU7ABVASG2/sSMDuAAGCuBCAguUZ2gV9oQRQS6EhuIr8igY88rWABqdE2qfFIZP7ep8cJ0TtiTLCAQFEVbuF7jmABwbqsSyDKAva6kLwOXhcznjMggAOMAaSEb4GA9AteYnsWWggJr8IkTa+2QPLNz2ocZIe6ui8eBKS6EbKhyrYOJzzZ8YF13QSiVMgIhBcCHgTEi50YtIMAFiDSIQYBH0fDbA5APuDECHLFOSEgFYHFuj3fFkAsophXEgHkt6RomNviTUAKFWRz8oZ3ZnNCM/QXt4EAKux0csCbgKSDQJ6lWEDyYgKL4hDAAaoHd0GncwhInHXRNeviJbygjBHCC2bkTmB+zqPF5TjejrYUCIjjuFQdDiesqsRwf+kE3Fbo3Tupo721iDD1EBDg8x15twvAt7UYHwTkEsABLG5sUIEE4o8SPBBoTFMkgVLXV+zjPgTE83LDCckz4KyGj70d5AEzmQo/g3BDQDysmWQS2IPvGDIegQz2I+fwcIBzjnTegBAQR3xLLaEd4cMwIOCVANann+MFBMQybXHCsQRY0uN+1nBJBJ34ig6BE4xqEAhIDZZ+EzDALhNgihpY8QgIBCeAA6AdcgiIIb88SmAoh2G4a9xmw9bm2Rqm4pEuAnms7/CBFSkgkpYTTijhkxIzgkBMAn47DDE9cz+3SAFx72a1EZFA1XjhbjsCkg5Mdp70ejpt76wPkGm7r02HbgGJ7nAcA3qVsGOjo7RkcFALMakb4uBNClGZxiIxFou7zxZXyuSLr0D6nTB8B9X3+GVugql7nUpWpGKnn3xAh2KOa5ECggTws7BKHrW0LbU0f/vltnWLK/FFk5aAVM3ajvt9lqCJ50Bt86uGo/ZEeBAEhBPoub+MjdLgksxa4AvikJaA1Eii0k8INZDhERAAAQsCJXU4shQQlwHEKdtiJTl7FFFwhhIDBSWQ+wE2GwHZtHEjtaamqNVqqQQZGxuj0dFRGsztW1RB0x+TgQAIuCCQaws9eQERp/A4LLtYbxgDBLIl0K9DkuLWkaSAuGxRZZulcAwEQEA8gagH4BmimQGiAQtKgQWkvsbmWgJaxA6PggAIZEJA/P7WZ+sOLCDVox1VoaubiydAAARAwIpASh0WkQKSEkCrTCGi+jWZ7cxSngcBKZGAHfII1D5AB1pWYgREfAknL7eysShQrmfDC44kTqBGwkvdH+0EpAaIhaE3VlgHcyWedv7MB1t/bDEyCDgmIKlDYycgNcFIAlDTBTwmnQBEUXqEDOxDEHWQjA/guoFqXg8mIFJLsJrc8BgIgIBXAmWIxx9//EG77rorHXPMMXT//fcrovzD0Oeffz699dZbtPnmm9O5555Lt9xyCw0MDNCmTZvommuuoccee4z+/fdfOvzww+nhhx+mFStW0JTmB6nfe+89OuOMM2jvvfem5557rh292267ja699lpaunRp++9OPvlkevzxx9X/P/HEE3TDDTcou3baaSd66KGHaI899lDXvAtIbIX0muMYPCyBMvaUsEwxW1QCZ599Nr377rt0xBFHtAXkpJNOouXLl9N9991HvH8edthhdOWVV9J5551H99xzDz344IP0+uuv01ZbbUXnnHOO+m0bTz75ZNuPXh2eV155he68807aeeedad26dfME5Prrr6fffvuN7r333i4WX3zxBR188MH06quv0r777qvmv/322+nbb7+lzTbbzI+AoEUVNScxOQiAQAIEXn75Zbr55pvp6KOPpp9//lkJyF9//UUjIyP0ww8/0DbbbKO84BP/008/rSqSAw88kC666CI6/fTT1bXvvvuOdtttN+JKhsWEKxEWHv6ceeaZtGHDBrrxxhvp448/pn322UdVFV999dU8Abn44ouVGHGVs/DD4vLLL7+oKmf2w3Y99dRTdMghh7gTELSoDDIWJ2gDSLhljgASJtds4BM/b+hcGTz77LP0008/KQH5/PPPlUisXbu27TpXKCeeeKLayFeuXElvvPGGakPxZ2ZmRrW5+Lmtt96adt99d3rmmWdoenqauLrhv2dRmd2fb7rpJvrmm29UxTL7uwJPO+00ajabqgrhNth+++2nqpVtt92WTjjhBGUPV0CzH26bsT0sZNYtLLSock1x+AUCIOCLAFcQe+21F11xxRXEm/qsgHzwwQd03HHH0Zo1a9pTf/LJJ+q0/+eff9IWW2xBn376Ke2yyy7t68PDw6o6YUF67bXX6Oqrr1bvR7jdxO2vzg/PxePdfffdSiz4WRYw7hpdfvnlqi116aWXqjn4z1FHHaXs4Spl9nPssccqe6666qp6AoIWla+0wrggAAK5E3j++efpjjvuUJv+kiVL5gkIv3Pg6uKff/5pY3jzzTdVy4pP+1w1vPjii3TAAQeo69yi4grk66+/ph133FH9Hb+U5/cin332WRdKFpDx8fF2C6tXAcD7O8/z448/0mWXXabsue6669pjHXTQQcqeCy+80FxA5rWoZojG/pPhr0tPumOQtPG57xnwDwTaBE455RR655131MbPH35/wULAL6lfeukl9Q6EhWSHHXZQ17mdxO9LuHXFmzd/k+qCCy5Q11gMuMXE7Sce74EHHmi3sM466yz1ba6FFUingPC1Dz/8kLbffnvVDuNvWvEfrjC4rcXvU77//nv1DoY/rAOrVq2iF154Qc2rbWGhRYXMBwEQAAF/BDpbWDwLv5Pgr+s+8sgjqpXF7xz4nlNPPVV9A4urFxYTfvHNlQC/+2Dh4Bfv+++/P3Eb7O+//1Zi89FHH9F2223XNn5hBcIX+CU+//tJPDZ/VZjFid+T8Mt7fu/C3wpjAeOx+Su//LXeL7/8UlVPPQUELSp/yYKRQQAEQGBhVTD7DoT/nqsJ/soui8TQ0BBdcsklxN+G4g9XCfzfvNnzew5+H8Ev37fccks69NBD6fjjj1fvVfjD377i9he3yo488kh6//33VQXB4sTvOrjlxW2uyclJ9Y7j7bffVq0v/truXXfdRY1GQ32NmL+5deutt9Kvv/5Ke+65Jz366KPtdllbQLgs0f0gCsKeKAF0txINHMwGARkE+n3LdvXq1TQwPj4+wzewivEPsHT+RKKZ+fxPkvAuhQ8IgAAIgEDOBPh9Df8wIv/MClcs/wXIZT3M35g24AAAAABJRU5ErkJggg=="
-	sightingData := &entity.Sighting{TigerID: tigerID, SeenAt: time.Now(), Latitude: -6.18, Longitude: 106.0,
-		ImageData: imageData}
+	tigerData := &entity.Tiger{ID: tigerID}
+	mockTTL := 1 * time.Minute
 
 	testCases := []ServiceTestCase{
 		{
-			testcaseName: "Error invalid tigerID",
+			testcaseName: "successfully get the data from redis",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.TigerID = 0
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID), &emptyTiger, mockTTL, gomock.Any()).
+					SetArg(2, tigerData).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTiger(mockCtx, tigerID)
+
+				require.NoError(t, resErr)
+				require.Equal(t, tigerData, resData)
 			},
 		},
 		{
-			testcaseName: "Error invalid seen at timestamp",
+			testcaseName: "Error tiger not found",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.SeenAt = time.Unix(0, 0)
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+				callbackFunc := func(ctx context.Context, key string, anyTiger **entity.Tiger, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(nil, entity.ErrTigerNotFound)
+					_, _ = callback()
+				}
+
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID), &emptyTiger, mockTTL, gomock.Any()).Do(callbackFunc).Return(entity.ErrTigerNotFound)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTiger(mockCtx, tigerID)
+				require.ErrorIs(t, resErr, entity.ErrTigerNotFound)
+				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error invalid latitude",
+			testcaseName: "successfully get the data from database",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.Latitude = 200.0
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+				callbackFunc := func(ctx context.Context, key string, anyTiger **entity.Tiger, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
+					_, _ = callback()
+				}
+
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID), &emptyTiger, mockTTL, gomock.Any()).Do(callbackFunc).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetTiger(mockCtx, tigerID)
+				require.NoError(t, resErr)
+				require.Equal(t, tigerData, resData)
 			},
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestUpdateTiger(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	dateOfBirth := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	currentTiger := func() *entity.Tiger {
+		return &entity.Tiger{ID: tigerID, Name: "tiger-1", DateOfBirth: dateOfBirth,
+			LastSeenTimestamp: time.Now(), LastSeenLatitude: -6.18, LastSeenLongitude: 106.0}
+	}
+	update := &entity.Tiger{ID: tigerID, Name: "tiger-2", DateOfBirth: dateOfBirth.AddDate(1, 0, 0),
+		MaxSightingDistance: sql.NullFloat64{Float64: 20000, Valid: true}}
+
+	testCases := []ServiceTestCase{
 		{
-			testcaseName: "Error invalid longitude",
+			testcaseName: "Error unknown field in update mask",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.Longitude = 200.0
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, []string{"last_seen_latitude"})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error empty image data",
+			testcaseName: "Error tiger not found",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.ImageData = ""
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(nil, entity.ErrTigerNotFound)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, []string{"name"})
+				require.ErrorIs(t, resErr, entity.ErrTigerNotFound)
+				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error no tiger in database",
+			testcaseName: "Error invalid name",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(nil, errors.New("db error"))
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
 
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
-				require.Error(t, resErr)
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, &entity.Tiger{ID: tigerID}, []string{"name"})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error distance exceed 5 km",
+			testcaseName: "Error when update database",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.Latitude = -8.10
-
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, gomock.Any()).Return(errors.New("db error"))
 
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, []string{"name"})
 				require.Error(t, resErr)
+				require.Nil(t, resData)
 			},
 		},
 		{
-			testcaseName: "Error invalid base64 image",
+			testcaseName: "successfully update fields in update mask",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-				sightingData2 := *sightingData
-				sightingData2.ImageData = "this-is-invalid-base64-image"
-
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
-
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
-				require.Error(t, resErr)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, gomock.Any()).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerUpdated, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, []string{"name"})
+				require.NoError(t, resErr)
+				require.Equal(t, "tiger-2", resData.Name)
+				require.Equal(t, dateOfBirth, resData.DateOfBirth)
 			},
 		},
 		{
-			testcaseName: "Error when insert to database",
+			testcaseName: "successfully update all fields with empty update mask",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
-				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(errors.New("db error"))
-
-				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
-				require.Error(t, resErr)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, gomock.Any()).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerUpdated, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, nil)
+				require.NoError(t, resErr)
+				require.Equal(t, "tiger-2", resData.Name)
+				require.Equal(t, update.DateOfBirth, resData.DateOfBirth)
+				require.False(t, resData.MaxSightingDistance.Valid)
 			},
 		},
 		{
-			testcaseName: "Error when update tiger data",
+			testcaseName: "successfully update tiger name by default, keeping its date of birth when omitted",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
-
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
-				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
-				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(errors.New("error db"))
-
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, gomock.Any()).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerUpdated, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, &entity.Tiger{ID: tigerID, Name: "tiger-2"}, nil)
+				require.NoError(t, resErr)
+				require.Equal(t, "tiger-2", resData.Name)
+				require.Equal(t, dateOfBirth, resData.DateOfBirth)
+			},
+		},
+		{
+			testcaseName: "Error date of birth in update mask is omitted",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, &entity.Tiger{ID: tigerID}, []string{"date_of_birth"})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error invalid max sighting distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+
+				invalid := &entity.Tiger{ID: tigerID, MaxSightingDistance: sql.NullFloat64{Float64: -1, Valid: true}}
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, invalid, []string{"max_sighting_distance"})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "successfully override max sighting distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(currentTiger(), nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, gomock.Any()).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerUpdated, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.UpdateTiger(mockCtx, update, []string{"max_sighting_distance"})
+				require.NoError(t, resErr)
+				require.Equal(t, "tiger-1", resData.Name)
+				require.Equal(t, update.MaxSightingDistance, resData.MaxSightingDistance)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestDeleteTiger(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().DeleteTiger(mockCtx, tigerID).Return(entity.ErrTigerNotFound)
+
+				resErr := serviceTestSuite.sightingSvc.DeleteTiger(mockCtx, tigerID)
+				require.ErrorIs(t, resErr, entity.ErrTigerNotFound)
+			},
+		},
+		{
+			testcaseName: "Error when create outbox event",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().DeleteTiger(mockCtx, tigerID).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerDeleted, tigerID)).Return(errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.DeleteTiger(mockCtx, tigerID)
+				require.EqualError(t, resErr, "db error")
+			},
+		},
+		{
+			testcaseName: "successfully delete tiger",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().DeleteTiger(mockCtx, tigerID).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerDeleted, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.DeleteTiger(mockCtx, tigerID)
+				require.NoError(t, resErr)
+			},
+		},
+		{
+			testcaseName: "successfully delete tiger whose cache is left to the event handler when redis fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().DeleteTiger(mockCtx, tigerID).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventTigerDeleted, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(0), errors.New("redis error"))
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(0), errors.New("redis error"))
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(errors.New("redis error"))
+
+				resErr := serviceTestSuite.sightingSvc.DeleteTiger(mockCtx, tigerID)
+				require.NoError(t, resErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestGetSightingsByTigerID(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	sightingsData := []*entity.Sighting{
+		{ID: 3, SeenAt: now},
+		{ID: 2, SeenAt: now.Add(-time.Hour)},
+		{ID: 1, SeenAt: now.Add(-2 * time.Hour)},
+	}
+	filter := &entity.SightingFilter{SeenFrom: now.Add(-24 * time.Hour)}
+	mockTTL := 1 * time.Minute
+	tigerID := int32(1)
+	expectVersion := func(suite *SightingTestSuite) {
+		suite.redisRepo.EXPECT().Fetch(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID), gomock.Any(), time.Duration(0), gomock.Any()).
+			SetArg(2, int64(7)).Return(nil)
+	}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "successfully get the data from redis",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, &entity.SightingPage{Sightings: sightingsData}).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 0, "")
+
+				require.NoError(t, resErr)
+				require.Equal(t, sightingsData, resData.Sightings)
+			},
+		},
+		{
+			testcaseName: "successfully leave the images empty unless asked",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				cached := []*entity.Sighting{{ID: 3, SeenAt: now, ImageKey: "sighting/1/c.png"}}
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, &entity.SightingPage{Sightings: cached}).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, nil, 0, "")
+
+				require.NoError(t, resErr)
+				require.Equal(t, "sighting/1/c.png", resData.Sightings[0].ImageKey)
+				require.Empty(t, resData.Sightings[0].ImageData)
+			},
+		},
+		{
+			testcaseName: "successfully get the images from the image store",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				cached := []*entity.Sighting{
+					{ID: 3, SeenAt: now, ImageKey: "sighting/1/c.png"},
+					{ID: 2, SeenAt: now.Add(-time.Hour), ImageKey: "sighting/1/b.jpeg"},
+					{ID: 1, SeenAt: now.Add(-2 * time.Hour), ImageData: "data:image/png;base64,bGVnYWN5"},
+					{ID: 0, SeenAt: now.Add(-3 * time.Hour), ImageKey: "sighting/1/0.jpeg"},
+				}
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, &entity.SightingPage{Sightings: cached}).Return(nil)
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, "sighting/1/c_medium.png").
+					Return(&imagestore.Image{Data: []byte("png"), ContentType: "image/png"}, nil)
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, "sighting/1/b_medium.jpeg").Return(nil, imagestore.ErrNotFound)
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, "sighting/1/b.jpeg").Return(nil, imagestore.ErrNotFound)
+				// images moved from database have no renditions
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, "sighting/1/0_medium.jpeg").Return(nil, imagestore.ErrNotFound)
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, "sighting/1/0.jpeg").
+					Return(&imagestore.Image{Data: []byte("jpeg"), ContentType: "image/jpeg"}, nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, &entity.SightingFilter{IncludeImageData: true}, 0, "")
+
+				require.NoError(t, resErr)
+				require.Equal(t, "data:image/png;base64,cG5n", resData.Sightings[0].ImageData)
+				require.Empty(t, resData.Sightings[1].ImageData)
+				require.Equal(t, "data:image/png;base64,bGVnYWN5", resData.Sightings[2].ImageData)
+				require.Equal(t, "data:image/jpeg;base64,anBlZw==", resData.Sightings[3].ImageData)
+			},
+		},
+		{
+			testcaseName: "successfully get the images from the image store concurrently",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				cached := []*entity.Sighting{
+					{ID: 3, SeenAt: now, ImageKey: "sighting/1/c.png"},
+					{ID: 2, SeenAt: now.Add(-time.Hour), ImageKey: "sighting/1/b.png"},
+					{ID: 1, SeenAt: now.Add(-2 * time.Hour), ImageKey: "sighting/1/a.png"},
+				}
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).
+					SetArg(2, &entity.SightingPage{Sightings: cached}).Return(nil)
+				// every read waits for the others to start, which never happens if they are read one at a time
+				var started sync.WaitGroup
+				started.Add(len(cached))
+				allStarted := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(allStarted)
+				}()
+				serviceTestSuite.imageStore.EXPECT().Get(mockCtx, gomock.Any()).Times(len(cached)).
+					DoAndReturn(func(context.Context, string) (*imagestore.Image, error) {
+						started.Done()
+						select {
+						case <-allStarted:
+							return &imagestore.Image{Data: []byte("png"), ContentType: "image/png"}, nil
+						case <-time.After(5 * time.Second):
+							return nil, errors.New("images are not read concurrently")
+						}
+					})
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, &entity.SightingFilter{IncludeImageData: true}, 0, "")
+
+				require.NoError(t, resErr)
+				for _, sighting := range resData.Sightings {
+					require.Equal(t, "data:image/png;base64,cG5n", sighting.ImageData)
+				}
+			},
+		},
+		{
+			testcaseName: "Error invalid seen time window",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				invalidFilter := &entity.SightingFilter{SeenFrom: now, SeenTo: now.Add(-time.Hour)}
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, invalidFilter, 0, "")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error invalid page token",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 0, "not-a-token")
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				callbackFunc := func(ctx context.Context, key string, anyPage **entity.SightingPage, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(nil, entity.ErrTigerNotFound)
+					_, _ = callback()
+				}
+
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(entity.ErrTigerNotFound)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 0, "")
+				require.ErrorIs(t, resErr, entity.ErrTigerNotFound)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "Error when retrieve from database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				callbackFunc := func(ctx context.Context, key string, anyPage **entity.SightingPage, ttl time.Duration, callback func() (interface{}, error)) {
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(&entity.Tiger{ID: tigerID}, nil)
+					serviceTestSuite.sightingRepo.EXPECT().GetSightingsByTigerID(mockCtx, tigerID, filter, nil, int32(service.DefaultSightingsPageSize+1)).Return(nil, errors.New("db error"))
+					_, _ = callback()
+				}
+
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(callbackFunc).Return(errors.New("db error"))
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 0, "")
+				require.Equal(t, errors.New("db error"), resErr)
+				require.Nil(t, resData)
+			},
+		},
+		{
+			testcaseName: "successfully get pages from database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				var keys []string
+				firstPage := func(ctx context.Context, key string, anyPage **entity.SightingPage, ttl time.Duration, callback func() (interface{}, error)) {
+					keys = append(keys, key)
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(&entity.Tiger{ID: tigerID}, nil)
+					serviceTestSuite.sightingRepo.EXPECT().GetSightingsByTigerID(mockCtx, tigerID, filter, nil, int32(3)).Return(sightingsData, nil)
+					_, _ = callback()
+				}
+				secondPage := func(ctx context.Context, key string, anyPage **entity.SightingPage, ttl time.Duration, callback func() (interface{}, error)) {
+					keys = append(keys, key)
+					cursor := &entity.SightingCursor{SeenAt: sightingsData[1].SeenAt, ID: sightingsData[1].ID}
+					serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(&entity.Tiger{ID: tigerID}, nil)
+					serviceTestSuite.sightingRepo.EXPECT().GetSightingsByTigerID(mockCtx, tigerID, filter, cursor, int32(3)).Return(sightingsData[2:], nil)
+					_, _ = callback()
+				}
+
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(firstPage).Return(nil)
+
+				resData, resErr := serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 2, "")
+				require.NoError(t, resErr)
+				require.Equal(t, sightingsData[:2], resData.Sightings)
+				require.NotEmpty(t, resData.NextPageToken)
+
+				expectVersion(serviceTestSuite)
+				serviceTestSuite.redisRepo.EXPECT().Fetch(mockCtx, gomock.Any(), gomock.Any(), mockTTL, gomock.Any()).Do(secondPage).Return(nil)
+
+				resData, resErr = serviceTestSuite.sightingSvc.GetSightingsByTigerID(mockCtx, tigerID, filter, 2, resData.NextPageToken)
+				require.NoError(t, resErr)
+				require.Equal(t, sightingsData[2:], resData.Sightings)
+				require.Empty(t, resData.NextPageToken)
+
+				require.Len(t, keys, 2)
+				require.NotEqual(t, keys[0], keys[1])
+				for _, key := range keys {
+					require.True(t, strings.HasPrefix(key, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID)+":7:"))
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestCreateSighting(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	seenAt := time.Now()
+	lastSeen := seenAt.Add(-time.Hour)
+	currentTiger := func() *entity.Tiger {
+		return &entity.Tiger{ID: tigerID, Name: "tiger-1",
+			DateOfBirth: time.Now(), LastSeenTimestamp: lastSeen, LastSeenLatitude: -6.18, LastSeenLongitude: 106.0}
+	}
+	stillMovement := &entity.Movement{Speed: sql.NullFloat64{Valid: true}}
+	imageData := "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAZAAAACWCAYAAADwkd5lAAAAAXNSR0IArs4c6QAAFFhJREFUeF7tnVloZEUXx08mo04cMoZJPkYaEURwwQ0XFBUXXFAQRURFRXFXRHBHUR8EUR8U3MB9Qdwe1AfFHRFxQ8GFuKEo+iC2iTMdF8Zxos5MPk59XzqddHeq7q3tVNW/YUDn3lt1zu+cqn+dczuZgfHx8ZmNGzfS0NAQLV++nJYuXUr4gAAIxCUwQ0QDcU3A7CDQRWDDhg20bt06Wr9+PQ0ODtJAs9mcWbVqFU1NTVGr1VIPjI2N0ejoqLohiw9WY+AwArhz4EDqHGm/AYF6PhkuMHrpw+rVq/8nII1Go/0EKwsLCT8wPDyshGRkZCRY8DBRQgSw0hIKFkwFgWoEfv/9d6UDa9euVTrAhQV3qmY/ExMT3QLSOYVugGrm4O6iCUBsooc/3RCka3n0oFc0oEoBoRWQ2bn7lTDZtLgqQpZ0u7SlJc0eSbGCLXMEkCe+s8GccN393VhAOl2tolC+EWH8PAmYp36e/sMrEAhBwLbDVEtAYre4st9csnewxtIAkxrQ8AgIdBNwWQBYCwhaXEhRPQHs/npGuAME/BGo26LSWeRMQNDi0qHGdRDoTwASu3h2gE+91WPbotLN6kVAYre4dE7jenkEem5A2JXKS4QCPHbZotLh8i4gaHHpQoDrICCNAJRVWkR09vhqUenmDSYgaHHNEsDi1CUlroMACJgR6NWiWjY0FOxX4EQRELS4zJIDd8kgAMmXEYdSrNDlW8gWlY55dAFBi0sXIlwHARAonUCsFpWOuxgBQYtLFypcBwEQKI2A729R2fIUKSBocdmGFc+DAAikSkBSi0rHULyAoMWlCyGugwAIpE5AaotKxzUZAUGLSxdKXAcBEEiNgPQWlY5nkgKCFpcurLgOAiAglUBKLSodw+QFBC0uXYhxHQRAIDYBly0q3dd8Q/qajYCgxRUybTAXCICACYHUW1Q6H7MUkLRbXJ7OF56G1SUYroNAkgQs1sv66fXUWlPGPwmevYCgxZXk8oXRXQQsdrT/j2U/QuCwJGSwyxZVYMpW0xUjIGhxWeUJHgYBEOhBIPcWlS7oRQpI2i0uXUhLvZ7QcbV2iErw0RBORBQ5fYvKFmPxAoIWl+GCxW0gkCoB212SiEptUelC7k5AHARJZ6yr6zpTrU4YusFdOdFjnIhTe/QKQysCCG6URCi9RaWD3ldApOVrLHuCJVAsB3UZgusgEJpA5LVgdYAMzSryfO4qkMiO+J4eJaxvwq7Gj7z7uHID4wQlEGJ955eZMzQxMUkDzWZzptFoBA1YypP5P6Hkl2opxxu250sgWIchU4SoQCwDiwS0BIjHQSAwAdsDII53cwFDBeIoea1KYGSkoyhgGBDoTcBqfQJqXwIFVCDhd2fbEw7yFQRAwA0BdAjccOw3SgEC4hOgXpyQwD75Y2wQ6CaAA1y4rICABGJdt4TWS5QHB6JM6sEPDFkMgbrrqxhAnhyFgHgCu9iwOCFFgI4psySACj9uWL0LCA6ziwcYCyDuAsDs6RHAAUxOzLwLiBxXZVuCElx2fGBdXAJYH3H5p/MSHSUL4YRlsViQPxbw5D2KCt1nTBYslhprBxWIz/g4GBsLyAFEDJEUAYkHqBp7qz/mgoyBgPgLs9ORUcI7xYnBhBEwz29Bu6cwhjHMgYDEoG45Z78TGpaWJVjJj2ca3FIq7EzDRxAQyZuGgW2lLEADFH5uyXXl+6FlNKrEFpWR4bipiwAEJJOkMG8BZOIw3EiKQN38hH7LDnMfAUHYZIdtcetwwqsfPWR+fXa9nkSF7JZn52gSchUViL/4ihgZC1hEGIoyAgeYcsINASkk1nVbCHo8Es5Beitxh18C/vLLr90Y3Y4ABMSOX5JP44SYZNicGe1S8l1UuFp7tDcsjsbycWfccxwIApJjVCv45GIDqDAdbs2AAA4gGQTRkQsQEEcgUx8GLYiEIxjgiI38SDg/PJouQ0ACLACPDLMbuuQTJlJxfjqjQs1ueTt1SIaAOHVJ2mBpb0nYQKTlk397Sj5A+KcraAYHWxMERFA8JZsSr4XhIMslgxViW9j4IqZCwm5tBgTEGmF5A+CEmk/MUWHmE8sYnkBAYlDPaE5sQOkFEweA9GIm1WIIiNTIJGZX2BZIYnAEmIv4CAhChiZAQDIMamyXcMKNHYG5+VEhyolFjpZAQFxGFe8Gu2hiA3OZYGZjQcDNOOEuewIQEHuGGMGAQNotFPkng7T5GiQQbhFJAAIiMix5GNVv2613Qpa/iceIWswKDxGJEXFZc0JAZMWjOGtiboCpwq4nwKl6C7slE4CASI5OQbahBbN4sMGnoMWQkKsTkxM00Gw2ZxqNhjuzbWpbm2fdeYCRIhLACXsOPiq0iImIqbUEUIFoEeGGmARK20D5/DS9fj21Wi2ampqi4eEVNDq6kkZGRmKGAXODQE8CwgUkxXIkRZvlr47cWzi5+yc/w2BhHQLCBaSOS3hGHAHHmppCi8vU5dIqLHG5CYOsCEBArPAt8rDpDuJrfttxE7E/xQ04BQG0TR88nzOBuc0BApJznAvyTXoLKJZ9iZwDCsrUvFyFgOQVT3hDRCFP+LoNOsUKCUkEAqYEICCmpHCfIQHdlmo4jKPbYmzgIQXMESYM44CArMx34JDBEBkLSInhNIh4obeoFlJrilpTLUVgbGyMRkdHaXBwsINI/ZyJ1aIqNJxw2wuB6vmfsYB4IYxBMyDgskKIUeFkEAK4kAmBcgWkuthmEnK40UmgjgC4FCBEoywCuW07bgUkNzpl5XbR3upaULrrXfCwForOp1KcdysgpVCDn1kT6Kwwli1bpnydnp5W70z43cnQ0FDW/sM5EOhNoPtUVJCAFHIkLMRNn0scAuKTLsbOiUBBAlI3bNiR65JL6Tldi0p3PSVfYWtkAoZbiuFtUZ2BgETFj8ljE0j7Jbr5FmN+Z+yIYP6UCFQTEGRhSrGFrX0IuPwWVR0Byj8w2Cjkx9hNjKoJiHwqYix0Ex4x7iRviO8WlO/xkw9ABQc6146cdSTHkgoovd8KAfGOGBPEJBCjQnBZ4cRkh7lBQEcAAqIjhOueCbg/2UnawGMImOeAYXgQaBOAgCAZsiAgvYUk3b4skgBOBCcAAQmOHBO6JJDiCV9SheQyFhirPAIQkPJinrzHOW3AKQpg8gmUhAPuW7s+3J6YmKSBZrM502g0fIyPMUHACYHcW0C5++ckCTCIOAKoQMSFBAZ1EijxhJ5ThRUsm9M4sFfHIdwvCEj1kOIJRcBfZmMDnUuxEgUUCywdAoYC4m+zSAdViZaGi3tJLZw6VIPwqWNYicuiIJ91KWEoIAURg6tBCcg/YeuWUFBcajJUaOGZY8beBCAgyIzgBLABukMuX4Dd+ZrbSPKOJtUJhxeQHKhV51z8E0FaMAVTBt9cgy97wwwvILnGGX71JIATcgcWy73A9PF0KjxTj7C4pBLIR0CQi2JyLJ0NTAwyb4ZAwL2hxcBEJERAhO/+ws2TkMnptlDKCG668ZGQ3b1t8J05vsd3QVaIgLhwBWPEIJDPCTeF5eomwqgQy4n14hljzwEC4mZNFjVK3A3IPumrBks7o/aGqjP6uL+3kX4OAEkA8QG5uDGzERCkrN/c9dMCQdT8Rs18dD/xNZkfOWBCSeo9E5MT+GWKiwan8Pw2OaEWjkjq2v6/XdWjE7fCFI4zkHnVoxbIsAXTZFOBxMHXOWsqIdeTwgaiZ1TKHSYHCN8sTFaWyT0+7Iw1rw9f6owJAalDLcNn4rUwMoS50KUMdhnkRwF5WsNFCEgNaDk9YnbCzGAHzClokX1BhRo5AIKmh4DU7hQLimJFU7ABVASG2/sSMDuAAGCuBCAguUZ2gV9oQRQS6EhuIr8igY88rWABqdE2qfFIZP7ep8cJ0TtiTLCAQFEVbuF7jmABwbqsSyDKAva6kLwOXhcznjMggAOMAaSEb4GA9AteYnsWWggJr8IkTa+2QPLNz2ocZIe6ui8eBKS6EbKhyrYOJzzZ8YF13QSiVMgIhBcCHgTEi50YtIMAFiDSIQYBH0fDbA5APuDECHLFOSEgFYHFuj3fFkAsophXEgHkt6RomNviTUAKFWRz8oZ3ZnNCM/QXt4EAKux0csCbgKSDQJ6lWEDyYgKL4hDAAaoHd0GncwhInHXRNeviJbygjBHCC2bkTmB+zqPF5TjejrYUCIjjuFQdDiesqsRwf+kE3Fbo3Tupo721iDD1EBDg8x15twvAt7UYHwTkEsABLG5sUIEE4o8SPBBoTFMkgVLXV+zjPgTE83LDCckz4KyGj70d5AEzmQo/g3BDQDysmWQS2IPvGDIegQz2I+fwcIBzjnTegBAQR3xLLaEd4cMwIOCVANann+MFBMQybXHCsQRY0uN+1nBJBJ34ig6BE4xqEAhIDZZ+EzDALhNgihpY8QgIBCeAA6AdcgiIIb88SmAoh2G4a9xmw9bm2Rqm4pEuAnms7/CBFSkgkpYTTijhkxIzgkBMAn47DDE9cz+3SAFx72a1EZFA1XjhbjsCkg5Mdp70ejpt76wPkGm7r02HbgGJ7nAcA3qVsGOjo7RkcFALMakb4uBNClGZxiIxFou7zxZXyuSLr0D6nTB8B9X3+GVugql7nUpWpGKnn3xAh2KOa5ECggTws7BKHrW0LbU0f/vltnWLK/FFk5aAVM3ajvt9lqCJ50Bt86uGo/ZEeBAEhBPoub+MjdLgksxa4AvikJaA1Eii0k8INZDhERAAAQsCJXU4shQQlwHEKdtiJTl7FFFwhhIDBSWQ+wE2GwHZtHEjtaamqNVqqQQZGxuj0dFRGsztW1RB0x+TgQAIuCCQaws9eQERp/A4LLtYbxgDBLIl0K9DkuLWkaSAuGxRZZulcAwEQEA8gagH4BmimQGiAQtKgQWkvsbmWgJaxA6PggAIZEJA/P7WZ+sOLCDVox1VoaubiydAAARAwIpASh0WkQKSEkCrTCGi+jWZ7cxSngcBKZGAHfII1D5AB1pWYgREfAknL7eysShQrmfDC44kTqBGwkvdH+0EpAaIhaE3VlgHcyWedv7MB1t/bDEyCDgmIKlDYycgNcFIAlDTBTwmnQBEUXqEDOxDEHWQjA/guoFqXg8mIFJLsJrc8BgIgIBXAmWIxx9//EG77rorHXPMMXT//fcrovzD0Oeffz699dZbtPnmm9O5555Lt9xyCw0MDNCmTZvommuuoccee4z+/fdfOvzww+nhhx+mFStW0JTmB6nfe+89OuOMM2jvvfem5557rh292267ja699lpaunRp++9OPvlkevzxx9X/P/HEE3TDDTcou3baaSd66KGHaI899lDXvAtIbIX0muMYPCyBMvaUsEwxW1QCZ599Nr377rt0xBFHtAXkpJNOouXLl9N9991HvH8edthhdOWVV9J5551H99xzDz344IP0+uuv01ZbbUXnnHOO+m0bTz75ZNuPXh2eV155he68807aeeedad26dfME5Prrr6fffvuN7r333i4WX3zxBR188MH06quv0r777qvmv/322+nbb7+lzTbbzI+AoEUVNScxOQiAQAIEXn75Zbr55pvp6KOPpp9//lkJyF9//UUjIyP0ww8/0DbbbKO84BP/008/rSqSAw88kC666CI6/fTT1bXvvvuOdtttN+JKhsWEKxEWHv6ceeaZtGHDBrrxxhvp448/pn322UdVFV999dU8Abn44ouVGHGVs/DD4vLLL7+oKmf2w3Y99dRTdMghh7gTELSoDDIWJ2gDSLhljgASJtds4BM/b+hcGTz77LP0008/KQH5/PPPlUisXbu27TpXKCeeeKLayFeuXElvvPGGakPxZ2ZmRrW5+Lmtt96adt99d3rmmWdoenqauLrhv2dRmd2fb7rpJvrmm29UxTL7uwJPO+00ajabqgrhNth+++2nqpVtt92WTjjhBGUPV0CzH26bsT0sZNYtLLSock1x+AUCIOCLAFcQe+21F11xxRXEm/qsgHzwwQd03HHH0Zo1a9pTf/LJJ+q0/+eff9IWW2xBn376Ke2yyy7t68PDw6o6YUF67bXX6Oqrr1bvR7jdxO2vzg/PxePdfffdSiz4WRYw7hpdfvnlqi116aWXqjn4z1FHHaXs4Spl9nPssccqe6666qp6AoIWla+0wrggAAK5E3j++efpjjvuUJv+kiVL5gkIv3Pg6uKff/5pY3jzzTdVy4pP+1w1vPjii3TAAQeo69yi4grk66+/ph133FH9Hb+U5/cin332WRdKFpDx8fF2C6tXAcD7O8/z448/0mWXXabsue6669pjHXTQQcqeCy+80FxA5rWoZojG/pPhr0tPumOQtPG57xnwDwTaBE455RR655131MbPH35/wULAL6lfeukl9Q6EhWSHHXZQ17mdxO9LuHXFmzd/k+qCCy5Q11gMuMXE7Sce74EHHmi3sM466yz1ba6FFUingPC1Dz/8kLbffnvVDuNvWvEfrjC4rcXvU77//nv1DoY/rAOrVq2iF154Qc2rbWGhRYXMBwEQAAF/BDpbWDwLv5Pgr+s+8sgjqpXF7xz4nlNPPVV9A4urFxYTfvHNlQC/+2Dh4Bfv+++/P3Eb7O+//1Zi89FHH9F2223XNn5hBcIX+CU+//tJPDZ/VZjFid+T8Mt7fu/C3wpjAeOx+Su//LXeL7/8UlVPPQUELSp/yYKRQQAEQGBhVTD7DoT/nqsJ/soui8TQ0BBdcsklxN+G4g9XCfzfvNnzew5+H8Ev37fccks69NBD6fjjj1fvVfjD377i9he3yo488kh6//33VQXB4sTvOrjlxW2uyclJ9Y7j7bffVq0v/truXXfdRY1GQ32NmL+5deutt9Kvv/5Ke+65Jz366KPtdllbQLgs0f0gCsKeKAF0txINHMwGARkE+n3LdvXq1TQwPj4+wzewivEPsHT+RKKZ+fxPkvAuhQ8IgAAIgEDOBPh9Df8wIv/MClcs/wXIZT3M35g24AAAAABJRU5ErkJggg=="
+	sightingData := &entity.Sighting{TigerID: tigerID, SeenAt: seenAt, Latitude: -6.18, Longitude: 106.0,
+		ImageData: imageData}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid tigerID",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.TigerID = 0
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error invalid seen at timestamp",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = time.Unix(0, 0)
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error seen at timestamp in the future",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = time.Now().Add(10 * time.Minute)
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "seen_at cannot be in the future")
+			},
+		},
+		{
+			testcaseName: "Error invalid latitude",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.Latitude = 200.0
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error invalid longitude",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.Longitude = 200.0
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error empty image data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.ImageData = ""
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error no tiger in database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(nil, errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(nil, entity.ErrTigerNotFound)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.ErrorIs(t, resErr, entity.ErrTigerNotFound)
+			},
+		},
+		{
+			testcaseName: "Error sighting rejected by validator",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.Latitude = -8.10
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -8.10, 106.0).Return(213515.92, nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2, gomock.Any()).
+					Return(apperrors.NewPreconditionError("distance exceed 5000. Distance: 213515.92"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(resErr))
+			},
+		},
+		{
+			testcaseName: "Error when calculate distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error invalid base64 image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.ImageData = "this-is-invalid-base64-image"
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error unsupported image format",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				// a bmp image sent as png
+				sightingData2.ImageData = "data:image/png;base64," + base64.StdEncoding.EncodeToString(append([]byte("BM"), make([]byte, 64)...))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image format is not supported")
+			},
+		},
+		{
+			testcaseName: "Error image larger than max bytes",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				data := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, imageLimits.MaxBytes)...)
+				sightingData2.ImageData = base64.StdEncoding.EncodeToString(data)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image is larger than 1048576 bytes")
+			},
+		},
+		{
+			testcaseName: "Error image larger than max dimensions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				var data bytes.Buffer
+				require.NoError(t, png.Encode(&data, image.NewGray(image.Rect(0, 0, imageLimits.MaxWidth+1, 1))))
+				sightingData2.ImageData = "data:image/png;base64," + base64.StdEncoding.EncodeToString(data.Bytes())
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image is 1001x1 pixels, larger than 1000x1000")
+			},
+		},
+		{
+			testcaseName: "Error truncated image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.ImageData = "data:image/png;base64,iVBORw0KGgo="
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image metadata can't be removed: image is truncated")
+			},
+		},
+		{
+			testcaseName: "Error image can't be decoded",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				// png image ending right after its signature
+				sightingData2.ImageData = "data:image/png;base64,iVBORw0KGgoAAAAASUVORK5CYII="
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "image can't be decoded")
+			},
+		},
+		{
+			testcaseName: "Error when store image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, sightingImageKey(tigerID, ".png"), gomock.Any()).Return(errors.New("s3 error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.EqualError(t, resErr, "s3 error")
+			},
+		},
+		{
+			testcaseName: "Error when store rendition",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, sightingImageKey(tigerID, ".png"), gomock.Any()).Return(nil)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, sightingImageKey(tigerID, "_thumbnail.png"), gomock.Any()).Return(nil)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, sightingImageKey(tigerID, "_medium.png"), gomock.Any()).Return(errors.New("s3 error"))
+				serviceTestSuite.imageStore.EXPECT().Delete(mockCtx, sightingImageKey(tigerID, ".png")).Return(nil)
+				serviceTestSuite.imageStore.EXPECT().Delete(mockCtx, sightingImageKey(tigerID, "_thumbnail.png")).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.EqualError(t, resErr, "s3 error")
+			},
+		},
+		{
+			testcaseName: "Error when begin transaction and delete image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.imageStore.EXPECT().Delete(mockCtx, sightingImageKey(tigerID, ".png")).Return(errors.New("s3 error"))
+				for _, rendition := range imageRenditions {
+					serviceTestSuite.imageStore.EXPECT().Delete(mockCtx, sightingImageKey(tigerID, "_"+rendition.Name+".png")).Return(nil)
+				}
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).Return(errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.EqualError(t, resErr, "db error")
+			},
+		},
+		{
+			testcaseName: "Error when begin transaction",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).Return(errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error concurrent update on the same tiger",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).Return(entity.ErrConcurrentUpdate)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.ErrorIs(t, resErr, entity.ErrConcurrentUpdate)
+			},
+		},
+		{
+			testcaseName: "Error when insert to database",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error invalid reporter email",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sightingData2 := *sightingData
+				sightingData2.ReporterEmail = "not-an-email"
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.Contains(t, resErr.Error(), "not a valid email address")
+			},
+		},
+		{
+			testcaseName: "Error when get prior reporters",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database with an event listing the prior reporters",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.ReporterName = "Ranger"
+				sightingData2.ReporterEmail = "Ranger <Ranger@Example.com>"
+				// 600x300 image whose left, middle and right thirds are red, green and blue
+				original := image.NewNRGBA(image.Rect(0, 0, 600, 300))
+				for x := 0; x < 600; x++ {
+					c := []color.NRGBA{{R: 255, A: 255}, {G: 255, A: 255}, {B: 255, A: 255}}[x/200]
+					for y := 0; y < 300; y++ {
+						original.SetNRGBA(x, y, c)
+					}
+				}
+				var originalData bytes.Buffer
+				require.NoError(t, png.Encode(&originalData, original))
+				sightingData2.ImageData = "data:image/png;base64," + base64.StdEncoding.EncodeToString(originalData.Bytes())
+				var (
+					stored  *entity.OutboxEvent
+					watched *entity.WatchedSighting
+					images  = map[string]*imagestore.Image{}
+				)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "ranger@example.com").
+					Return([]string{"a@example.com", "b@example.com"}, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).
+					DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+						stored = event
+						return nil
+					})
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, value interface{}) error {
+						watched = value.(*entity.WatchedSighting)
+						return nil
+					})
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.Equal(t, "ranger@example.com", sightingData2.ReporterEmail)
+				require.Equal(t, "tiger-1", watched.TigerName)
+				require.Equal(t, "Ranger", watched.ReporterName)
+				require.Empty(t, watched.ReporterEmail)
+				require.Empty(t, watched.ImageData)
+				require.True(t, sightingImageKey(tigerID, ".png").Matches(sightingData2.ImageKey))
+				require.Equal(t, &imagestore.Image{Data: originalData.Bytes(), ContentType: "image/png"}, images[sightingData2.ImageKey])
+				ext := filepath.Ext(sightingData2.ImageKey)
+				renditionKey := strings.TrimSuffix(sightingData2.ImageKey, ext) + "_%s" + ext
+				// thumbnail fills 100x80 with the center of the image, the others fit in their size without being scaled up
+				for name, size := range map[string]image.Point{
+					service.ImageRenditionThumbnail: {X: 100, Y: 80},
+					service.ImageRenditionMedium:    {X: 250, Y: 125},
+					service.ImageRenditionLarge:     {X: 600, Y: 300},
+				} {
+					rendition := images[fmt.Sprintf(renditionKey, name)]
+					require.NotNil(t, rendition, name)
+					require.Equal(t, "image/png", rendition.ContentType)
+					decoded, err := png.Decode(bytes.NewReader(rendition.Data))
+					require.NoError(t, err)
+					require.Equal(t, size, decoded.Bounds().Size(), name)
+					if name == service.ImageRenditionThumbnail {
+						r, g, b, _ := decoded.At(50, 40).RGBA()
+						require.Equal(t, []uint32{0, 0xffff, 0}, []uint32{r, g, b})
+					}
+				}
+				var payload entity.SightingRecordedEvent
+				require.NoError(t, json.Unmarshal(stored.Payload, &payload))
+				require.True(t, sightingData2.SeenAt.Equal(payload.SeenAt))
+				payload.SeenAt = time.Time{}
+				require.Equal(t, entity.SightingRecordedEvent{TigerID: tigerID, TigerName: "tiger-1",
+					Latitude: -6.18, Longitude: 106.0, ReporterName: "Ranger", PriorReporters: []string{"a@example.com", "b@example.com"}},
+					payload)
+			},
+		},
+		{
+			testcaseName: "Error when store outbox event",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, gomock.Any()).Return(errors.New("error db"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error when update tiger data",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(errors.New("error db"))
+
 				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
 				require.Error(t, resErr)
 			},
@@ -455,32 +1794,382 @@ func TestCreateSighting(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
+				require.NoError(t, resErr)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database when publish to watchers fails",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
 
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
 				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
 				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
-				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, service.GetTigersKey).Return(nil)
-				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(errors.New("redis error"))
 
 				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sightingData)
 				require.NoError(t, resErr)
 			},
 		},
+		{
+			testcaseName: "successfully insert to database a sighting flagged as needs review",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.Latitude = -8.10
+				flagForReview := func(_ context.Context, _ *entity.Tiger, sighting *entity.Sighting, _ *entity.Movement) error {
+					sighting.NeedsReview = true
+					return nil
+				}
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -8.10, 106.0).Return(213515.92, nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2, gomock.Any()).DoAndReturn(flagForReview)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.True(t, sightingData2.NeedsReview)
+				require.InDelta(t, 213.51592, sightingData2.Speed.Float64, 0.0001)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database with speed from the last seen location",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.Latitude = -6.20
+				tigerData.LastSeenTimestamp = sightingData2.SeenAt.Add(-2 * time.Hour)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.20, 106.0).Return(float64(10000), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 10000, Speed: sql.NullFloat64{Float64: 5, Valid: true}}).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.True(t, sightingData2.Speed.Valid)
+				require.InDelta(t, 5.0, sightingData2.Speed.Float64, 0.0001)
+				require.Equal(t, sightingData2.SeenAt, tigerData.LastSeenTimestamp)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting in the future within clock skew",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = time.Now().Add(time.Minute)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2, stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.Equal(t, sightingData2.SeenAt, tigerData.LastSeenTimestamp)
+			},
+		},
+		{
+			testcaseName: "Error when get adjacent sightings of a backdated sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = lastSeen.Add(-2 * time.Hour)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetAdjacentSightings(mockCtx, tigerID, sightingData2.SeenAt, -6.18, 106.0).
+					Return(nil, nil, errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Error(t, resErr)
+			},
+		},
+		{
+			testcaseName: "Error backdated sighting rejected against the next sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = lastSeen.Add(-2 * time.Hour)
+				previous := &entity.Waypoint{SeenAt: lastSeen.Add(-4 * time.Hour), Distance: 4000}
+				next := &entity.Waypoint{SeenAt: lastSeen.Add(-time.Hour), Distance: 90000}
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectDeleteImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetAdjacentSightings(mockCtx, tigerID, sightingData2.SeenAt, -6.18, 106.0).Return(previous, next, nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 2, Valid: true}}).Return(nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 90000, Speed: sql.NullFloat64{Float64: 90, Valid: true}}).
+					Return(apperrors.NewPreconditionError("speed exceed 60 km/h. Speed: 90.00 km/h"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(resErr))
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a backdated sighting between two sightings",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = lastSeen.Add(-2 * time.Hour)
+				previous := &entity.Waypoint{SeenAt: lastSeen.Add(-4 * time.Hour), Distance: 4000}
+				next := &entity.Waypoint{SeenAt: lastSeen.Add(-time.Hour), Distance: 1000}
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetAdjacentSightings(mockCtx, tigerID, sightingData2.SeenAt, -6.18, 106.0).Return(previous, next, nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 2, Valid: true}}).Return(nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 1000, Speed: sql.NullFloat64{Float64: 1, Valid: true}}).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.Equal(t, sql.NullFloat64{Float64: 2, Valid: true}, sightingData2.Speed)
+				require.Equal(t, lastSeen, tigerData.LastSeenTimestamp)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a backdated sighting older than every sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				sightingData2.SeenAt = lastSeen.Add(-2 * time.Hour)
+
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetAdjacentSightings(mockCtx, tigerID, sightingData2.SeenAt, -6.18, 106.0).Return(nil, nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(3000), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, &sightingData2,
+					&entity.Movement{Distance: 3000, Speed: sql.NullFloat64{Float64: 1.5, Valid: true}}).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.False(t, sightingData2.Speed.Valid)
+				require.Equal(t, lastSeen, tigerData.LastSeenTimestamp)
+			},
+		},
 		{
 			testcaseName: "successfully insert to database using jpeg image",
 			testcaseFunction: func(t *testing.T) {
 				t.Parallel()
 
 				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
 
 				sightingData2 := *sightingData
 				sightingData2.ImageData = "data:image/jpeg;base64,/9j/4AAQSkZJRgABAQEAYABgAAD/4QAiRXhpZgAATU0AKgAAAAgAAQESAAMAAAABAAEAAAAAAAD/2wBDAAIBAQIBAQICAgICAgICAwUDAwMDAwYEBAMFBwYHBwcGBwcICQsJCAgKCAcHCg0KCgsMDAwMBwkODw0MDgsMDAz/2wBDAQICAgMDAwYDAwYMCAcIDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAz/wAARCAAyAEsDASIAAhEBAxEB/8QAHwAAAQUBAQEBAQEAAAAAAAAAAAECAwQFBgcICQoL/8QAtRAAAgEDAwIEAwUFBAQAAAF9AQIDAAQRBRIhMUEGE1FhByJxFDKBkaEII0KxwRVS0fAkM2JyggkKFhcYGRolJicoKSo0NTY3ODk6Q0RFRkdISUpTVFVWV1hZWmNkZWZnaGlqc3R1dnd4eXqDhIWGh4iJipKTlJWWl5iZmqKjpKWmp6ipqrKztLW2t7i5usLDxMXGx8jJytLT1NXW19jZ2uHi4+Tl5ufo6erx8vP09fb3+Pn6/8QAHwEAAwEBAQEBAQEBAQAAAAAAAAECAwQFBgcICQoL/8QAtREAAgECBAQDBAcFBAQAAQJ3AAECAxEEBSExBhJBUQdhcRMiMoEIFEKRobHBCSMzUvAVYnLRChYkNOEl8RcYGRomJygpKjU2Nzg5OkNERUZHSElKU1RVVldYWVpjZGVmZ2hpanN0dXZ3eHl6goOEhYaHiImKkpOUlZaXmJmaoqOkpaanqKmqsrO0tba3uLm6wsPExcbHyMnK0tPU1dbX2Nna4uPk5ebn6Onq8vP09fb3+Pn6/9oADAMBAAIRAxEAPwD9EKKKKACipLKzm1K/gtbeMy3F1KkMSAgF3ZgqrzxySBzxzXXXnwXm0+6kt7jxZ8P4LiFzHLFJrRV43U4ZWHlcEEEEdiKAONorrf8AhUf/AFOPw7/8HZ/+NUf8Kj/6nH4d/wDg7P8A8aoA5Kitrxh4Eu/BcdjLNdaVqFpqSSNbXWnXX2iCUxtsdQ2AcqxAPGMnGcggYtABRRRQAUUUUAa3w+/5KH4d/wCwtZ/+j0r1LwH4h8DaP4q8dR+KoLF719dvHjkurM3CmASt8qfK2GDbyQACcr1xx5b8Pv8Akofh3/sLWf8A6PSvX/hB8ILDxr8S/FmvakwuIdN8RXtvDaMvyPKspfe/qBvXC+oOc8CgDx3RvDVx448Wf2foNnNIbqVzbxO2TDFu4MjcgBVIy3r0ySAev+Lv7PmofDDTLfUI5v7SsNirdSqm37NL3yP+eZPRj06HsT9GeEvhzongS4vpdJsobOTUpfNmK/oq/wB1ByQowoLHAFbVzDHeQtFIqSRyAq6MAyuDwQR3BoA+RfGP/JHPh/8A9xb/ANKxXI11ni0/8WX+Hv01X/0rWuToAKKKKACiiigCbTdQm0fVLW8t2VbizmS4iLLuAdGDKSO/IHFddqXxP0PWb+a7vPAehXN3dSNNNKbmUeY7EszY7ZYk4964uigDrf8AhPPDP/RPNB/8CpaP+E88M/8ARPNB/wDAqWuSooA3vG3jr/hL7XTbWDTbPSdP0lJVtra3LNtMrh5CWY5OWAPbHPrWDRRQAUUUUAFFFFABRRRQAUUUUAFFFFABRRRQB//Z"
 
-				serviceTestSuite.sightingRepo.EXPECT().GetTigerByID(mockCtx, tigerID).Return(tigerData, nil)
-				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, sightingData2).Return(nil)
+				serviceTestSuite.expectNoDuplicateImage(mockCtx)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".jpeg")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+			},
+		},
+		{
+			testcaseName: "successfully insert to database using animated gif image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				// 40x20 animation whose first frame is red and second frame is blue
+				palette := color.Palette{color.NRGBA{R: 255, A: 255}, color.NRGBA{B: 255, A: 255}}
+				blue := image.NewPaletted(image.Rect(0, 0, 40, 20), palette)
+				for i := range blue.Pix {
+					blue.Pix[i] = 1
+				}
+				var originalData bytes.Buffer
+				require.NoError(t, gif.EncodeAll(&originalData, &gif.GIF{
+					Image: []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 40, 20), palette), blue},
+					Delay: []int{10, 10},
+				}))
+				// the data URI declares a wrong media type
+				sightingData2.ImageData = "data:image/png;base64," + base64.StdEncoding.EncodeToString(originalData.Bytes())
+				images := map[string]*imagestore.Image{}
+
+				serviceTestSuite.imageStore.EXPECT().Put(mockCtx, gomock.Any(), gomock.Any()).Times(1 + len(imageRenditions)).
+					DoAndReturn(func(_ context.Context, key string, value *imagestore.Image) error {
+						images[key] = value
+						return nil
+					})
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
+				require.NoError(t, resErr)
+				require.True(t, sightingImageKey(tigerID, ".gif").Matches(sightingData2.ImageKey))
+				require.Equal(t, &imagestore.Image{Data: originalData.Bytes(), ContentType: "image/gif"}, images[sightingData2.ImageKey])
+				// renditions are png images of the first frame
+				renditionKey := strings.TrimSuffix(sightingData2.ImageKey, ".gif") + "_%s.png"
+				for _, rendition := range imageRenditions {
+					rendered := images[fmt.Sprintf(renditionKey, rendition.Name)]
+					require.NotNil(t, rendered, rendition.Name)
+					require.Equal(t, "image/png", rendered.ContentType)
+					decoded, err := png.Decode(bytes.NewReader(rendered.Data))
+					require.NoError(t, err)
+					r, g, b, _ := decoded.At(0, 0).RGBA()
+					require.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b}, rendition.Name)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully insert to database using webp image",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				tigerData := currentTiger()
+				sightingData2 := *sightingData
+				// 1x1 lossy webp image
+				sightingData2.ImageData = "UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA"
+
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".webp")
+				serviceTestSuite.sightingRepo.EXPECT().WithTransaction(mockCtx, gomock.Any()).DoAndReturn(runInTransaction)
+				serviceTestSuite.sightingRepo.EXPECT().GetTigerByIDForUpdate(mockCtx, tigerID).Return(tigerData, nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetDistanceFromLastSeen(mockCtx, tigerID, -6.18, 106.0).Return(float64(0), nil)
+				serviceTestSuite.validator.EXPECT().Validate(mockCtx, tigerData, gomock.Any(), stillMovement).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateSighting(mockCtx, &sightingData2).Return(nil)
+				serviceTestSuite.sightingRepo.EXPECT().GetPriorReporters(mockCtx, tigerID, "").Return(nil, nil)
+				serviceTestSuite.sightingRepo.EXPECT().CreateOutboxEvent(mockCtx, outboxEvent(entity.EventSightingRecorded, tigerID)).Return(nil)
 				serviceTestSuite.sightingRepo.EXPECT().UpdateTiger(mockCtx, tigerData).Return(nil)
-				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, service.GetTigersKey).Return(nil)
-				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, service.GetTigersVersionKey).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Incr(mockCtx, fmt.Sprintf(service.GetSightingsByTigerIDVersionKey, tigerID)).Return(int64(1), nil)
+				serviceTestSuite.redisRepo.EXPECT().Del(mockCtx, fmt.Sprintf(service.GetTigerByIDKey, tigerID)).Return(nil)
+				serviceTestSuite.redisRepo.EXPECT().Publish(mockCtx, service.WatchSightingsChannel, gomock.Any()).Return(nil)
 
 				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, &sightingData2)
 				require.NoError(t, resErr)
@@ -492,3 +2181,257 @@ func TestCreateSighting(t *testing.T) {
 		t.Run(tc.testcaseName, tc.testcaseFunction)
 	}
 }
+
+// pictureData returns a picture of width x 3/4 width pixels as base64 data URI, encoded by encode with the media type.
+// The picture is a wave of gray, different depending on whether it is the other picture.
+func pictureData(t *testing.T, width int, other bool, mediaType string, encode func(w io.Writer, m image.Image) error) string {
+	height := width * 3 / 4
+	picture := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			u, v := float64(x)/float64(width), float64(y)/float64(height)
+			wave := math.Sin(7*u + 4*v)
+			if other {
+				wave = math.Cos(9*v - 5*u)
+			}
+			picture.SetGray(x, y, color.Gray{Y: uint8(128 + 100*wave)})
+		}
+	}
+	var data bytes.Buffer
+	require.NoError(t, encode(&data, picture))
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data.Bytes())
+}
+
+func encodeJPEG(w io.Writer, m image.Image) error {
+	return jpeg.Encode(w, m, &jpeg.Options{Quality: 60})
+}
+
+func TestCreateSighting_DuplicateImage(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	tigerID := int32(1)
+	currentTiger := func() *entity.Tiger {
+		return &entity.Tiger{ID: tigerID, Name: "tiger-1", DateOfBirth: time.Now(), LastSeenTimestamp: time.Now().Add(-time.Hour)}
+	}
+	newSighting := func() *entity.Sighting {
+		return &entity.Sighting{TigerID: tigerID, SeenAt: time.Now().Add(-time.Minute), Latitude: -6.18, Longitude: 106.82,
+			ImageData: pictureData(t, 96, false, "image/png", png.Encode)}
+	}
+	// the same picture submitted before for another tiger
+	duplicate := &entity.SimilarSighting{Sighting: entity.Sighting{ID: 9, TigerID: 2}, Distance: 1}
+	// plainPictureData returns a png image of 64x48 gray pixels shaded by the x coordinate
+	plainPictureData := func(t *testing.T, shade func(x int) uint8) string {
+		picture := image.NewGray(image.Rect(0, 0, 64, 48))
+		for y := 0; y < 48; y++ {
+			for x := 0; x < 64; x++ {
+				picture.SetGray(x, y, color.Gray{Y: shade(x)})
+			}
+		}
+		var data bytes.Buffer
+		require.NoError(t, png.Encode(&data, picture))
+		return "data:image/png;base64," + base64.StdEncoding.EncodeToString(data.Bytes())
+	}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error sighting whose image is a near-duplicate is rejected",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, gomock.Any(), int32(3), int32(1)).
+					Return([]*entity.SimilarSighting{duplicate}, nil)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, newSighting())
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(resErr))
+				require.EqualError(t, resErr, "image is a near-duplicate of the image of sighting 9 of tiger 2")
+			},
+		},
+		{
+			testcaseName: "successfully insert to database a sighting whose image is a near-duplicate as needs review",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				reviewSvc := service.NewTigerSightingService(serviceTestSuite.sightingRepo, serviceTestSuite.redisRepo, serviceTestSuite.imageStore,
+					imageLimits, imageRenditions, exifTolerance, service.DuplicateImages{MaxDistance: 2, Review: true}, serviceTestSuite.validator, 5*time.Minute)
+				sighting := newSighting()
+
+				serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, gomock.Any(), int32(2), int32(1)).
+					Return([]*entity.SimilarSighting{duplicate}, nil)
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectCreateSighting(mockCtx, currentTiger(), sighting)
+
+				resErr := reviewSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.True(t, sighting.NeedsReview)
+				require.NotZero(t, sighting.ImageHash)
+			},
+		},
+		{
+			testcaseName: "Error when get similar sightings",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, gomock.Any(), int32(3), int32(1)).Return(nil, errors.New("db error"))
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, newSighting())
+				require.EqualError(t, resErr, "db error")
+			},
+		},
+	}
+	for _, plain := range []struct {
+		name  string
+		shade func(x int) uint8
+	}{
+		{name: "blank", shade: func(int) uint8 { return 128 }},
+		{name: "shaded from white to black", shade: func(x int) uint8 { return uint8(255 - 4*x) }},
+	} {
+		plain := plain
+		testCases = append(testCases, ServiceTestCase{
+			testcaseName: "successfully insert to database a sighting whose " + plain.name + " image is too plain to look for near-duplicates",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				sighting := newSighting()
+				sighting.ImageData = plainPictureData(t, plain.shade)
+
+				serviceTestSuite.expectStoreImages(mockCtx, tigerID, ".png")
+				serviceTestSuite.expectCreateSighting(mockCtx, currentTiger(), sighting)
+
+				resErr := serviceTestSuite.sightingSvc.CreateSighting(mockCtx, sighting)
+				require.NoError(t, resErr)
+				require.False(t, sighting.NeedsReview)
+				require.Zero(t, sighting.ImageHash)
+			},
+		})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestFindSimilarImages(t *testing.T) {
+	t.Parallel()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCtx := context.Background()
+	picture := pictureData(t, 96, false, "image/png", png.Encode)
+	similar := []*entity.SimilarSighting{
+		{Sighting: entity.Sighting{ID: 4, TigerID: 1, ImageKey: "sighting/1/a.png"}, Distance: 0},
+		{Sighting: entity.Sighting{ID: 9, TigerID: 2, ImageKey: "sighting/2/b.jpeg"}, Distance: 2},
+	}
+	// hashOf returns the image hash of the image data searched by FindSimilarImages
+	hashOf := func(t *testing.T, imageData string) uint64 {
+		serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+		var hash uint64
+		serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, gomock.Any(), int32(3), int32(service.DefaultSimilarImagesLimit)).
+			DoAndReturn(func(_ context.Context, h uint64, _, _ int32) ([]*entity.SimilarSighting, error) {
+				hash = h
+				return nil, nil
+			})
+		_, err := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{ImageData: imageData})
+		require.NoError(t, err)
+		return hash
+	}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid query",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				res, resErr := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{
+					MaxDistance: sql.NullInt32{Int32: 8, Valid: true}, Limit: 101,
+				})
+				require.Nil(t, res)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.EqualError(t, resErr, "image data should contain valid base64 image format; max distance must be between 0 and 7; limit must be between 0 and 100")
+			},
+		},
+		{
+			testcaseName: "Error image can't be decoded",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				res, resErr := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{ImageData: "dGV4dA=="})
+				require.Nil(t, res)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+			},
+		},
+		{
+			testcaseName: "Error image is too plain to be compared",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				var blank bytes.Buffer
+				require.NoError(t, png.Encode(&blank, image.NewGray(image.Rect(0, 0, 64, 48))))
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				res, resErr := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{
+					ImageData: base64.StdEncoding.EncodeToString(blank.Bytes()),
+				})
+				require.Nil(t, res)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(resErr))
+				require.EqualError(t, resErr, "image is too plain to be compared")
+			},
+		},
+		{
+			testcaseName: "Error when get similar sightings",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, gomock.Any(), int32(3), int32(service.DefaultSimilarImagesLimit)).
+					Return(nil, errors.New("db error"))
+
+				res, resErr := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{ImageData: picture})
+				require.Nil(t, res)
+				require.EqualError(t, resErr, "db error")
+			},
+		},
+		{
+			testcaseName: "successfully find similar images within the given distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				serviceTestSuite := TigerSightingServiceTestSuite(mockCtrl)
+				serviceTestSuite.sightingRepo.EXPECT().GetSimilarSightings(mockCtx, hashOf(t, picture), int32(0), int32(10)).Return(similar, nil)
+
+				res, resErr := serviceTestSuite.sightingSvc.FindSimilarImages(mockCtx, &entity.SimilarImageQuery{
+					ImageData: picture, MaxDistance: sql.NullInt32{Valid: true}, Limit: 10,
+				})
+				require.NoError(t, resErr)
+				require.Equal(t, similar, res)
+			},
+		},
+		{
+			testcaseName: "successfully hash the same picture scaled or re-encoded alike, and another picture differently",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+
+				hash := hashOf(t, picture)
+				require.LessOrEqual(t, bits.OnesCount64(hash^hashOf(t, pictureData(t, 96, false, "image/jpeg", encodeJPEG))), 3)
+				require.LessOrEqual(t, bits.OnesCount64(hash^hashOf(t, pictureData(t, 320, false, "image/png", png.Encode))), 3)
+				require.LessOrEqual(t, bits.OnesCount64(hash^hashOf(t, pictureData(t, 40, false, "image/jpeg", encodeJPEG))), 3)
+				// a thumbnail loses more details, yet is still found
+				thumbnail := bits.OnesCount64(hash ^ hashOf(t, pictureData(t, 24, false, "image/jpeg", encodeJPEG)))
+				require.Greater(t, thumbnail, 3)
+				require.LessOrEqual(t, thumbnail, service.MaxSimilarImageDistance)
+				require.Greater(t, bits.OnesCount64(hash^hashOf(t, pictureData(t, 96, true, "image/png", png.Encode))), service.MaxSimilarImageDistance)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// SightingValidator defines the policy deciding whether a new sighting of a tiger is accepted.
+type SightingValidator interface {
+	// Validate checks the sighting against the tiger it belongs to.
+	// movement is the move of the tiger between the sighting and a chronologically adjacent known position.
+	// It is called once for each adjacent position, i.e. the one before and the one after the sighting.
+	// It returns an error if the sighting must be rejected. It may accept the sighting and set NeedsReview instead.
+	Validate(ctx context.Context, tiger *entity.Tiger, sighting *entity.Sighting, movement *entity.Movement) error
+}
+
+// DistanceValidator accepts sightings within a maximum distance from the adjacent positions of the tiger.
+// The maximum distance of a tiger is its MaxSightingDistance if set, otherwise the default one.
+type DistanceValidator struct {
+	maxDistance float64
+	review      bool
+}
+
+// NewDistanceValidator creates an instance of DistanceValidator.
+// maxDistance is the default maximum distance in meters.
+// If review is true, farther sightings are accepted and flagged as needs review instead of rejected.
+func NewDistanceValidator(maxDistance float64, review bool) *DistanceValidator {
+	return &DistanceValidator{
+		maxDistance: maxDistance,
+		review:      review,
+	}
+}
+
+// Validate implements SightingValidator.
+func (v *DistanceValidator) Validate(_ context.Context, tiger *entity.Tiger, sighting *entity.Sighting, movement *entity.Movement) error {
+	maxDistance := v.maxDistance
+	if tiger.MaxSightingDistance.Valid {
+		maxDistance = tiger.MaxSightingDistance.Float64
+	}
+	if movement.Distance <= maxDistance {
+		return nil
+	}
+	if v.review {
+		sighting.NeedsReview = true
+		return nil
+	}
+	return apperrors.NewPreconditionError("distance exceed %.0f. Distance: %.2f", maxDistance, movement.Distance)
+}
+
+// SpeedValidator accepts sightings whose speed from the adjacent positions of the tiger is physically possible.
+// A movement without speed, i.e. the tiger moved between two positions at the same time, is never possible.
+type SpeedValidator struct {
+	maxSpeed float64
+	review   bool
+}
+
+// NewSpeedValidator creates an instance of SpeedValidator.
+// maxSpeed is the maximum speed in km/h.
+// If review is true, faster sightings are accepted and flagged as needs review instead of rejected.
+func NewSpeedValidator(maxSpeed float64, review bool) *SpeedValidator {
+	return &SpeedValidator{
+		maxSpeed: maxSpeed,
+		review:   review,
+	}
+}
+
+// Validate implements SightingValidator.
+func (v *SpeedValidator) Validate(_ context.Context, _ *entity.Tiger, sighting *entity.Sighting, movement *entity.Movement) error {
+	if movement.Speed.Valid && movement.Speed.Float64 <= v.maxSpeed {
+		return nil
+	}
+	if v.review {
+		sighting.NeedsReview = true
+		return nil
+	}
+	if !movement.Speed.Valid {
+		return apperrors.NewPreconditionError("speed exceed %.0f km/h. Tiger moved at the time of an adjacent sighting", v.maxSpeed)
+	}
+	return apperrors.NewPreconditionError("speed exceed %.0f km/h. Speed: %.2f km/h", v.maxSpeed, movement.Speed.Float64)
+}
+
+// SightingValidators is a SightingValidator accepting sightings accepted by all of its validators.
+type SightingValidators []SightingValidator
+
+// Validate implements SightingValidator.
+// Validators are called in order and the first error is returned.
+func (vs SightingValidators) Validate(ctx context.Context, tiger *entity.Tiger, sighting *entity.Sighting, movement *entity.Movement) error {
+	for _, v := range vs {
+		if err := v.Validate(ctx, tiger, sighting, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+)
+
+func TestDistanceValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	mockCtx := context.Background()
+	tiger := &entity.Tiger{ID: 1}
+	tigerWithOverride := &entity.Tiger{ID: 2, MaxSightingDistance: sql.NullFloat64{Float64: 20000, Valid: true}}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "accept sighting within default distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewDistanceValidator(5000, false).Validate(mockCtx, tiger, sighting, &entity.Movement{Distance: 5000})
+				require.NoError(t, err)
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "Error sighting farther than default distance",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewDistanceValidator(5000, false).Validate(mockCtx, tiger, sighting, &entity.Movement{Distance: 5000.01})
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(err))
+				require.EqualError(t, err, "distance exceed 5000. Distance: 5000.01")
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "accept sighting within distance of the tiger",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewDistanceValidator(5000, false).Validate(mockCtx, tigerWithOverride, sighting, &entity.Movement{Distance: 15000})
+				require.NoError(t, err)
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "Error sighting farther than distance of the tiger",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewDistanceValidator(50000, false).Validate(mockCtx, tigerWithOverride, sighting, &entity.Movement{Distance: 25000})
+				require.EqualError(t, err, "distance exceed 20000. Distance: 25000.00")
+			},
+		},
+		{
+			testcaseName: "flag far sighting as needs review in review mode",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewDistanceValidator(5000, true).Validate(mockCtx, tiger, sighting, &entity.Movement{Distance: 25000})
+				require.NoError(t, err)
+				require.True(t, sighting.NeedsReview)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestSpeedValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	mockCtx := context.Background()
+	tiger := &entity.Tiger{ID: 1}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "accept sighting within max speed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 1000, Speed: sql.NullFloat64{Float64: 60, Valid: true}}
+
+				err := service.NewSpeedValidator(60, false).Validate(mockCtx, tiger, sighting, movement)
+				require.NoError(t, err)
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "Error sighting faster than max speed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 240, Valid: true}}
+
+				err := service.NewSpeedValidator(60, false).Validate(mockCtx, tiger, sighting, movement)
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(err))
+				require.EqualError(t, err, "speed exceed 60 km/h. Speed: 240.00 km/h")
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "Error sighting moved at the time of the previous sighting",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+
+				err := service.NewSpeedValidator(60, false).Validate(mockCtx, tiger, sighting, &entity.Movement{Distance: 10})
+				require.Equal(t, apperrors.KindPrecondition, apperrors.KindOf(err))
+				require.EqualError(t, err, "speed exceed 60 km/h. Tiger moved at the time of an adjacent sighting")
+			},
+		},
+		{
+			testcaseName: "flag fast sighting as needs review in review mode",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 240, Valid: true}}
+
+				err := service.NewSpeedValidator(60, true).Validate(mockCtx, tiger, sighting, movement)
+				require.NoError(t, err)
+				require.True(t, sighting.NeedsReview)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestSightingValidators_Validate(t *testing.T) {
+	t.Parallel()
+
+	mockCtx := context.Background()
+	tiger := &entity.Tiger{ID: 1}
+	validators := service.SightingValidators{
+		service.NewDistanceValidator(5000, false),
+		service.NewSpeedValidator(60, true),
+	}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "accept sighting accepted by all validators",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 4, Valid: true}}
+
+				err := validators.Validate(mockCtx, tiger, sighting, movement)
+				require.NoError(t, err)
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "Error sighting rejected by a validator",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 6000, Speed: sql.NullFloat64{Float64: 240, Valid: true}}
+
+				err := validators.Validate(mockCtx, tiger, sighting, movement)
+				require.EqualError(t, err, "distance exceed 5000. Distance: 6000.00")
+				require.False(t, sighting.NeedsReview)
+			},
+		},
+		{
+			testcaseName: "flag sighting flagged by a validator as needs review",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				sighting := &entity.Sighting{}
+				movement := &entity.Movement{Distance: 4000, Speed: sql.NullFloat64{Float64: 240, Valid: true}}
+
+				err := validators.Validate(mockCtx, tiger, sighting, movement)
+				require.NoError(t, err)
+				require.True(t, sighting.NeedsReview)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/driver/redis"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+const (
+	// WatchSightingsChannel is the redis channel new sightings are published to, as JSON encoded entity.WatchedSighting
+	WatchSightingsChannel = BaseKey + "sighting:watch-sightings"
+	// MaxWatchedTigers is the maximum number of tiger IDs a watcher of sightings may filter by
+	MaxWatchedTigers = 100
+	// watchedSightingsBuffer is the number of sightings waiting for a watcher before next sightings are dropped
+	watchedSightingsBuffer = 64
+)
+
+// SightingWatch defines the interface to watch new sightings.
+type SightingWatch interface {
+	// WatchSightings returns the new sightings matching the filter, from now until ctx is done
+	// The returned channel is closed when ctx is done or the sightings can't be received anymore.
+	// A watcher not keeping up with the sightings misses some of them.
+	WatchSightings(ctx context.Context, filter *entity.SightingWatchFilter) (<-chan *entity.WatchedSighting, error)
+}
+
+// SightingWatchService is responsible for dispatching the sightings published to WatchSightingsChannel to the watchers.
+// A single redis subscription is shared by every watcher of the process and closed when nobody watches.
+type SightingWatchService struct {
+	redisRepo redis.Redis
+
+	mu           sync.Mutex
+	subscription *sightingSubscription
+}
+
+// sightingSubscription is a redis subscription to WatchSightingsChannel with the watchers it dispatches to.
+type sightingSubscription struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	watchers map[*sightingWatcher]struct{}
+}
+
+// sightingWatcher receives the sightings matching its filter.
+type sightingWatcher struct {
+	filter    *entity.SightingWatchFilter
+	sightings chan *entity.WatchedSighting
+}
+
+// NewSightingWatchService creates an instance of SightingWatchService.
+func NewSightingWatchService(redisRepo redis.Redis) *SightingWatchService {
+	return &SightingWatchService{redisRepo: redisRepo}
+}
+
+// WatchSightings returns the new sightings matching the filter, from now until ctx is done
+func (s *SightingWatchService) WatchSightings(ctx context.Context, filter *entity.SightingWatchFilter) (<-chan *entity.WatchedSighting, error) {
+	logger := logging.NewServiceLogger(ctx, "WatchSightings", logrus.Fields{})
+
+	if err := isValidSightingWatchFilter(filter); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate sighting watch filter")
+		return nil, err
+	}
+
+	watcher := &sightingWatcher{filter: filter, sightings: make(chan *entity.WatchedSighting, watchedSightingsBuffer)}
+	s.mu.Lock()
+	if s.subscription == nil {
+		// subscribe without the lock, so that a slow redis doesn't hold back the dispatch to the other watchers
+		s.mu.Unlock()
+		subscription, messages, err := s.subscribe(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		// another watcher may have subscribed meanwhile, its subscription is kept and this one canceled
+		if s.subscription == nil {
+			s.subscription = subscription
+			go s.dispatch(subscription.ctx, subscription, messages)
+		} else {
+			subscription.cancel()
+		}
+	}
+
+	subscription := s.subscription
+	subscription.watchers[watcher] = struct{}{}
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.unwatch(subscription, watcher)
+	}()
+	return watcher.sightings, nil
+}
+
+// subscribe subscribes to WatchSightingsChannel, the subscription outlives the watcher starting it.
+// It is canceled when the last watcher leaves.
+func (s *SightingWatchService) subscribe(ctx context.Context) (*sightingSubscription, <-chan []byte, error) {
+	logger := logging.NewServiceLogger(ctx, "subscribe", logrus.Fields{})
+
+	subscriptionCtx, cancel := context.WithCancel(context.Background())
+	messages, err := s.redisRepo.Subscribe(subscriptionCtx, WatchSightingsChannel)
+	if err != nil {
+		cancel()
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Subscribe")
+		return nil, nil, err
+	}
+	return &sightingSubscription{ctx: subscriptionCtx, cancel: cancel, watchers: map[*sightingWatcher]struct{}{}}, messages, nil
+}
+
+// dispatch sends every sighting received by the subscription to the watchers whose filter matches it.
+// When the subscription ends, the remaining watchers are closed.
+func (s *SightingWatchService) dispatch(ctx context.Context, subscription *sightingSubscription, messages <-chan []byte) {
+	logger := logging.NewServiceLogger(ctx, "dispatch", logrus.Fields{})
+
+	for message := range messages {
+		sighting := &entity.WatchedSighting{}
+		if err := json.Unmarshal(message, sighting); err != nil {
+			logging.WithError(err, logger).Warn("Error when unmarshal watched sighting")
+			continue
+		}
+
+		s.mu.Lock()
+		for watcher := range subscription.watchers {
+			if !matchesSightingWatchFilter(watcher.filter, sighting) {
+				continue
+			}
+			select {
+			case watcher.sightings <- sighting:
+			default:
+				logger.WithField("sighting_id", sighting.ID).Warn("Sighting dropped for a watcher not keeping up")
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for watcher := range subscription.watchers {
+		delete(subscription.watchers, watcher)
+		close(watcher.sightings)
+	}
+	if s.subscription == subscription {
+		s.subscription = nil
+	}
+	subscription.cancel()
+}
+
+// unwatch removes the watcher from the subscription and cancels the subscription if nobody watches anymore.
+func (s *SightingWatchService) unwatch(subscription *sightingSubscription, watcher *sightingWatcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the watcher is already closed if the subscription ended
+	if _, ok := subscription.watchers[watcher]; !ok {
+		return
+	}
+	delete(subscription.watchers, watcher)
+	close(watcher.sightings)
+	if len(subscription.watchers) == 0 && s.subscription == subscription {
+		s.subscription = nil
+		subscription.cancel()
+	}
+}
+
+// publishSighting sends the new sighting to its watchers.
+// Errors are ignored, watchers miss the sighting but can still read it from the database.
+func publishSighting(ctx context.Context, redisRepo redis.Redis, sighting *entity.WatchedSighting) {
+	logger := logging.NewServiceLogger(ctx, "publishSighting", logrus.Fields{"sighting_id": sighting.ID})
+
+	if err := redisRepo.Publish(ctx, WatchSightingsChannel, sighting); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from redisRepo.Publish")
+	}
+}
+
+// matchesSightingWatchFilter returns true if the sighting is of one of the tigers and inside the bounding box of the filter.
+func matchesSightingWatchFilter(filter *entity.SightingWatchFilter, sighting *entity.WatchedSighting) bool {
+	if len(filter.TigerIDs) > 0 {
+		found := false
+		for _, id := range filter.TigerIDs {
+			if id == sighting.TigerID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	box := filter.BoundingBox
+	if box == nil {
+		return true
+	}
+	if sighting.Latitude < box.MinLatitude || sighting.Latitude > box.MaxLatitude {
+		return false
+	}
+	// a box crossing the antimeridian contains the longitudes east of its minimum or west of its maximum
+	if box.MinLongitude > box.MaxLongitude {
+		return sighting.Longitude >= box.MinLongitude || sighting.Longitude <= box.MaxLongitude
+	}
+	return sighting.Longitude >= box.MinLongitude && sighting.Longitude <= box.MaxLongitude
+}
@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	mockRedisRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/common/redis"
+)
+
+// expectSubscribe makes redis subscribe once to the watched sightings and returns the messages published
+// with the context of the subscription.
+func expectSubscribe(redisRepo *mockRedisRepo.MockRedis) (chan []byte, chan context.Context) {
+	messages := make(chan []byte)
+	subscribed := make(chan context.Context, 1)
+	redisRepo.EXPECT().Subscribe(gomock.Any(), service.WatchSightingsChannel).
+		DoAndReturn(func(ctx context.Context, _ string) (<-chan []byte, error) {
+			subscribed <- ctx
+			return messages, nil
+		})
+	return messages, subscribed
+}
+
+func publishWatchedSighting(t *testing.T, messages chan<- []byte, sighting *entity.WatchedSighting) {
+	b, err := json.Marshal(sighting)
+	require.NoError(t, err)
+	messages <- b
+}
+
+func watchedSighting(id, tigerID int32, latitude, longitude float64) *entity.WatchedSighting {
+	return &entity.WatchedSighting{
+		Sighting:  entity.Sighting{ID: id, TigerID: tigerID, Latitude: latitude, Longitude: longitude},
+		TigerName: "tiger",
+	}
+}
+
+func TestSightingWatchService_WatchSightings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid filter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc := service.NewSightingWatchService(mockRedisRepo.NewMockRedis(gomock.NewController(t)))
+
+				sightings, err := svc.WatchSightings(context.Background(), &entity.SightingWatchFilter{
+					TigerIDs:    []int32{1, 0},
+					BoundingBox: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10, MinLongitude: -200},
+				})
+				require.Nil(t, sightings)
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+				require.Contains(t, err.Error(), "tiger id")
+				require.Contains(t, err.Error(), "latitude range")
+				require.Contains(t, err.Error(), "longitude range")
+			},
+		},
+		{
+			testcaseName: "Error too many tiger ids",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc := service.NewSightingWatchService(mockRedisRepo.NewMockRedis(gomock.NewController(t)))
+
+				_, err := svc.WatchSightings(context.Background(), &entity.SightingWatchFilter{
+					TigerIDs: make([]int32, service.MaxWatchedTigers+1),
+				})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+				require.Contains(t, err.Error(), "at most 100 tiger ids")
+			},
+		},
+		{
+			testcaseName: "Error when subscribe",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				redisRepo.EXPECT().Subscribe(gomock.Any(), service.WatchSightingsChannel).Return(nil, errors.New("redis error"))
+
+				sightings, err := service.NewSightingWatchService(redisRepo).WatchSightings(context.Background(), &entity.SightingWatchFilter{})
+				require.Error(t, err)
+				require.Nil(t, sightings)
+			},
+		},
+		{
+			testcaseName: "successfully receive only sightings matching the filter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				messages, _ := expectSubscribe(redisRepo)
+
+				sightings, err := service.NewSightingWatchService(redisRepo).WatchSightings(ctx, &entity.SightingWatchFilter{
+					TigerIDs:    []int32{1, 2},
+					BoundingBox: &entity.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170},
+				})
+				require.NoError(t, err)
+
+				messages <- []byte("not-json")
+				publishWatchedSighting(t, messages, watchedSighting(1, 3, 0, 175))
+				publishWatchedSighting(t, messages, watchedSighting(2, 1, 20, 175))
+				publishWatchedSighting(t, messages, watchedSighting(3, 1, 0, 0))
+				publishWatchedSighting(t, messages, watchedSighting(4, 1, 0, 175))
+				publishWatchedSighting(t, messages, watchedSighting(5, 2, 0, -175))
+
+				require.Equal(t, watchedSighting(4, 1, 0, 175), <-sightings)
+				require.Equal(t, watchedSighting(5, 2, 0, -175), <-sightings)
+			},
+		},
+		{
+			testcaseName: "successfully share the subscription until the last watcher leaves",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				firstCtx, cancelFirst := context.WithCancel(context.Background())
+				secondCtx, cancelSecond := context.WithCancel(context.Background())
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				messages, subscribed := expectSubscribe(redisRepo)
+				svc := service.NewSightingWatchService(redisRepo)
+
+				first, err := svc.WatchSightings(firstCtx, &entity.SightingWatchFilter{})
+				require.NoError(t, err)
+				second, err := svc.WatchSightings(secondCtx, &entity.SightingWatchFilter{TigerIDs: []int32{2}})
+				require.NoError(t, err)
+				subscriptionCtx := <-subscribed
+
+				publishWatchedSighting(t, messages, watchedSighting(1, 2, 0, 0))
+				require.Equal(t, watchedSighting(1, 2, 0, 0), <-first)
+				require.Equal(t, watchedSighting(1, 2, 0, 0), <-second)
+
+				cancelFirst()
+				_, ok := <-first
+				require.False(t, ok)
+				require.NoError(t, subscriptionCtx.Err())
+
+				cancelSecond()
+				_, ok = <-second
+				require.False(t, ok)
+				<-subscriptionCtx.Done()
+				close(messages)
+			},
+		},
+		{
+			testcaseName: "successfully subscribe without holding back the other watchers, keeping a single subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				type subscription struct {
+					ctx      context.Context
+					messages chan []byte
+				}
+				// both first watchers subscribe at once, until redis confirms their subscriptions
+				subscribed := make(chan subscription, 2)
+				confirmed := make(chan struct{})
+				redisRepo.EXPECT().Subscribe(gomock.Any(), service.WatchSightingsChannel).Times(2).
+					DoAndReturn(func(ctx context.Context, _ string) (<-chan []byte, error) {
+						messages := make(chan []byte)
+						subscribed <- subscription{ctx: ctx, messages: messages}
+						<-confirmed
+						return messages, nil
+					})
+				svc := service.NewSightingWatchService(redisRepo)
+
+				type watch struct {
+					sightings <-chan *entity.WatchedSighting
+					err       error
+				}
+				watches := make(chan watch, 2)
+				for i := 0; i < 2; i++ {
+					go func() {
+						sightings, err := svc.WatchSightings(ctx, &entity.SightingWatchFilter{})
+						watches <- watch{sightings: sightings, err: err}
+					}()
+				}
+				first, second := <-subscribed, <-subscribed
+				close(confirmed)
+				firstWatch, secondWatch := <-watches, <-watches
+				require.NoError(t, firstWatch.err)
+				require.NoError(t, secondWatch.err)
+
+				// the subscription registered last is canceled, the other one is shared
+				kept := first
+				select {
+				case <-first.ctx.Done():
+					kept = second
+				case <-second.ctx.Done():
+				}
+				require.NoError(t, kept.ctx.Err())
+				publishWatchedSighting(t, kept.messages, watchedSighting(1, 2, 0, 0))
+				require.Equal(t, watchedSighting(1, 2, 0, 0), <-firstWatch.sightings)
+				require.Equal(t, watchedSighting(1, 2, 0, 0), <-secondWatch.sightings)
+			},
+		},
+		{
+			testcaseName: "successfully close the watchers when the subscription ends",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				redisRepo := mockRedisRepo.NewMockRedis(gomock.NewController(t))
+				messages, _ := expectSubscribe(redisRepo)
+				svc := service.NewSightingWatchService(redisRepo)
+
+				sightings, err := svc.WatchSightings(ctx, &entity.SightingWatchFilter{})
+				require.NoError(t, err)
+				close(messages)
+				_, ok := <-sightings
+				require.False(t, ok)
+
+				// the next watcher subscribes again
+				expectSubscribe(redisRepo)
+				_, err = svc.WatchSightings(ctx, &entity.SightingWatchFilter{})
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+const (
+	// DefaultWebhookDeliveriesPageSize is the page size of GetWebhookDeliveries if it is not specified
+	DefaultWebhookDeliveriesPageSize = 50
+	// MaxWebhookDeliveriesPageSize is the maximum page size of GetWebhookDeliveries
+	MaxWebhookDeliveriesPageSize = 100
+	// webhookSecretSize is the number of random bytes of a webhook secret, hex encoded
+	webhookSecretSize = 32
+)
+
+// Webhook defines the interface to webhook subscription services.
+type Webhook interface {
+	// CreateWebhookSubscription store a new webhook subscription in database with a generated secret
+	// It returns entity.ErrTigerNotFound if the subscription is filtered by a tiger which doesn't exist.
+	CreateWebhookSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error
+	// GetWebhookSubscriptions get every webhook subscription from database, without their secret
+	GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	// DeleteWebhookSubscription soft delete webhook subscription for given ID in database
+	// It returns entity.ErrWebhookSubscriptionNotFound if the subscription doesn't exist.
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int32) error
+	// GetWebhookDeliveries get the latest delivery attempts of webhook subscription for given ID, latest first
+	// It returns entity.ErrWebhookSubscriptionNotFound if the subscription doesn't exist.
+	GetWebhookDeliveries(ctx context.Context, subscriptionID int32, pageSize int32) ([]*entity.WebhookDeliveryAttempt, error)
+}
+
+// WebhookRepository defines the interface to webhook subscription repository.
+type WebhookRepository interface {
+	// GetTigerByID get tiger by ID from database
+	GetTigerByID(ctx context.Context, tigerID int32) (*entity.Tiger, error)
+	// CreateWebhookSubscription store a new webhook subscription in database
+	CreateWebhookSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error
+	// GetWebhookSubscriptions get every webhook subscription from database order by ID, without their secret
+	GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	// GetWebhookSubscriptionByID get webhook subscription by ID with its secret from database
+	GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int32) (*entity.WebhookSubscription, error)
+	// DeleteWebhookSubscription soft delete webhook subscription in database
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int32) error
+	// GetWebhookDeliveryAttempts get at most limit delivery attempts for given subscription ID from database, latest first
+	GetWebhookDeliveryAttempts(ctx context.Context, subscriptionID int32, limit int32) ([]*entity.WebhookDeliveryAttempt, error)
+}
+
+// WebhookService is responsible for hold dependencies related to webhook subscription service.
+type WebhookService struct {
+	repo                 WebhookRepository
+	allowPrivateNetworks bool
+}
+
+// NewWebhookService creates an instance of WebhookService.
+// Subscriptions to a loopback, private or link-local host are rejected unless allowPrivateNetworks is set.
+func NewWebhookService(repo WebhookRepository, allowPrivateNetworks bool) *WebhookService {
+	return &WebhookService{repo: repo, allowPrivateNetworks: allowPrivateNetworks}
+}
+
+// CreateWebhookSubscription store a new webhook subscription in database with a generated secret
+// The secret is set on the subscription. It is never returned again, since deliveries are signed with it.
+func (w *WebhookService) CreateWebhookSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	logger := logging.NewServiceLogger(ctx, "CreateWebhookSubscription", logrus.Fields{})
+
+	// validate input
+	if err := isValidWebhookSubscription(subscription, w.allowPrivateNetworks); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from validate webhook subscription")
+		return err
+	}
+	if subscription.TigerID.Valid {
+		if _, err := w.repo.GetTigerByID(ctx, subscription.TigerID.Int32); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from repo.GetTigerByID")
+			return err
+		}
+	}
+
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		logging.WithError(err, logger).Warn("Error when generate webhook secret")
+		return err
+	}
+	subscription.Secret = hex.EncodeToString(secret)
+
+	if err := w.repo.CreateWebhookSubscription(ctx, subscription); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.CreateWebhookSubscription")
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookSubscriptions get every webhook subscription from database, without their secret
+func (w *WebhookService) GetWebhookSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	logger := logging.NewServiceLogger(ctx, "GetWebhookSubscriptions", logrus.Fields{})
+
+	subscriptions, err := w.repo.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetWebhookSubscriptions")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription soft delete webhook subscription for given ID in database
+// Deliveries of the subscription waiting for a retry are dropped by the deliverer.
+func (w *WebhookService) DeleteWebhookSubscription(ctx context.Context, subscriptionID int32) error {
+	logger := logging.NewServiceLogger(ctx, "DeleteWebhookSubscription", logrus.Fields{})
+
+	if err := w.repo.DeleteWebhookSubscription(ctx, subscriptionID); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.DeleteWebhookSubscription")
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries get the latest delivery attempts of webhook subscription for given ID, latest first
+func (w *WebhookService) GetWebhookDeliveries(ctx context.Context, subscriptionID int32, pageSize int32) ([]*entity.WebhookDeliveryAttempt, error) {
+	logger := logging.NewServiceLogger(ctx, "GetWebhookDeliveries", logrus.Fields{})
+
+	// validate input
+	if pageSize < 0 || pageSize > MaxWebhookDeliveriesPageSize {
+		err := apperrors.NewValidationError(apperrors.FieldViolation{Field: "page_size", Description: fmt.Sprintf("page size must be between 0 and %d", MaxWebhookDeliveriesPageSize)})
+		logging.WithError(err, logger).Warn("Error when get from validate page size")
+		return nil, err
+	}
+	if pageSize == 0 {
+		pageSize = DefaultWebhookDeliveriesPageSize
+	}
+
+	// make sure the subscription exists, so unknown subscription is not reported as a subscription without delivery
+	if _, err := w.repo.GetWebhookSubscriptionByID(ctx, subscriptionID); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetWebhookSubscriptionByID")
+		return nil, err
+	}
+
+	attempts, err := w.repo.GetWebhookDeliveryAttempts(ctx, subscriptionID, pageSize)
+	if err != nil {
+		logging.WithError(err, logger).Warn("Error when get from repo.GetWebhookDeliveryAttempts")
+		return nil, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,274 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/service"
+	mockRepo "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/service"
+)
+
+func WebhookServiceTestSuite(t *testing.T) (*service.WebhookService, *mockRepo.MockWebhookRepository) {
+	repo := mockRepo.NewMockWebhookRepository(gomock.NewController(t))
+	return service.NewWebhookService(repo, false), repo
+}
+
+func TestWebhookService_CreateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid url",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, _ := WebhookServiceTestSuite(t)
+
+				for _, u := range []string{"", "example.com/hook", "ftp://example.com/hook", "https://", "://example.com"} {
+					err := svc.CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{URL: u})
+					require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err), u)
+				}
+			},
+		},
+		{
+			testcaseName: "Error private host",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, _ := WebhookServiceTestSuite(t)
+
+				for _, u := range []string{
+					"http://localhost:8080/hook", "http://api.LOCALHOST./hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook",
+					"http://192.168.1.1/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]:8080/hook",
+					"http://[fe80::1%25eth0]/hook", "http://[::ffff:10.0.0.1]/hook", "http://0.0.0.0/hook",
+				} {
+					err := svc.CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{URL: u})
+					require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err), u)
+					require.Contains(t, err.Error(), "loopback, private or link-local", u)
+				}
+			},
+		},
+		{
+			testcaseName: "successfully create subscription to private host if allowed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repo := mockRepo.NewMockWebhookRepository(gomock.NewController(t))
+				repo.EXPECT().CreateWebhookSubscription(mockCtx, gomock.Any()).Return(nil)
+
+				err := service.NewWebhookService(repo, true).CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{URL: "http://localhost:8080/hook"})
+				require.NoError(t, err)
+			},
+		},
+		{
+			testcaseName: "Error invalid filters",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, _ := WebhookServiceTestSuite(t)
+
+				err := svc.CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{
+					URL:         "https://example.com/hook",
+					TigerID:     sql.NullInt32{Int32: -1, Valid: true},
+					BoundingBox: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10, MinLongitude: -200},
+				})
+				require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+				require.Contains(t, err.Error(), "tiger id")
+				require.Contains(t, err.Error(), "latitude range")
+				require.Contains(t, err.Error(), "longitude range")
+			},
+		},
+		{
+			testcaseName: "Error tiger not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetTigerByID(mockCtx, int32(1)).Return(nil, entity.ErrTigerNotFound)
+
+				err := svc.CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{
+					URL:     "https://example.com/hook",
+					TigerID: sql.NullInt32{Int32: 1, Valid: true},
+				})
+				require.Equal(t, entity.ErrTigerNotFound, err)
+			},
+		},
+		{
+			testcaseName: "Error when store webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().CreateWebhookSubscription(mockCtx, gomock.Any()).Return(errors.New("db error"))
+
+				err := svc.CreateWebhookSubscription(mockCtx, &entity.WebhookSubscription{URL: "https://example.com/hook"})
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "successfully create webhook subscription with a random secret",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetTigerByID(mockCtx, int32(1)).Return(&entity.Tiger{ID: 1}, nil)
+				repo.EXPECT().CreateWebhookSubscription(mockCtx, gomock.Any()).Return(nil).Times(2)
+
+				newSubscription := func() *entity.WebhookSubscription {
+					return &entity.WebhookSubscription{
+						URL:         "http://partner.example.com:8080/hook",
+						BoundingBox: &entity.BoundingBox{MinLatitude: -10, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170},
+					}
+				}
+				first := newSubscription()
+				first.TigerID = sql.NullInt32{Int32: 1, Valid: true}
+				require.NoError(t, svc.CreateWebhookSubscription(mockCtx, first))
+				second := newSubscription()
+				require.NoError(t, svc.CreateWebhookSubscription(mockCtx, second))
+
+				require.Len(t, first.Secret, 64)
+				require.Len(t, second.Secret, 64)
+				require.NotEqual(t, first.Secret, second.Secret)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestWebhookService_GetWebhookSubscriptions(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error when get webhook subscriptions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetWebhookSubscriptions(mockCtx).Return(nil, errors.New("db error"))
+
+				res, err := svc.GetWebhookSubscriptions(mockCtx)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "successfully get webhook subscriptions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				subscriptions := []*entity.WebhookSubscription{{ID: 1, URL: "https://example.com/hook"}}
+				repo.EXPECT().GetWebhookSubscriptions(mockCtx).Return(subscriptions, nil)
+
+				res, err := svc.GetWebhookSubscriptions(mockCtx)
+				require.NoError(t, err)
+				require.Equal(t, subscriptions, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestWebhookService_DeleteWebhookSubscription(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error webhook subscription not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().DeleteWebhookSubscription(mockCtx, int32(1)).Return(entity.ErrWebhookSubscriptionNotFound)
+
+				err := svc.DeleteWebhookSubscription(mockCtx, 1)
+				require.Equal(t, entity.ErrWebhookSubscriptionNotFound, err)
+			},
+		},
+		{
+			testcaseName: "successfully delete webhook subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().DeleteWebhookSubscription(mockCtx, int32(1)).Return(nil)
+
+				require.NoError(t, svc.DeleteWebhookSubscription(mockCtx, 1))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestWebhookService_GetWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	attempts := []*entity.WebhookDeliveryAttempt{{ID: 2, SubscriptionID: 1, Attempt: 2, StatusCode: 200}, {ID: 1, SubscriptionID: 1, Attempt: 1}}
+
+	testCases := []ServiceTestCase{
+		{
+			testcaseName: "Error invalid page size",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, _ := WebhookServiceTestSuite(t)
+
+				for _, pageSize := range []int32{-1, service.MaxWebhookDeliveriesPageSize + 1} {
+					res, err := svc.GetWebhookDeliveries(mockCtx, 1, pageSize)
+					require.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+					require.Nil(t, res)
+				}
+			},
+		},
+		{
+			testcaseName: "Error webhook subscription not found",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(1)).Return(nil, entity.ErrWebhookSubscriptionNotFound)
+
+				res, err := svc.GetWebhookDeliveries(mockCtx, 1, 0)
+				require.Equal(t, entity.ErrWebhookSubscriptionNotFound, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "Error when get delivery attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(1)).Return(&entity.WebhookSubscription{ID: 1}, nil)
+				repo.EXPECT().GetWebhookDeliveryAttempts(mockCtx, int32(1), int32(service.DefaultWebhookDeliveriesPageSize)).Return(nil, errors.New("db error"))
+
+				res, err := svc.GetWebhookDeliveries(mockCtx, 1, 0)
+				require.Error(t, err)
+				require.Nil(t, res)
+			},
+		},
+		{
+			testcaseName: "successfully get delivery attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				svc, repo := WebhookServiceTestSuite(t)
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(1)).Return(&entity.WebhookSubscription{ID: 1}, nil)
+				repo.EXPECT().GetWebhookDeliveryAttempts(mockCtx, int32(1), int32(10)).Return(attempts, nil)
+
+				res, err := svc.GetWebhookDeliveries(mockCtx, 1, 10)
+				require.NoError(t, err)
+				require.Equal(t, attempts, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
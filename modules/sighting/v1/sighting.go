@@ -14,6 +14,7 @@ import (
 	"github.com/ibrahimker/tigerhall-kittens/common/config"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
 	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/internal/builder"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
 )
 
 // InitGrpc initializes gRPC user management modules.
@@ -22,6 +23,17 @@ func InitGrpc(server *grpc.Server, cfg *config.Config, pool *pgxpool.Pool, rds *
 	tigerv1.RegisterTigerSightingServiceServer(server, sightingBuilder)
 }
 
+// InitWorker registers the background jobs of sighting module to the worker.
+func InitWorker(w *worker.Worker, cfg *config.Config, pool *pgxpool.Pool, rds *goredis.Client, logger *logrus.Entry) {
+	builder.BuildTigerSightingJobs(w, cfg, pool, rds, logger)
+}
+
+// MigrateImages moves the images of sightings stored in database to the image store, reading batchSize images at once,
+// and returns the number of images moved.
+func MigrateImages(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, batchSize int32) (int, error) {
+	return builder.BuildImageMigration(cfg, pool).MoveImagesToStore(ctx, batchSize)
+}
+
 // InitRest initializes REST user management modules.
 // If any error occurs, it logs the error and continue the process.
 func InitRest(ctx context.Context, server *runtime.ServeMux, grpcPort string, logger *logrus.Entry, options ...grpc.DialOption) {
@@ -29,3 +41,11 @@ func InitRest(ctx context.Context, server *runtime.ServeMux, grpcPort string, lo
 		logging.WithError(err, logger).Error("RegisterTigerSightingServiceHandlerFromEndpoint failed to be registered")
 	}
 }
+
+// InitImageRest initializes REST handlers of sighting images, which are streamed rather than proxied to gRPC.
+// If any error occurs, it logs the error and continue the process.
+func InitImageRest(server *runtime.ServeMux, cfg *config.Config, pool *pgxpool.Pool, logger *logrus.Entry) {
+	if err := builder.BuildSightingImageHandler(cfg, pool, server, logger).Register(); err != nil {
+		logging.WithError(err, logger).Error("SightingImage handler failed to be registered")
+	}
+}
@@ -2,28 +2,93 @@ package sightingv1_test
 
 import (
 	"context"
+	"encoding/base64"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redismock/v8"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	tigerv1 "github.com/ibrahimker/tigerhall-kittens/api/proto"
 	"github.com/ibrahimker/tigerhall-kittens/common/config"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
 	sightingv1 "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1"
+	"github.com/ibrahimker/tigerhall-kittens/server"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
 )
 
 func TestInitGrpc(t *testing.T) {
 	t.Run("successfully build Tiger Sighting GRPC", func(t *testing.T) {
 		pool := &pgxpool.Pool{}
-		cfg, _ := config.NewConfig("../../../../test/fixture/env.valid")
+		cfg, _ := config.NewConfig("../../../test/fixture/env.valid")
 		rds, _ := redismock.NewClientMock()
 
 		sightingv1.InitGrpc(grpc.NewServer(), cfg, pool, rds, logging.NewTestLogger())
 	})
 }
 
+func TestInitGrpc_ImageLimit(t *testing.T) {
+	cfg, err := config.NewConfig("../../../test/fixture/env.valid")
+	require.NoError(t, err)
+	rds, _ := redismock.NewClientMock()
+	srv := server.NewDevelopmentGrpc(cfg.Port.GRPC, logging.NewTestLogger(), server.MaxRecvMsgSize(cfg.ImageLimits))
+	defer srv.Stop()
+	sightingv1.InitGrpc(srv.Server, cfg, &pgxpool.Pool{}, rds, logging.NewTestLogger())
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(listener) }()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(64<<20)))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := tigerv1.NewTigerSightingServiceClient(conn)
+	createSighting := func(imageData string) error {
+		_, err := client.CreateSighting(context.Background(), &tigerv1.CreateSightingRequest{
+			Id: 1, SeenAt: timestamppb.New(time.Now().Add(-time.Minute)),
+			Latitude: wrapperspb.Double(-6.18), Longitude: wrapperspb.Double(106.82), ImageData: imageData,
+		})
+		return err
+	}
+
+	t.Run("Error image larger than the limit, in a request larger than the default gRPC limit", func(t *testing.T) {
+		// the base64 encoded image is about 13.3 MiB, far more than the default 4 MiB of gRPC
+		imageData := "data:image/png;base64," + base64.StdEncoding.EncodeToString(make([]byte, cfg.ImageLimits.MaxBytes+1))
+
+		err := createSighting(imageData)
+
+		require.Equal(t, codes.InvalidArgument, status.Code(err), err)
+		require.Contains(t, err.Error(), "image is larger than 10485760 bytes")
+	})
+
+	t.Run("Error request far larger than the image limit", func(t *testing.T) {
+		err := createSighting(strings.Repeat("A", 2*cfg.ImageLimits.MaxBytes))
+
+		require.Equal(t, codes.ResourceExhausted, status.Code(err), err)
+	})
+}
+
+func TestInitWorker(t *testing.T) {
+	t.Run("successfully build Tiger Sighting jobs", func(t *testing.T) {
+		pool := &pgxpool.Pool{}
+		cfg, _ := config.NewConfig("../../../test/fixture/env.valid")
+		rds, _ := redismock.NewClientMock()
+		w := worker.NewWorker(worker.NewRedisQueue(rds, cfg.Worker.PollInterval, cfg.Worker.Lease), logging.NewTestLogger(), &cfg.Worker)
+
+		sightingv1.InitWorker(w, cfg, pool, rds, logging.NewTestLogger())
+	})
+}
+
 func TestInitRest(t *testing.T) {
 	t.Run("successfully build Tiger Sighting Rest", func(t *testing.T) {
 		options := grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(20000000))
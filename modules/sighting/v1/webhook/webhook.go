@@ -0,0 +1,290 @@
+// Package webhook pushes new sightings to the endpoints partners subscribed.
+// Each event is POSTed as JSON and signed with the secret of the subscription, so that receivers can check
+// it was sent by us and was not altered. Deliveries are background jobs retried with the backoff of the worker,
+// and every attempt is logged so that partners can see why they missed an event.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+// JobDeliverWebhook is the type of the job POSTing one webhook event to one subscription.
+const JobDeliverWebhook = "sighting.deliver_webhook"
+
+// Headers sent with every delivery
+const (
+	// HeaderID is the ID of the delivery, the same for every attempt. Receivers should ignore IDs they already processed.
+	HeaderID = "X-Webhook-Id"
+	// HeaderEvent is the type of the event.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderTimestamp is the Unix time in seconds the attempt was sent at.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is "sha256=" followed by the signature computed by Sign.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxResponseSize is the number of bytes of the response read before the connection is reused. The rest is ignored.
+const maxResponseSize = 64 << 10
+
+// ErrForbiddenAddress is returned when a delivery would connect to an address of a private network.
+var ErrForbiddenAddress = errors.New("address of a private network is forbidden")
+
+// forbiddenNetworks lists the special purpose networks not covered by the methods of net.IP.
+var forbiddenNetworks = parseNetworks(
+	"0.0.0.0/8",     // this network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved, including broadcast
+	"64:ff9b::/96",  // NAT64, which could translate to a private IPv4 address
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// IsPublicIP reports whether deliveries may connect to ip.
+// Loopback, private, link-local (e.g. the cloud metadata 169.254.169.254), multicast and reserved addresses are not public,
+// so that subscriptions can't reach the internal network.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// controlPublicAddress is the net.Dialer control refusing to connect to an address which isn't public.
+// It runs after the host is resolved, so a host resolving to a private address is refused too, even if it changes.
+func controlPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp header, a dot and the body, keyed by the secret.
+// The timestamp is signed so that receivers can reject replayed deliveries.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Repository defines the interface to webhook subscription storage.
+type Repository interface {
+	// GetWebhookSubscriptionsForSighting get webhook subscriptions matching a sighting of given tiger ID at the given point
+	GetWebhookSubscriptionsForSighting(ctx context.Context, tigerID int32, latitude, longitude float64) ([]*entity.WebhookSubscription, error)
+	// GetWebhookSubscriptionByID get webhook subscription by ID with its secret
+	// It returns entity.ErrWebhookSubscriptionNotFound if the subscription doesn't exist or has been deleted.
+	GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int32) (*entity.WebhookSubscription, error)
+	// CreateWebhookDeliveryAttempt store a new delivery attempt
+	CreateWebhookDeliveryAttempt(ctx context.Context, attempt *entity.WebhookDeliveryAttempt) error
+}
+
+// delivery is the payload of a JobDeliverWebhook job.
+// Body is encoded once, so that every attempt sends the same bytes.
+type delivery struct {
+	SubscriptionID int32           `json:"subscription_id"`
+	DeliveryID     string          `json:"delivery_id"`
+	EventType      string          `json:"event_type"`
+	Body           json.RawMessage `json:"body"`
+}
+
+// Dispatcher is responsible for turning sighting recorded events into delivery jobs.
+type Dispatcher struct {
+	repo  Repository
+	queue worker.Queue
+}
+
+// NewDispatcher creates an instance of Dispatcher.
+func NewDispatcher(repo Repository, queue worker.Queue) *Dispatcher {
+	return &Dispatcher{repo: repo, queue: queue}
+}
+
+// Handle enqueues a JobDeliverWebhook for each subscription matching the sighting of an EventSightingRecorded event.
+// If one of them fails the event is retried, so the subscriptions before it may receive the delivery twice,
+// with the same delivery ID.
+func (d *Dispatcher) Handle(ctx context.Context, event *entity.OutboxEvent) error {
+	var payload entity.SightingRecordedEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return err
+	}
+
+	subscriptions, err := d.repo.GetWebhookSubscriptionsForSighting(ctx, payload.TigerID, payload.Latitude, payload.Longitude)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		deliveryID := fmt.Sprintf("%d-%d", event.ID, subscription.ID)
+		// copy the fields one by one, the reporters must never leave our system
+		body, err := json.Marshal(&entity.WebhookEvent{
+			ID:        deliveryID,
+			Type:      entity.WebhookEventSightingRecorded,
+			CreatedAt: event.CreatedAt,
+			Data: &entity.WebhookSighting{
+				SightingID: payload.SightingID,
+				TigerID:    payload.TigerID,
+				TigerName:  payload.TigerName,
+				SeenAt:     payload.SeenAt,
+				Latitude:   payload.Latitude,
+				Longitude:  payload.Longitude,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(&delivery{
+			SubscriptionID: subscription.ID,
+			DeliveryID:     deliveryID,
+			EventType:      entity.WebhookEventSightingRecorded,
+			Body:           body,
+		})
+		if err != nil {
+			return err
+		}
+		if err = d.queue.Enqueue(ctx, JobDeliverWebhook, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliverer is responsible for POSTing the event of a JobDeliverWebhook job to its subscription.
+type Deliverer struct {
+	repo   Repository
+	client *http.Client
+}
+
+// NewDeliverer creates an instance of Deliverer.
+// Redirects are not followed, a redirect response is a failed attempt.
+// Connections to addresses which aren't public fail, unless private networks are allowed by the configuration.
+// No proxy is used, since the proxy would connect to the address instead.
+func NewDeliverer(repo Repository, cfg *config.Webhook) *Deliverer {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = controlPublicAddress
+	}
+	return &Deliverer{
+		repo: repo,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Handle POSTs the event of the job and logs the attempt. The job is dropped if the subscription was deleted.
+// A response other than 2xx is returned as an error so that the job is retried.
+func (d *Deliverer) Handle(ctx context.Context, job *worker.Job) error {
+	var payload delivery
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+
+	subscription, err := d.repo.GetWebhookSubscriptionByID(ctx, payload.SubscriptionID)
+	if errors.Is(err, entity.ErrWebhookSubscriptionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	statusCode, err := d.post(ctx, subscription, &payload)
+	attempt := &entity.WebhookDeliveryAttempt{
+		SubscriptionID: subscription.ID,
+		DeliveryID:     payload.DeliveryID,
+		EventType:      payload.EventType,
+		Attempt:        int32(job.Attempts),
+		StatusCode:     int32(statusCode),
+		Duration:       time.Since(start),
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	// a delivery isn't repeated only because its log is missing
+	if rerr := d.repo.CreateWebhookDeliveryAttempt(ctx, attempt); rerr != nil {
+		logger := logging.FromContext(ctx).WithFields(logrus.Fields{"delivery_id": payload.DeliveryID})
+		logging.WithError(rerr, logger).Warn("Error when get from repo.CreateWebhookDeliveryAttempt")
+	}
+
+	return err
+}
+
+// post sends the signed body to the subscription and returns the status code of the response, 0 if there is none.
+func (d *Deliverer) post(ctx context.Context, subscription *entity.WebhookSubscription, payload *delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(payload.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tigerhall-kittens-webhook/1.0")
+	req.Header.Set(HeaderID, payload.DeliveryID)
+	req.Header.Set(HeaderEvent, payload.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(subscription.Secret, timestamp, payload.Body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
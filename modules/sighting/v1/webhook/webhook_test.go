@@ -0,0 +1,415 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+	"github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/webhook"
+	mock_webhook "github.com/ibrahimker/tigerhall-kittens/test/mock/modules/sighting/v1/webhook"
+	mock_worker "github.com/ibrahimker/tigerhall-kittens/test/mock/worker"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+type WebhookTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+var (
+	// webhookConfig allows private networks, since the receivers of the tests listen on the loopback
+	webhookConfig = &config.Webhook{Timeout: time.Second, AllowPrivateNetworks: true}
+	createdAt     = time.Date(2022, 1, 1, 12, 0, 1, 0, time.UTC)
+	// sightingRecorded is an event of a sighting of tiger 1 reported after two other rangers
+	sightingRecorded = &entity.OutboxEvent{
+		ID:          42,
+		Type:        entity.EventSightingRecorded,
+		AggregateID: 1,
+		Payload: []byte(`{"sighting_id":3,"tiger_id":1,"tiger_name":"Tiger-1","seen_at":"2022-01-01T12:00:00Z",` +
+			`"latitude":-6.18,"longitude":108,"reporter_name":"Ranger","prior_reporters":["a@example.com","b@example.com"]}`),
+		CreatedAt: createdAt,
+	}
+)
+
+// deliveryMatcher matches the attempt of the delivery with the given status code and error, whatever its duration.
+type deliveryMatcher struct {
+	want entity.WebhookDeliveryAttempt
+}
+
+func deliveryAttempt(attempt int32, statusCode int32, err string) gomock.Matcher {
+	return deliveryMatcher{want: entity.WebhookDeliveryAttempt{
+		SubscriptionID: 7,
+		DeliveryID:     "42-7",
+		EventType:      entity.WebhookEventSightingRecorded,
+		Attempt:        attempt,
+		StatusCode:     statusCode,
+		Error:          err,
+	}}
+}
+
+func (m deliveryMatcher) Matches(x interface{}) bool {
+	attempt, ok := x.(*entity.WebhookDeliveryAttempt)
+	if !ok || attempt.Duration < 0 {
+		return false
+	}
+	got := *attempt
+	got.Duration = 0
+	if m.want.Error != "" && strings.Contains(got.Error, m.want.Error) {
+		got.Error = m.want.Error
+	}
+	return got == m.want
+}
+
+func (m deliveryMatcher) String() string {
+	return fmt.Sprintf("is attempt %d with status %d and error %q", m.want.Attempt, m.want.StatusCode, m.want.Error)
+}
+
+// dispatch runs the dispatcher on sightingRecorded for a subscription and returns the job it enqueued.
+func dispatch(t *testing.T, subscription *entity.WebhookSubscription) *worker.Job {
+	ctrl := gomock.NewController(t)
+	repo := mock_webhook.NewMockRepository(ctrl)
+	queue := mock_worker.NewMockQueue(ctrl)
+	repo.EXPECT().GetWebhookSubscriptionsForSighting(gomock.Any(), int32(1), -6.18, 108.0).
+		Return([]*entity.WebhookSubscription{{ID: subscription.ID, URL: subscription.URL}}, nil)
+
+	var job *worker.Job
+	queue.EXPECT().Enqueue(gomock.Any(), webhook.JobDeliverWebhook, gomock.Any()).
+		DoAndReturn(func(_ context.Context, jobType string, payload []byte) error {
+			job = &worker.Job{ID: "1", Type: jobType, Payload: payload, Attempts: 1}
+			return nil
+		})
+
+	require.NoError(t, webhook.NewDispatcher(repo, queue).Handle(context.Background(), sightingRecorded))
+	return job
+}
+
+// receiver is a local webhook endpoint recording the requests it receives.
+type receiver struct {
+	*httptest.Server
+	requests chan *http.Request
+	bodies   chan []byte
+}
+
+func newReceiver(t *testing.T, handler http.HandlerFunc) *receiver {
+	r := &receiver{requests: make(chan *http.Request, 10), bodies: make(chan []byte, 10)}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.requests <- req
+		r.bodies <- body
+		handler(w, req)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '1640995200.{"id":"1"}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "9625e5f87371d2aa9b2221e4cdb95f07ee8113467e1789c738bf69abc6f551ff",
+		webhook.Sign("secret", "1640995200", []byte(`{"id":"1"}`)))
+	require.NotEqual(t, webhook.Sign("secret", "1640995200", []byte(`{"id":"1"}`)), webhook.Sign("other", "1640995200", []byte(`{"id":"1"}`)))
+	require.NotEqual(t, webhook.Sign("secret", "1640995200", []byte(`{"id":"1"}`)), webhook.Sign("secret", "1640995201", []byte(`{"id":"1"}`)))
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+	for _, ip := range []string{"93.184.216.34", "8.8.8.8", "2606:2800:220:1:248:1893:25c8:1946"} {
+		require.True(t, webhook.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1",
+		"255.255.255.255", "224.0.0.1", "::1", "::", "fe80::1", "fc00::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254", "64:ff9b::a00:1",
+	} {
+		require.False(t, webhook.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestDispatcher_Handle(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []WebhookTestCase{
+		{
+			testcaseName: "successfully enqueue a delivery job for each matching subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				repo := mock_webhook.NewMockRepository(ctrl)
+				queue := mock_worker.NewMockQueue(ctrl)
+				repo.EXPECT().GetWebhookSubscriptionsForSighting(mockCtx, int32(1), -6.18, 108.0).
+					Return([]*entity.WebhookSubscription{{ID: 7}, {ID: 8}}, nil)
+
+				var payloads []string
+				queue.EXPECT().Enqueue(mockCtx, webhook.JobDeliverWebhook, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, payload []byte) error {
+						payloads = append(payloads, string(payload))
+						return nil
+					}).Times(2)
+
+				require.NoError(t, webhook.NewDispatcher(repo, queue).Handle(mockCtx, sightingRecorded))
+				body := `{"id":"42-%d","type":"sighting.recorded","created_at":"2022-01-01T12:00:01Z",` +
+					`"data":{"sighting_id":3,"tiger_id":1,"tiger_name":"Tiger-1","seen_at":"2022-01-01T12:00:00Z","latitude":-6.18,"longitude":108}}`
+				require.Equal(t, []string{
+					`{"subscription_id":7,"delivery_id":"42-7","event_type":"sighting.recorded","body":` + strings.Replace(body, "%d", "7", 1) + `}`,
+					`{"subscription_id":8,"delivery_id":"42-8","event_type":"sighting.recorded","body":` + strings.Replace(body, "%d", "8", 1) + `}`,
+				}, payloads)
+				for _, payload := range payloads {
+					require.NotContains(t, payload, "example.com")
+					require.NotContains(t, payload, "Ranger")
+				}
+			},
+		},
+		{
+			testcaseName: "successfully enqueue nothing without matching subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				repo := mock_webhook.NewMockRepository(ctrl)
+				repo.EXPECT().GetWebhookSubscriptionsForSighting(mockCtx, int32(1), -6.18, 108.0).Return(nil, nil)
+
+				require.NoError(t, webhook.NewDispatcher(repo, mock_worker.NewMockQueue(ctrl)).Handle(mockCtx, sightingRecorded))
+			},
+		},
+		{
+			testcaseName: "Error invalid event payload",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+
+				err := webhook.NewDispatcher(mock_webhook.NewMockRepository(ctrl), mock_worker.NewMockQueue(ctrl)).
+					Handle(mockCtx, &entity.OutboxEvent{Type: entity.EventSightingRecorded, Payload: []byte(`not-json`)})
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "Error when get matching subscriptions",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				repo := mock_webhook.NewMockRepository(ctrl)
+				repo.EXPECT().GetWebhookSubscriptionsForSighting(mockCtx, int32(1), -6.18, 108.0).Return(nil, errors.New("db error"))
+
+				require.Error(t, webhook.NewDispatcher(repo, mock_worker.NewMockQueue(ctrl)).Handle(mockCtx, sightingRecorded))
+			},
+		},
+		{
+			testcaseName: "Error when enqueue delivery job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+				repo := mock_webhook.NewMockRepository(ctrl)
+				queue := mock_worker.NewMockQueue(ctrl)
+				repo.EXPECT().GetWebhookSubscriptionsForSighting(mockCtx, int32(1), -6.18, 108.0).
+					Return([]*entity.WebhookSubscription{{ID: 7}, {ID: 8}}, nil)
+				queue.EXPECT().Enqueue(mockCtx, webhook.JobDeliverWebhook, gomock.Any()).Return(errors.New("redis error"))
+
+				require.Error(t, webhook.NewDispatcher(repo, queue).Handle(mockCtx, sightingRecorded))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestDeliverer_Handle(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+
+	testCases := []WebhookTestCase{
+		{
+			testcaseName: "successfully deliver a signed event to the receiver",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL + "/hook", Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, http.StatusNoContent, "")).Return(nil)
+
+				start := time.Now()
+				require.NoError(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+
+				req, body := <-rcv.requests, <-rcv.bodies
+				require.Equal(t, http.MethodPost, req.Method)
+				require.Equal(t, "/hook", req.URL.Path)
+				require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+				require.Equal(t, "42-7", req.Header.Get(webhook.HeaderID))
+				require.Equal(t, entity.WebhookEventSightingRecorded, req.Header.Get(webhook.HeaderEvent))
+				timestamp := req.Header.Get(webhook.HeaderTimestamp)
+				sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+				require.NoError(t, err)
+				require.InDelta(t, start.Unix(), sentAt, 1)
+				require.Equal(t, "sha256="+webhook.Sign("secret", timestamp, body), req.Header.Get(webhook.HeaderSignature))
+
+				var event struct {
+					ID   string                 `json:"id"`
+					Type string                 `json:"type"`
+					Data map[string]interface{} `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(body, &event))
+				require.Equal(t, "42-7", event.ID)
+				require.Equal(t, entity.WebhookEventSightingRecorded, event.Type)
+				require.Equal(t, "Tiger-1", event.Data["tiger_name"])
+				require.NotContains(t, event.Data, "prior_reporters")
+				require.NotContains(t, string(body), "@")
+			},
+		},
+		{
+			testcaseName: "failed delivery is logged and returned to be retried",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				job.Attempts = 3
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(3, http.StatusServiceUnavailable, "unexpected status 503")).Return(nil)
+
+				err := webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job)
+				require.EqualError(t, err, "unexpected status 503")
+			},
+		},
+		{
+			testcaseName: "redirect is not followed",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(w http.ResponseWriter, req *http.Request) {
+					http.Redirect(w, req, "/elsewhere", http.StatusFound)
+				})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, http.StatusFound, "unexpected status 302")).Return(nil)
+
+				require.Error(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+				require.Len(t, rcv.requests, 1)
+			},
+		},
+		{
+			testcaseName: "slow receiver times out",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(w http.ResponseWriter, _ *http.Request) {
+					time.Sleep(100 * time.Millisecond)
+				})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, 0, "Client.Timeout exceeded")).Return(nil)
+
+				err := webhook.NewDeliverer(repo, &config.Webhook{Timeout: 10 * time.Millisecond, AllowPrivateNetworks: true}).Handle(mockCtx, job)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "unreachable receiver is logged without status",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(http.ResponseWriter, *http.Request) {})
+				rcv.Close()
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, 0, "connection refused")).Return(nil)
+
+				require.Error(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+			},
+		},
+		{
+			testcaseName: "Error connect to private address",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(http.ResponseWriter, *http.Request) {})
+				// the host is only resolved to the loopback when connecting, like a host rebound to a private address
+				url := strings.Replace(rcv.URL, "127.0.0.1", "localhost", 1)
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: url})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: url, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, 0, webhook.ErrForbiddenAddress.Error())).Return(nil)
+
+				err := webhook.NewDeliverer(repo, &config.Webhook{Timeout: time.Second}).Handle(mockCtx, job)
+				require.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+				require.Empty(t, rcv.requests)
+			},
+		},
+		{
+			testcaseName: "successfully deliver even if the attempt is not logged",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(http.ResponseWriter, *http.Request) {})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).
+					Return(&entity.WebhookSubscription{ID: 7, URL: rcv.URL, Secret: "secret"}, nil)
+				repo.EXPECT().CreateWebhookDeliveryAttempt(mockCtx, deliveryAttempt(1, http.StatusOK, "")).Return(errors.New("db error"))
+
+				require.NoError(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+			},
+		},
+		{
+			testcaseName: "successfully drop delivery of deleted subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rcv := newReceiver(t, func(http.ResponseWriter, *http.Request) {})
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7, URL: rcv.URL})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).Return(nil, entity.ErrWebhookSubscriptionNotFound)
+
+				require.NoError(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+				require.Empty(t, rcv.requests)
+			},
+		},
+		{
+			testcaseName: "Error when get subscription",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				job := dispatch(t, &entity.WebhookSubscription{ID: 7})
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+				repo.EXPECT().GetWebhookSubscriptionByID(mockCtx, int32(7)).Return(nil, errors.New("db error"))
+
+				require.Error(t, webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, job))
+			},
+		},
+		{
+			testcaseName: "Error invalid job payload",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				repo := mock_webhook.NewMockRepository(gomock.NewController(t))
+
+				err := webhook.NewDeliverer(repo, webhookConfig).Handle(mockCtx, &worker.Job{Type: webhook.JobDeliverWebhook, Payload: []byte(`not-json`)})
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
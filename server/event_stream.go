@@ -0,0 +1,90 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MIMEEventStream is the media type of Server-Sent Events.
+const MIMEEventStream = "text/event-stream"
+
+// EventStreamMarshaler writes the responses of server-streaming calls as Server-Sent Events.
+// The gateway uses it for requests sent with "Accept: text/event-stream", like the ones of the browser EventSource.
+// Each response is a message event whose data is the JSON response. An error is an error event whose data is the JSON status.
+type EventStreamMarshaler struct {
+	runtime.Marshaler
+}
+
+// NewEventStreamMarshaler creates an instance of EventStreamMarshaler encoding JSON like the default marshaler of the gateway.
+func NewEventStreamMarshaler() *EventStreamMarshaler {
+	return &EventStreamMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+}
+
+// ContentType returns the media type of Server-Sent Events.
+func (m *EventStreamMarshaler) ContentType(_ interface{}) string {
+	return MIMEEventStream
+}
+
+// Marshal encodes v into an event.
+// The {"result": response} and {"error": status} chunks written by the gateway for streams are unwrapped.
+func (m *EventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	event := ""
+	switch chunk := v.(type) {
+	case map[string]interface{}:
+		if result, ok := chunk["result"]; ok && len(chunk) == 1 {
+			v = result
+		}
+	case map[string]proto.Message:
+		if st, ok := chunk["error"]; ok && len(chunk) == 1 {
+			v, event = st, "error"
+		}
+	case *spb.Status:
+		event = "error"
+	}
+
+	data, err := m.Marshaler.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	// the gateway writes no delimiter after an error, the blank line ending the event is written here
+	if event == "error" {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Delimiter returns the blank line ending the event of a response.
+func (m *EventStreamMarshaler) Delimiter() []byte {
+	return []byte("\n")
+}
+
+// eventStreamHeaders stops caches and proxies from buffering the responses sent as Server-Sent Events.
+func eventStreamHeaders(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == MIMEEventStream {
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ibrahimker/tigerhall-kittens/server"
+)
+
+// forwardEventStream forwards the responses returned by recv as the gateway does for a server-streaming call.
+func forwardEventStream(recv func() (proto.Message, error)) *httptest.ResponseRecorder {
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(server.MIMEEventStream, server.NewEventStreamMarshaler()))
+	req := httptest.NewRequest(http.MethodGet, "/v1/sighting:watch", nil)
+	req.Header.Set("Accept", server.MIMEEventStream)
+	_, marshaler := runtime.MarshalerForRequest(mux, req)
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+
+	w := httptest.NewRecorder()
+	runtime.ForwardResponseStream(ctx, mux, marshaler, w, req, recv)
+	return w
+}
+
+func TestEventStreamMarshaler(t *testing.T) {
+	t.Run("successfully send each response as an event", func(t *testing.T) {
+		responses := []proto.Message{wrapperspb.String("first"), wrapperspb.String("second")}
+		w := forwardEventStream(func() (proto.Message, error) {
+			if len(responses) == 0 {
+				return nil, io.EOF
+			}
+			res := responses[0]
+			responses = responses[1:]
+			return res, nil
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, server.MIMEEventStream, w.Header().Get("Content-Type"))
+		assert.Equal(t, "data: \"first\"\n\ndata: \"second\"\n\n", w.Body.String())
+	})
+
+	t.Run("successfully send an error as an error event", func(t *testing.T) {
+		sent := false
+		w := forwardEventStream(func() (proto.Message, error) {
+			if !sent {
+				sent = true
+				return wrapperspb.String("first"), nil
+			}
+			return nil, status.Error(codes.Unavailable, "watch again")
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "data: \"first\"\n\nevent: error\ndata: {\"code\":14,\"message\":\"watch again\",\"details\":[]}\n\n", w.Body.String())
+	})
+
+	t.Run("successfully send an error before any response with its HTTP status", func(t *testing.T) {
+		w := forwardEventStream(func() (proto.Message, error) {
+			return nil, status.Error(codes.InvalidArgument, "invalid bounding box")
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "event: error\ndata: {\"code\":3,")
+	})
+}
@@ -2,6 +2,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -21,11 +22,15 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
 )
 
 const (
 	connProtocol = "tcp"
+	// requestHeadroom is the size of a request allowed besides its base64 encoded image,
+	// i.e. the data URI prefix, the other fields and their encoding.
+	requestHeadroom = 1 << 20
 )
 
 // Grpc is responsible to act as gRPC server.
@@ -45,16 +50,26 @@ func NewGrpc(port string, options ...grpc.ServerOption) *Grpc {
 	}
 }
 
+// MaxRecvMsgSize returns the option letting the server receive a request holding an image of the maximum size
+// once base64 encoded, which is 4/3 of its size. Larger requests are rejected with ResourceExhausted
+// before being read, while images between the limits are rejected with InvalidArgument by their service.
+func MaxRecvMsgSize(limits config.ImageLimits) grpc.ServerOption {
+	return grpc.MaxRecvMsgSize((limits.MaxBytes+2)/3*4 + requestHeadroom)
+}
+
 // NewDevelopmentGrpc creates an instance of Grpc for used in development environment.
+// The options are added to the ones of the interceptors.
 //
-// These are list of interceptors that are attached (from innermost to outermost):
+// These are list of interceptors that are attached to unary and stream calls (from innermost to outermost):
+// 	- Error translator, using common/errors.
 // 	- Metrics, using Prometheus.
 // 	- Logging, using logrus/zap.
 // 	- Recoverer, using grpc_recovery.
-func NewDevelopmentGrpc(port string, logger *logrus.Entry) *Grpc {
-	options := grpc_middleware.WithUnaryServerChain(defaultUnaryServerInterceptors(logger)...)
+func NewDevelopmentGrpc(port string, logger *logrus.Entry, options ...grpc.ServerOption) *Grpc {
+	unaryOptions := grpc_middleware.WithUnaryServerChain(defaultUnaryServerInterceptors(logger)...)
+	streamOptions := grpc_middleware.WithStreamServerChain(defaultStreamServerInterceptors(logger)...)
 
-	srv := NewGrpc(port, options)
+	srv := NewGrpc(port, append([]grpc.ServerOption{unaryOptions, streamOptions}, options...)...)
 	grpc_prometheus.Register(srv.Server)
 	return srv
 }
@@ -62,21 +77,25 @@ func NewDevelopmentGrpc(port string, logger *logrus.Entry) *Grpc {
 // NewProductionGrpc creates an instance of Grpc with default production options attached.
 // Actually, it can be used for non-production environment (such as staging or sandbox) as long as the environment satisfies all prerequisites.
 //
-// These are list of interceptors that are attached (from innermost to outermost):
+// These are list of interceptors that are attached to unary and stream calls (from innermost to outermost):
+// 	- Error translator, using common/errors.
 // 	- Metrics, using Prometheus.
 // 	- Logging, using logrus/zap.
 // 	- Recoverer, using grpc_recovery.
 // 	- Error Reporter, using Google Cloud Error Reporter.
 //
+// The requests may hold an image of the maximum size of cfg.ImageLimits.
+//
 // It also activates some auxiliaries:
 // 	- Profiler, using Google Cloud Profiler.
 // 	- Tracing, using Google Cloud Stackdriver Trace. The sample probability is 1% for production environment. Otherwise, it is 100%.
 func NewProductionGrpc(cfg *config.Config, logger *logrus.Entry) (*Grpc, error) {
 	midds := []grpc.UnaryServerInterceptor{}
 	midds = append(midds, defaultUnaryServerInterceptors(logger)...)
-	options := grpc_middleware.WithUnaryServerChain(midds...)
+	unaryOptions := grpc_middleware.WithUnaryServerChain(midds...)
+	streamOptions := grpc_middleware.WithStreamServerChain(defaultStreamServerInterceptors(logger)...)
 
-	srv := NewGrpc(cfg.Port.GRPC, grpc.StatsHandler(&ocgrpc.ServerHandler{}), options)
+	srv := NewGrpc(cfg.Port.GRPC, grpc.StatsHandler(&ocgrpc.ServerHandler{}), MaxRecvMsgSize(cfg.ImageLimits), unaryOptions, streamOptions)
 	grpc_prometheus.Register(srv.Server)
 
 	return srv, nil
@@ -117,7 +136,31 @@ func (g *Grpc) serve() {
 func defaultUnaryServerInterceptors(logger *logrus.Entry) []grpc.UnaryServerInterceptor {
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
-	skipHealthCheckLog := []grpc_logrus.Option{
+	options := []grpc.UnaryServerInterceptor{
+		grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(recoveryHandler)),
+		grpc_logrus.UnaryServerInterceptor(logger, skipHealthCheckLog()...),
+		logging.UnaryServerInterceptor(false),
+		grpc_prometheus.UnaryServerInterceptor,
+		ErrorUnaryServerInterceptor,
+	}
+	return options
+}
+
+func defaultStreamServerInterceptors(logger *logrus.Entry) []grpc.StreamServerInterceptor {
+	grpc_prometheus.EnableHandlingTimeHistogram()
+
+	options := []grpc.StreamServerInterceptor{
+		grpc_recovery.StreamServerInterceptor(grpc_recovery.WithRecoveryHandler(recoveryHandler)),
+		grpc_logrus.StreamServerInterceptor(logger, skipHealthCheckLog()...),
+		logging.StreamServerInterceptor(false),
+		grpc_prometheus.StreamServerInterceptor,
+		ErrorStreamServerInterceptor,
+	}
+	return options
+}
+
+func skipHealthCheckLog() []grpc_logrus.Option {
+	return []grpc_logrus.Option{
 		grpc_logrus.WithDecider(func(methodFullName string, err error) bool {
 			service := path.Dir(methodFullName)[1:]
 			method := path.Base(methodFullName)
@@ -129,14 +172,19 @@ func defaultUnaryServerInterceptors(logger *logrus.Entry) []grpc.UnaryServerInte
 			return true
 		}),
 	}
+}
 
-	options := []grpc.UnaryServerInterceptor{
-		grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(recoveryHandler)),
-		grpc_logrus.UnaryServerInterceptor(logger, skipHealthCheckLog...),
-		logging.UnaryServerInterceptor(false),
-		grpc_prometheus.UnaryServerInterceptor,
-	}
-	return options
+// ErrorUnaryServerInterceptor converts errors defined in common/errors into gRPC status with the matching code.
+// Validation errors carry google.rpc.BadRequest details, so REST clients receive per-field 400 responses.
+func ErrorUnaryServerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, apperrors.ToGRPCError(err)
+}
+
+// ErrorStreamServerInterceptor converts errors defined in common/errors into gRPC status with the matching code,
+// like ErrorUnaryServerInterceptor does for unary calls.
+func ErrorStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return apperrors.ToGRPCError(handler(srv, ss))
 }
 
 func recoveryHandler(p interface{}) error {
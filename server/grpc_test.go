@@ -1,11 +1,19 @@
 package server_test
 
 import (
+	"context"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
 
+	apperrors "github.com/ibrahimker/tigerhall-kittens/common/errors"
 	"github.com/ibrahimker/tigerhall-kittens/common/logging"
 	"github.com/ibrahimker/tigerhall-kittens/server"
 )
@@ -55,3 +63,82 @@ func TestGrpc_Run(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestErrorUnaryServerInterceptor(t *testing.T) {
+	t.Run("translate application error", func(t *testing.T) {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, apperrors.NewNotFoundError("tiger not found")
+		}
+
+		_, err := server.ErrorUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("pass through response", func(t *testing.T) {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+
+		resp, err := server.ErrorUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+}
+
+func TestErrorStreamServerInterceptor(t *testing.T) {
+	t.Run("translate application error", func(t *testing.T) {
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			return apperrors.NewNotFoundError("tiger not found")
+		}
+
+		err := server.ErrorStreamServerInterceptor(nil, nil, &grpc.StreamServerInfo{}, handler)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("pass through end of stream", func(t *testing.T) {
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		}
+
+		err := server.ErrorStreamServerInterceptor(nil, nil, &grpc.StreamServerInfo{}, handler)
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestNewDevelopmentGrpc_StreamInterceptors(t *testing.T) {
+	t.Run("recover panicking stream", func(t *testing.T) {
+		srv := server.NewDevelopmentGrpc(testPort, logging.NewTestLogger())
+		defer srv.Stop()
+		srv.RegisterService(&grpc.ServiceDesc{
+			ServiceName: "test.v1.Panic",
+			HandlerType: (*interface{})(nil),
+			Streams: []grpc.StreamDesc{{
+				StreamName:    "Watch",
+				ServerStreams: true,
+				Handler: func(interface{}, grpc.ServerStream) error {
+					panic("boom")
+				},
+			}},
+		}, nil)
+		listener := bufconn.Listen(1024 * 1024)
+		go func() { _ = srv.Serve(listener) }()
+
+		conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+		assert.Nil(t, err)
+		defer conn.Close()
+		stream, err := conn.NewStream(context.Background(), &grpc.StreamDesc{ServerStreams: true}, "/test.v1.Panic/Watch")
+		assert.Nil(t, err)
+		assert.Nil(t, stream.CloseSend())
+
+		err = stream.RecvMsg(&emptypb.Empty{})
+
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		assert.Contains(t, err.Error(), "boom")
+	})
+}
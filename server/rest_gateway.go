@@ -20,10 +20,12 @@ type Rest struct {
 }
 
 // NewRest creates an instance of Rest.
+// Server-streaming calls are sent as Server-Sent Events to requests accepting text/event-stream.
 func NewRest(port string) *Rest {
 	return &Rest{
 		ServeMux: runtime.NewServeMux(
 			runtime.WithIncomingHeaderMatcher(MatcherLoadtestHeader),
+			runtime.WithMarshalerOption(MIMEEventStream, NewEventStreamMarshaler()),
 		),
 		port: port,
 	}
@@ -35,6 +37,7 @@ func NewProductionRest(port string, cfg *config.Config) *Rest {
 	srv := &Rest{
 		ServeMux: runtime.NewServeMux(
 			runtime.WithIncomingHeaderMatcher(MatcherLoadtestHeader),
+			runtime.WithMarshalerOption(MIMEEventStream, NewEventStreamMarshaler()),
 		),
 		port: port,
 	}
@@ -53,7 +56,7 @@ func (r *Rest) EnableHealth() error {
 // It runs inside a goroutine.
 func (r *Rest) Run() error {
 	go func() {
-		if err := http.ListenAndServe(fmt.Sprintf(":%s", r.port), allowCORS(r.ServeMux)); err != nil {
+		if err := http.ListenAndServe(fmt.Sprintf(":%s", r.port), allowCORS(eventStreamHeaders(r.ServeMux))); err != nil {
 			panic(err)
 		}
 	}()
@@ -71,7 +74,7 @@ func allowCORS(h http.Handler) http.Handler {
 		if origin := r.Header.Get("Origin"); origin != "" {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
-				headers := []string{"Content-Type", "Accept", "Authorization", loadtestHeader}
+				headers := []string{"Content-Type", "Accept", "Authorization", "Range", "If-None-Match", loadtestHeader}
 				w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ","))
 				methods := []string{"GET", "HEAD", "POST", "PUT", "DELETE"}
 				w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
@@ -0,0 +1,213 @@
+// Package fakes3 provides an S3 compatible storage keeping objects in memory, to test code using driver/s3.
+// Like MinIO, it accepts only path style requests signed with AWS Signature Version 4.
+package fakes3
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ibrahimker/tigerhall-kittens/driver/s3"
+)
+
+const (
+	// AccessKey is the access key accepted by Server.
+	AccessKey = "fakes3-access-key"
+	// SecretKey is the secret key of AccessKey.
+	SecretKey = "fakes3-secret-key"
+	// Region is the region requests must be signed for.
+	Region = "us-east-1"
+)
+
+// authorizationPattern parses the Authorization header of a request signed with AWS Signature Version 4.
+var authorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/[^,]+, SignedHeaders=([^,]+), Signature=[0-9a-f]+$`)
+
+// Object is an object stored by Server.
+type Object struct {
+	Data         []byte
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Server is an S3 compatible storage listening on a random port of localhost.
+type Server struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]map[string]Object
+}
+
+// NewServer starts a Server with the given empty buckets. It is closed when the test ends.
+func NewServer(t testing.TB, buckets ...string) *Server {
+	t.Helper()
+	s := &Server{buckets: map[string]map[string]Object{}}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = map[string]Object{}
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// URL returns the endpoint of the server, e.g. http://127.0.0.1:41234.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Object returns the object stored under the key in the bucket.
+func (s *Server) Object(bucket, key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.buckets[bucket][key]
+	return o, ok
+}
+
+// PutObject stores an object under the key in the bucket, e.g. to prepare a test.
+func (s *Server) PutObject(bucket, key string, o Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets[bucket][key] = o
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if code, status := authenticate(r, body); code != "" {
+		writeError(w, status, code, "The request is not signed with a valid key.")
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	bucket, key := parts[0], ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects, ok := s.buckets[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		sum := md5.Sum(body)
+		o := Object{Data: body, ContentType: r.Header.Get("Content-Type"), ETag: `"` + hex.EncodeToString(sum[:]) + `"`,
+			LastModified: time.Now().UTC().Truncate(time.Second)}
+		objects[key] = o
+		w.Header().Set("ETag", o.ETag)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		o, ok := objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", o.ContentType)
+		w.Header().Set("ETag", o.ETag)
+		if !o.LastModified.IsZero() {
+			w.Header().Set("Last-Modified", o.LastModified.Format(http.TimeFormat))
+		}
+		start, end, ok := parseRange(r.Header.Get("Range"), len(o.Data))
+		if !ok {
+			writeError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable.")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(end-start))
+		if r.Header.Get("Range") != "" {
+			w.Header().Set("Content-Range", "bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(end-1)+"/"+strconv.Itoa(len(o.Data)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(o.Data[start:end])
+		}
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed.")
+	}
+}
+
+// authenticate checks the signature of the request and returns the error code and status if it is not valid.
+// The signature is computed again from the signed headers, so a request changed after signing is rejected.
+func authenticate(r *http.Request, body []byte) (code string, status int) {
+	match := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return "AccessDenied", http.StatusForbidden
+	}
+	if match[1] != AccessKey {
+		return "InvalidAccessKeyId", http.StatusForbidden
+	}
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != s3.HashPayload(body) {
+		return "XAmzContentSHA256Mismatch", http.StatusBadRequest
+	}
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return "AccessDenied", http.StatusForbidden
+	}
+
+	signed := &http.Request{Method: r.Method, URL: r.URL, Host: r.Host, Header: http.Header{}}
+	for _, name := range strings.Split(match[2], ";") {
+		if name != "host" {
+			signed.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+		}
+	}
+	s3.NewSigner(AccessKey, SecretKey, Region).Sign(signed, payloadHash, signedAt)
+	if signed.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		return "SignatureDoesNotMatch", http.StatusForbidden
+	}
+	return "", 0
+}
+
+// parseRange returns the bytes [start, end) of an object of the size requested by the Range header,
+// supporting a single bytes=<first>- or bytes=<first>-<last> range. No header means the whole object.
+func parseRange(header string, size int) (start, end int, ok bool) {
+	if header == "" {
+		return 0, size, true
+	}
+	bounds := strings.SplitN(strings.TrimPrefix(header, "bytes="), "-", 2)
+	if len(bounds) != 2 || !strings.HasPrefix(header, "bytes=") {
+		return 0, 0, false
+	}
+	start, err := strconv.Atoi(bounds[0])
+	if err != nil || start >= size {
+		return 0, 0, false
+	}
+	end = size
+	if bounds[1] != "" {
+		last, err := strconv.Atoi(bounds[1])
+		if err != nil || last < start {
+			return 0, 0, false
+		}
+		if last+1 < size {
+			end = last + 1
+		}
+	}
+	return start, end, true
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,188 @@
+// Package fakesmtp provides an SMTP server storing received emails in memory, to test code sending emails.
+package fakesmtp
+
+import (
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Message is an email received by Server.
+type Message struct {
+	From string
+	To   []string
+	// Data is the content of the email, headers included, with LF line endings.
+	Data string
+}
+
+// Server is an SMTP server listening on a random port of localhost.
+type Server struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu       sync.Mutex
+	messages []Message
+	wg       sync.WaitGroup
+}
+
+// Option configures Server.
+type Option func(*Server)
+
+// WithAuth makes the server require AUTH PLAIN with the given credentials before accepting emails.
+func WithAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// NewServer starts a Server. It is closed when the test ends.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("fakesmtp: failed to listen: %v", err)
+	}
+	s := &Server{listener: listener}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.wg.Add(1)
+	go s.accept()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Host returns the host the server listens on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+// Messages returns the emails received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server and waits for open connections to end.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer s.wg.Done()
+	tc := textproto.NewConn(conn)
+	defer tc.Close()
+
+	authenticated := s.username == ""
+	var msg Message
+	_ = tc.PrintfLine("220 localhost fakesmtp ready")
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg := line, ""
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			verb, arg = line[:i], line[i+1:]
+		}
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			_ = tc.PrintfLine("250-localhost")
+			if s.username != "" {
+				_ = tc.PrintfLine("250-AUTH PLAIN")
+			}
+			_ = tc.PrintfLine("250 8BITMIME")
+		case "HELO", "NOOP":
+			_ = tc.PrintfLine("250 OK")
+		case "AUTH":
+			if s.checkPlainAuth(arg) {
+				authenticated = true
+				_ = tc.PrintfLine("235 Authentication successful")
+			} else {
+				_ = tc.PrintfLine("535 Authentication failed")
+			}
+		case "MAIL":
+			if !authenticated {
+				_ = tc.PrintfLine("530 Authentication required")
+				continue
+			}
+			msg = Message{From: address(arg)}
+			_ = tc.PrintfLine("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(arg))
+			_ = tc.PrintfLine("250 OK")
+		case "DATA":
+			_ = tc.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tc.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			msg = Message{}
+			_ = tc.PrintfLine("250 OK")
+		case "RSET":
+			msg = Message{}
+			_ = tc.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tc.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// checkPlainAuth checks the initial response of AUTH PLAIN, i.e. "PLAIN base64(\x00username\x00password)".
+func (s *Server) checkPlainAuth(arg string) bool {
+	fields := strings.Fields(arg)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "PLAIN") {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(string(decoded), "\x00")
+	return len(parts) == 3 && parts[1] == s.username && parts[2] == s.password
+}
+
+// address extracts the address of "FROM:<address> ..." or "TO:<address> ...".
+func address(arg string) string {
+	start := strings.IndexByte(arg, '<')
+	end := strings.IndexByte(arg, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
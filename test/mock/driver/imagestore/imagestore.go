@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./driver/imagestore/imagestore.go
+
+// Package mock_imagestore is a generated GoMock package.
+package mock_imagestore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	imagestore "github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+)
+
+// MockImageStore is a mock of ImageStore interface.
+type MockImageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageStoreMockRecorder
+}
+
+// MockImageStoreMockRecorder is the mock recorder for MockImageStore.
+type MockImageStoreMockRecorder struct {
+	mock *MockImageStore
+}
+
+// NewMockImageStore creates a new mock instance.
+func NewMockImageStore(ctrl *gomock.Controller) *MockImageStore {
+	mock := &MockImageStore{ctrl: ctrl}
+	mock.recorder = &MockImageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageStore) EXPECT() *MockImageStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockImageStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImageStoreMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImageStore)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockImageStore) Get(ctx context.Context, key string) (*imagestore.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*imagestore.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImageStoreMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImageStore)(nil).Get), ctx, key)
+}
+
+// Open mocks base method.
+func (m *MockImageStore) Open(ctx context.Context, key string) (*imagestore.ImageReader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(*imagestore.ImageReader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockImageStoreMockRecorder) Open(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockImageStore)(nil).Open), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockImageStore) Put(ctx context.Context, key string, image *imagestore.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockImageStoreMockRecorder) Put(ctx, key, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockImageStore)(nil).Put), ctx, key, image)
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./modules/sighting/v1/service/image.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	imagestore "github.com/ibrahimker/tigerhall-kittens/driver/imagestore"
+	entity "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// MockSightingImage is a mock of SightingImage interface.
+type MockSightingImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSightingImageMockRecorder
+}
+
+// MockSightingImageMockRecorder is the mock recorder for MockSightingImage.
+type MockSightingImageMockRecorder struct {
+	mock *MockSightingImage
+}
+
+// NewMockSightingImage creates a new mock instance.
+func NewMockSightingImage(ctrl *gomock.Controller) *MockSightingImage {
+	mock := &MockSightingImage{ctrl: ctrl}
+	mock.recorder = &MockSightingImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSightingImage) EXPECT() *MockSightingImageMockRecorder {
+	return m.recorder
+}
+
+// OpenSightingImage mocks base method.
+func (m *MockSightingImage) OpenSightingImage(ctx context.Context, tigerID, sightingID int32, variant string) (*imagestore.ImageReader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenSightingImage", ctx, tigerID, sightingID, variant)
+	ret0, _ := ret[0].(*imagestore.ImageReader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenSightingImage indicates an expected call of OpenSightingImage.
+func (mr *MockSightingImageMockRecorder) OpenSightingImage(ctx, tigerID, sightingID, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenSightingImage", reflect.TypeOf((*MockSightingImage)(nil).OpenSightingImage), ctx, tigerID, sightingID, variant)
+}
+
+// MockSightingImageRepository is a mock of SightingImageRepository interface.
+type MockSightingImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSightingImageRepositoryMockRecorder
+}
+
+// MockSightingImageRepositoryMockRecorder is the mock recorder for MockSightingImageRepository.
+type MockSightingImageRepositoryMockRecorder struct {
+	mock *MockSightingImageRepository
+}
+
+// NewMockSightingImageRepository creates a new mock instance.
+func NewMockSightingImageRepository(ctrl *gomock.Controller) *MockSightingImageRepository {
+	mock := &MockSightingImageRepository{ctrl: ctrl}
+	mock.recorder = &MockSightingImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSightingImageRepository) EXPECT() *MockSightingImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSightingByID mocks base method.
+func (m *MockSightingImageRepository) GetSightingByID(ctx context.Context, tigerID, sightingID int32) (*entity.Sighting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSightingByID", ctx, tigerID, sightingID)
+	ret0, _ := ret[0].(*entity.Sighting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSightingByID indicates an expected call of GetSightingByID.
+func (mr *MockSightingImageRepositoryMockRecorder) GetSightingByID(ctx, tigerID, sightingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingByID", reflect.TypeOf((*MockSightingImageRepository)(nil).GetSightingByID), ctx, tigerID, sightingID)
+}
+
+// MockImageMigration is a mock of ImageMigration interface.
+type MockImageMigration struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMigrationMockRecorder
+}
+
+// MockImageMigrationMockRecorder is the mock recorder for MockImageMigration.
+type MockImageMigrationMockRecorder struct {
+	mock *MockImageMigration
+}
+
+// NewMockImageMigration creates a new mock instance.
+func NewMockImageMigration(ctrl *gomock.Controller) *MockImageMigration {
+	mock := &MockImageMigration{ctrl: ctrl}
+	mock.recorder = &MockImageMigrationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageMigration) EXPECT() *MockImageMigrationMockRecorder {
+	return m.recorder
+}
+
+// MoveImagesToStore mocks base method.
+func (m *MockImageMigration) MoveImagesToStore(ctx context.Context, batchSize int32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveImagesToStore", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveImagesToStore indicates an expected call of MoveImagesToStore.
+func (mr *MockImageMigrationMockRecorder) MoveImagesToStore(ctx, batchSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveImagesToStore", reflect.TypeOf((*MockImageMigration)(nil).MoveImagesToStore), ctx, batchSize)
+}
+
+// MockImageMigrationRepository is a mock of ImageMigrationRepository interface.
+type MockImageMigrationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMigrationRepositoryMockRecorder
+}
+
+// MockImageMigrationRepositoryMockRecorder is the mock recorder for MockImageMigrationRepository.
+type MockImageMigrationRepositoryMockRecorder struct {
+	mock *MockImageMigrationRepository
+}
+
+// NewMockImageMigrationRepository creates a new mock instance.
+func NewMockImageMigrationRepository(ctrl *gomock.Controller) *MockImageMigrationRepository {
+	mock := &MockImageMigrationRepository{ctrl: ctrl}
+	mock.recorder = &MockImageMigrationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageMigrationRepository) EXPECT() *MockImageMigrationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSightingsWithImageData mocks base method.
+func (m *MockImageMigrationRepository) GetSightingsWithImageData(ctx context.Context, afterID, limit int32) ([]*entity.Sighting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSightingsWithImageData", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Sighting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSightingsWithImageData indicates an expected call of GetSightingsWithImageData.
+func (mr *MockImageMigrationRepositoryMockRecorder) GetSightingsWithImageData(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingsWithImageData", reflect.TypeOf((*MockImageMigrationRepository)(nil).GetSightingsWithImageData), ctx, afterID, limit)
+}
+
+// UpdateSightingImageKey mocks base method.
+func (m *MockImageMigrationRepository) UpdateSightingImageKey(ctx context.Context, sightingID int32, imageKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSightingImageKey", ctx, sightingID, imageKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSightingImageKey indicates an expected call of UpdateSightingImageKey.
+func (mr *MockImageMigrationRepositoryMockRecorder) UpdateSightingImageKey(ctx, sightingID, imageKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSightingImageKey", reflect.TypeOf((*MockImageMigrationRepository)(nil).UpdateSightingImageKey), ctx, sightingID, imageKey)
+}
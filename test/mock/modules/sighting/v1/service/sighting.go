@@ -7,6 +7,7 @@ package mock_service
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
@@ -64,34 +65,108 @@ func (mr *MockTigerSightingMockRecorder) CreateTiger(ctx, tiger interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTiger", reflect.TypeOf((*MockTigerSighting)(nil).CreateTiger), ctx, tiger)
 }
 
+// DeleteTiger mocks base method.
+func (m *MockTigerSighting) DeleteTiger(ctx context.Context, tigerID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTiger", ctx, tigerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTiger indicates an expected call of DeleteTiger.
+func (mr *MockTigerSightingMockRecorder) DeleteTiger(ctx, tigerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTiger", reflect.TypeOf((*MockTigerSighting)(nil).DeleteTiger), ctx, tigerID)
+}
+
+// FindSimilarImages mocks base method.
+func (m *MockTigerSighting) FindSimilarImages(ctx context.Context, query *entity.SimilarImageQuery) ([]*entity.SimilarSighting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSimilarImages", ctx, query)
+	ret0, _ := ret[0].([]*entity.SimilarSighting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSimilarImages indicates an expected call of FindSimilarImages.
+func (mr *MockTigerSightingMockRecorder) FindSimilarImages(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSimilarImages", reflect.TypeOf((*MockTigerSighting)(nil).FindSimilarImages), ctx, query)
+}
+
 // GetSightingsByTigerID mocks base method.
-func (m *MockTigerSighting) GetSightingsByTigerID(ctx context.Context, tigerID int32) ([]*entity.Sighting, error) {
+func (m *MockTigerSighting) GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, pageSize int32, pageToken string) (*entity.SightingPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSightingsByTigerID", ctx, tigerID)
-	ret0, _ := ret[0].([]*entity.Sighting)
+	ret := m.ctrl.Call(m, "GetSightingsByTigerID", ctx, tigerID, filter, pageSize, pageToken)
+	ret0, _ := ret[0].(*entity.SightingPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSightingsByTigerID indicates an expected call of GetSightingsByTigerID.
-func (mr *MockTigerSightingMockRecorder) GetSightingsByTigerID(ctx, tigerID interface{}) *gomock.Call {
+func (mr *MockTigerSightingMockRecorder) GetSightingsByTigerID(ctx, tigerID, filter, pageSize, pageToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingsByTigerID", reflect.TypeOf((*MockTigerSighting)(nil).GetSightingsByTigerID), ctx, tigerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingsByTigerID", reflect.TypeOf((*MockTigerSighting)(nil).GetSightingsByTigerID), ctx, tigerID, filter, pageSize, pageToken)
+}
+
+// GetTiger mocks base method.
+func (m *MockTigerSighting) GetTiger(ctx context.Context, tigerID int32) (*entity.Tiger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTiger", ctx, tigerID)
+	ret0, _ := ret[0].(*entity.Tiger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTiger indicates an expected call of GetTiger.
+func (mr *MockTigerSightingMockRecorder) GetTiger(ctx, tigerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTiger", reflect.TypeOf((*MockTigerSighting)(nil).GetTiger), ctx, tigerID)
 }
 
 // GetTigers mocks base method.
-func (m *MockTigerSighting) GetTigers(ctx context.Context) ([]*entity.Tiger, error) {
+func (m *MockTigerSighting) GetTigers(ctx context.Context, filter *entity.TigerFilter, pageSize int32, pageToken string) (*entity.TigerPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTigers", ctx)
-	ret0, _ := ret[0].([]*entity.Tiger)
+	ret := m.ctrl.Call(m, "GetTigers", ctx, filter, pageSize, pageToken)
+	ret0, _ := ret[0].(*entity.TigerPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTigers indicates an expected call of GetTigers.
-func (mr *MockTigerSightingMockRecorder) GetTigers(ctx interface{}) *gomock.Call {
+func (mr *MockTigerSightingMockRecorder) GetTigers(ctx, filter, pageSize, pageToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigers", reflect.TypeOf((*MockTigerSighting)(nil).GetTigers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigers", reflect.TypeOf((*MockTigerSighting)(nil).GetTigers), ctx, filter, pageSize, pageToken)
+}
+
+// SearchTigersNearby mocks base method.
+func (m *MockTigerSighting) SearchTigersNearby(ctx context.Context, query *entity.NearbyQuery) ([]*entity.NearbyTiger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTigersNearby", ctx, query)
+	ret0, _ := ret[0].([]*entity.NearbyTiger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTigersNearby indicates an expected call of SearchTigersNearby.
+func (mr *MockTigerSightingMockRecorder) SearchTigersNearby(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTigersNearby", reflect.TypeOf((*MockTigerSighting)(nil).SearchTigersNearby), ctx, query)
+}
+
+// UpdateTiger mocks base method.
+func (m *MockTigerSighting) UpdateTiger(ctx context.Context, tiger *entity.Tiger, paths []string) (*entity.Tiger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTiger", ctx, tiger, paths)
+	ret0, _ := ret[0].(*entity.Tiger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTiger indicates an expected call of UpdateTiger.
+func (mr *MockTigerSightingMockRecorder) UpdateTiger(ctx, tiger, paths interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTiger", reflect.TypeOf((*MockTigerSighting)(nil).UpdateTiger), ctx, tiger, paths)
 }
 
 // MockTigerSightingRepository is a mock of TigerSightingRepository interface.
@@ -117,6 +192,20 @@ func (m *MockTigerSightingRepository) EXPECT() *MockTigerSightingRepositoryMockR
 	return m.recorder
 }
 
+// CreateOutboxEvent mocks base method.
+func (m *MockTigerSightingRepository) CreateOutboxEvent(ctx context.Context, event *entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockTigerSightingRepositoryMockRecorder) CreateOutboxEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockTigerSightingRepository)(nil).CreateOutboxEvent), ctx, event)
+}
+
 // CreateSighting mocks base method.
 func (m *MockTigerSightingRepository) CreateSighting(ctx context.Context, sighting *entity.Sighting) error {
 	m.ctrl.T.Helper()
@@ -145,19 +234,94 @@ func (mr *MockTigerSightingRepositoryMockRecorder) CreateTiger(ctx, tiger interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTiger", reflect.TypeOf((*MockTigerSightingRepository)(nil).CreateTiger), ctx, tiger)
 }
 
+// DeleteTiger mocks base method.
+func (m *MockTigerSightingRepository) DeleteTiger(ctx context.Context, tigerID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTiger", ctx, tigerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTiger indicates an expected call of DeleteTiger.
+func (mr *MockTigerSightingRepositoryMockRecorder) DeleteTiger(ctx, tigerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTiger", reflect.TypeOf((*MockTigerSightingRepository)(nil).DeleteTiger), ctx, tigerID)
+}
+
+// GetAdjacentSightings mocks base method.
+func (m *MockTigerSightingRepository) GetAdjacentSightings(ctx context.Context, tigerID int32, seenAt time.Time, latitude, longitude float64) (*entity.Waypoint, *entity.Waypoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjacentSightings", ctx, tigerID, seenAt, latitude, longitude)
+	ret0, _ := ret[0].(*entity.Waypoint)
+	ret1, _ := ret[1].(*entity.Waypoint)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAdjacentSightings indicates an expected call of GetAdjacentSightings.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetAdjacentSightings(ctx, tigerID, seenAt, latitude, longitude interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjacentSightings", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetAdjacentSightings), ctx, tigerID, seenAt, latitude, longitude)
+}
+
+// GetDistanceFromLastSeen mocks base method.
+func (m *MockTigerSightingRepository) GetDistanceFromLastSeen(ctx context.Context, tigerID int32, latitude, longitude float64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDistanceFromLastSeen", ctx, tigerID, latitude, longitude)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDistanceFromLastSeen indicates an expected call of GetDistanceFromLastSeen.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetDistanceFromLastSeen(ctx, tigerID, latitude, longitude interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistanceFromLastSeen", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetDistanceFromLastSeen), ctx, tigerID, latitude, longitude)
+}
+
+// GetPriorReporters mocks base method.
+func (m *MockTigerSightingRepository) GetPriorReporters(ctx context.Context, tigerID int32, exclude string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriorReporters", ctx, tigerID, exclude)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriorReporters indicates an expected call of GetPriorReporters.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetPriorReporters(ctx, tigerID, exclude interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriorReporters", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetPriorReporters), ctx, tigerID, exclude)
+}
+
 // GetSightingsByTigerID mocks base method.
-func (m *MockTigerSightingRepository) GetSightingsByTigerID(ctx context.Context, tigerID int32) ([]*entity.Sighting, error) {
+func (m *MockTigerSightingRepository) GetSightingsByTigerID(ctx context.Context, tigerID int32, filter *entity.SightingFilter, cursor *entity.SightingCursor, limit int32) ([]*entity.Sighting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSightingsByTigerID", ctx, tigerID)
+	ret := m.ctrl.Call(m, "GetSightingsByTigerID", ctx, tigerID, filter, cursor, limit)
 	ret0, _ := ret[0].([]*entity.Sighting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSightingsByTigerID indicates an expected call of GetSightingsByTigerID.
-func (mr *MockTigerSightingRepositoryMockRecorder) GetSightingsByTigerID(ctx, tigerID interface{}) *gomock.Call {
+func (mr *MockTigerSightingRepositoryMockRecorder) GetSightingsByTigerID(ctx, tigerID, filter, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingsByTigerID", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetSightingsByTigerID), ctx, tigerID, filter, cursor, limit)
+}
+
+// GetSimilarSightings mocks base method.
+func (m *MockTigerSightingRepository) GetSimilarSightings(ctx context.Context, hash uint64, maxDistance, limit int32) ([]*entity.SimilarSighting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilarSightings", ctx, hash, maxDistance, limit)
+	ret0, _ := ret[0].([]*entity.SimilarSighting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilarSightings indicates an expected call of GetSimilarSightings.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetSimilarSightings(ctx, hash, maxDistance, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSightingsByTigerID", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetSightingsByTigerID), ctx, tigerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilarSightings", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetSimilarSightings), ctx, hash, maxDistance, limit)
 }
 
 // GetTigerByID mocks base method.
@@ -175,19 +339,49 @@ func (mr *MockTigerSightingRepositoryMockRecorder) GetTigerByID(ctx, tigerID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigerByID", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetTigerByID), ctx, tigerID)
 }
 
+// GetTigerByIDForUpdate mocks base method.
+func (m *MockTigerSightingRepository) GetTigerByIDForUpdate(ctx context.Context, tigerID int32) (*entity.Tiger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTigerByIDForUpdate", ctx, tigerID)
+	ret0, _ := ret[0].(*entity.Tiger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTigerByIDForUpdate indicates an expected call of GetTigerByIDForUpdate.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetTigerByIDForUpdate(ctx, tigerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigerByIDForUpdate", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetTigerByIDForUpdate), ctx, tigerID)
+}
+
 // GetTigers mocks base method.
-func (m *MockTigerSightingRepository) GetTigers(ctx context.Context) ([]*entity.Tiger, error) {
+func (m *MockTigerSightingRepository) GetTigers(ctx context.Context, filter *entity.TigerFilter, cursor *entity.TigerCursor, limit int32) ([]*entity.Tiger, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTigers", ctx)
+	ret := m.ctrl.Call(m, "GetTigers", ctx, filter, cursor, limit)
 	ret0, _ := ret[0].([]*entity.Tiger)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTigers indicates an expected call of GetTigers.
-func (mr *MockTigerSightingRepositoryMockRecorder) GetTigers(ctx interface{}) *gomock.Call {
+func (mr *MockTigerSightingRepositoryMockRecorder) GetTigers(ctx, filter, cursor, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigers", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetTigers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigers", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetTigers), ctx, filter, cursor, limit)
+}
+
+// GetTigersNearby mocks base method.
+func (m *MockTigerSightingRepository) GetTigersNearby(ctx context.Context, latitude, longitude, radius float64, since time.Time, limit int32) ([]*entity.NearbyTiger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTigersNearby", ctx, latitude, longitude, radius, since, limit)
+	ret0, _ := ret[0].([]*entity.NearbyTiger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTigersNearby indicates an expected call of GetTigersNearby.
+func (mr *MockTigerSightingRepositoryMockRecorder) GetTigersNearby(ctx, latitude, longitude, radius, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTigersNearby", reflect.TypeOf((*MockTigerSightingRepository)(nil).GetTigersNearby), ctx, latitude, longitude, radius, since, limit)
 }
 
 // UpdateTiger mocks base method.
@@ -203,3 +397,17 @@ func (mr *MockTigerSightingRepositoryMockRecorder) UpdateTiger(ctx, tiger interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTiger", reflect.TypeOf((*MockTigerSightingRepository)(nil).UpdateTiger), ctx, tiger)
 }
+
+// WithTransaction mocks base method.
+func (m *MockTigerSightingRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockTigerSightingRepositoryMockRecorder) WithTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockTigerSightingRepository)(nil).WithTransaction), ctx, fn)
+}
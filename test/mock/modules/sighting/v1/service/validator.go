@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./modules/sighting/v1/service/validator.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// MockSightingValidator is a mock of SightingValidator interface.
+type MockSightingValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockSightingValidatorMockRecorder
+}
+
+// MockSightingValidatorMockRecorder is the mock recorder for MockSightingValidator.
+type MockSightingValidatorMockRecorder struct {
+	mock *MockSightingValidator
+}
+
+// NewMockSightingValidator creates a new mock instance.
+func NewMockSightingValidator(ctrl *gomock.Controller) *MockSightingValidator {
+	mock := &MockSightingValidator{ctrl: ctrl}
+	mock.recorder = &MockSightingValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSightingValidator) EXPECT() *MockSightingValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockSightingValidator) Validate(ctx context.Context, tiger *entity.Tiger, sighting *entity.Sighting, movement *entity.Movement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, tiger, sighting, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockSightingValidatorMockRecorder) Validate(ctx, tiger, sighting, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockSightingValidator)(nil).Validate), ctx, tiger, sighting, movement)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./modules/sighting/v1/service/watch.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// MockSightingWatch is a mock of SightingWatch interface.
+type MockSightingWatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockSightingWatchMockRecorder
+}
+
+// MockSightingWatchMockRecorder is the mock recorder for MockSightingWatch.
+type MockSightingWatchMockRecorder struct {
+	mock *MockSightingWatch
+}
+
+// NewMockSightingWatch creates a new mock instance.
+func NewMockSightingWatch(ctrl *gomock.Controller) *MockSightingWatch {
+	mock := &MockSightingWatch{ctrl: ctrl}
+	mock.recorder = &MockSightingWatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSightingWatch) EXPECT() *MockSightingWatchMockRecorder {
+	return m.recorder
+}
+
+// WatchSightings mocks base method.
+func (m *MockSightingWatch) WatchSightings(ctx context.Context, filter *entity.SightingWatchFilter) (<-chan *entity.WatchedSighting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSightings", ctx, filter)
+	ret0, _ := ret[0].(<-chan *entity.WatchedSighting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchSightings indicates an expected call of WatchSightings.
+func (mr *MockSightingWatchMockRecorder) WatchSightings(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSightings", reflect.TypeOf((*MockSightingWatch)(nil).WatchSightings), ctx, filter)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./modules/sighting/v1/webhook/webhook.go
+
+// Package mock_webhook is a generated GoMock package.
+package mock_webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	entity "github.com/ibrahimker/tigerhall-kittens/modules/sighting/v1/entity"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookDeliveryAttempt mocks base method.
+func (m *MockRepository) CreateWebhookDeliveryAttempt(ctx context.Context, attempt *entity.WebhookDeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveryAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDeliveryAttempt indicates an expected call of CreateWebhookDeliveryAttempt.
+func (mr *MockRepositoryMockRecorder) CreateWebhookDeliveryAttempt(ctx, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveryAttempt", reflect.TypeOf((*MockRepository)(nil).CreateWebhookDeliveryAttempt), ctx, attempt)
+}
+
+// GetWebhookSubscriptionByID mocks base method.
+func (m *MockRepository) GetWebhookSubscriptionByID(ctx context.Context, subscriptionID int32) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionByID", ctx, subscriptionID)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptionByID indicates an expected call of GetWebhookSubscriptionByID.
+func (mr *MockRepositoryMockRecorder) GetWebhookSubscriptionByID(ctx, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionByID", reflect.TypeOf((*MockRepository)(nil).GetWebhookSubscriptionByID), ctx, subscriptionID)
+}
+
+// GetWebhookSubscriptionsForSighting mocks base method.
+func (m *MockRepository) GetWebhookSubscriptionsForSighting(ctx context.Context, tigerID int32, latitude, longitude float64) ([]*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionsForSighting", ctx, tigerID, latitude, longitude)
+	ret0, _ := ret[0].([]*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptionsForSighting indicates an expected call of GetWebhookSubscriptionsForSighting.
+func (mr *MockRepositoryMockRecorder) GetWebhookSubscriptionsForSighting(ctx, tigerID, latitude, longitude interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionsForSighting", reflect.TypeOf((*MockRepository)(nil).GetWebhookSubscriptionsForSighting), ctx, tigerID, latitude, longitude)
+}
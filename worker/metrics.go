@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSucceeded = "succeeded"
+	resultRetried   = "retried"
+	resultDead      = "dead"
+)
+
+var (
+	jobsEnqueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "jobs_enqueued_total",
+		Help:      "Total number of jobs enqueued, by job type.",
+	}, []string{"job_type"})
+	jobsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "jobs_processed_total",
+		Help:      "Total number of job runs, by job type and result: succeeded, retried or dead.",
+	}, []string{"job_type", "result"})
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "worker",
+		Name:      "job_duration_seconds",
+		Help:      "Duration of job runs, by job type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job_type"})
+	jobsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "worker",
+		Name:      "jobs_in_flight",
+		Help:      "Number of jobs running, by job type.",
+	}, []string{"job_type"})
+)
+
+func init() {
+	prometheus.MustRegister(jobsEnqueued, jobsProcessed, jobDuration, jobsInFlight)
+}
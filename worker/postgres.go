@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	jobStatusPending = "pending"
+	jobStatusDone    = "done"
+	jobStatusDead    = "dead"
+)
+
+// PgxPoolIface defines a little interface for pgxpool functionality.
+// Since in the real implementation we can use pgxpool.Pool,
+// this interface exists mostly for testing purpose.
+type PgxPoolIface interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// PostgresQueue is a Queue storing jobs in the worker.job table.
+// A dequeued job is claimed with FOR UPDATE SKIP LOCKED and hidden from other workers until its lease expires,
+// so a job taken by a worker which crashed runs again once the lease expires.
+type PostgresQueue struct {
+	pool         PgxPoolIface
+	pollInterval time.Duration
+	lease        time.Duration
+}
+
+// NewPostgresQueue creates an instance of PostgresQueue.
+func NewPostgresQueue(pool PgxPoolIface, pollInterval, lease time.Duration) *PostgresQueue {
+	return &PostgresQueue{pool: pool, pollInterval: pollInterval, lease: lease}
+}
+
+// Enqueue inserts a pending job due now.
+func (q *PostgresQueue) Enqueue(ctx context.Context, jobType string, payload []byte) error {
+	queryString := `INSERT INTO worker.job (job_type,payload,status,run_at,created_at) VALUES ($1, $2, $3, $4, $4)`
+	if _, err := q.pool.Exec(ctx, queryString, jobType, payload, jobStatusPending, time.Now()); err != nil {
+		return err
+	}
+	jobsEnqueued.WithLabelValues(jobType).Inc()
+	return nil
+}
+
+// Dequeue claims the pending job of the given type due first and postpones its run time by the lease.
+// If there is none, it waits for the poll interval and returns nil.
+func (q *PostgresQueue) Dequeue(ctx context.Context, jobType string) (*Job, error) {
+	now := time.Now()
+	queryString := `UPDATE worker.job SET attempts = attempts + 1, run_at = $2
+WHERE id = (SELECT id FROM worker.job WHERE job_type = $1 AND status = $3 AND run_at <= $4 ORDER BY run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED)
+RETURNING id, job_type, payload, attempts, created_at`
+
+	var (
+		id  int64
+		job Job
+	)
+	err := q.pool.QueryRow(ctx, queryString, jobType, now.Add(q.lease), jobStatusPending, now).
+		Scan(&id, &job.Type, &job.Payload, &job.Attempts, &job.EnqueuedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(q.pollInterval):
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	job.ID = strconv.FormatInt(id, 10)
+	return &job, nil
+}
+
+// Complete marks the job as done so that it is never claimed again.
+func (q *PostgresQueue) Complete(ctx context.Context, job *Job) error {
+	queryString := `UPDATE worker.job SET status = $2, finished_at = $3 WHERE id = $1`
+	return q.exec(ctx, job, queryString, jobStatusDone, time.Now())
+}
+
+// Retry records the error of the job and makes it due at runAt.
+func (q *PostgresQueue) Retry(ctx context.Context, job *Job, lastError string, runAt time.Time) error {
+	queryString := `UPDATE worker.job SET last_error = $2, run_at = $3 WHERE id = $1`
+	return q.exec(ctx, job, queryString, lastError, runAt)
+}
+
+// Fail records the error of the job and marks it as dead so that it is never claimed again.
+func (q *PostgresQueue) Fail(ctx context.Context, job *Job, lastError string) error {
+	queryString := `UPDATE worker.job SET status = $2, last_error = $3, finished_at = $4 WHERE id = $1`
+	return q.exec(ctx, job, queryString, jobStatusDead, lastError, time.Now())
+}
+
+// exec runs the statement with the ID of the job as first argument.
+func (q *PostgresQueue) exec(ctx context.Context, job *Job, queryString string, args ...interface{}) error {
+	id, err := strconv.ParseInt(job.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = q.pool.Exec(ctx, queryString, append([]interface{}{id}, args...)...)
+	return err
+}
@@ -0,0 +1,155 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+func TestPostgresQueue(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	insertJob := `INSERT INTO worker.job \(job_type,payload,status,run_at,created_at\) VALUES \(\$1, \$2, \$3, \$4, \$4\)`
+	claimJob := `UPDATE worker.job SET attempts = attempts \+ 1, run_at = \$2
+WHERE id = \(SELECT id FROM worker.job WHERE job_type = \$1 AND status = \$3 AND run_at <= \$4 ORDER BY run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED\)
+RETURNING id, job_type, payload, attempts, created_at`
+	completeJob := `UPDATE worker.job SET status = \$2, finished_at = \$3 WHERE id = \$1`
+	retryJob := `UPDATE worker.job SET last_error = \$2, run_at = \$3 WHERE id = \$1`
+	failJob := `UPDATE worker.job SET status = \$2, last_error = \$3, finished_at = \$4 WHERE id = \$1`
+	createdAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	job := &worker.Job{ID: "7", Type: "test", Payload: []byte(`{"a":1}`), Attempts: 2, EnqueuedAt: createdAt}
+
+	newQueue := func(t *testing.T) (*worker.PostgresQueue, pgxmock.PgxPoolIface) {
+		pool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		return worker.NewPostgresQueue(pool, 5*time.Millisecond, time.Minute), pool
+	}
+
+	testCases := []WorkerTestCase{
+		{
+			testcaseName: "successfully enqueue job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectExec(insertJob).
+					WithArgs("test", []byte(`{"a":1}`), "pending", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				require.NoError(t, queue.Enqueue(mockCtx, "test", []byte(`{"a":1}`)))
+				require.NoError(t, pool.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error when insert job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectExec(insertJob).WillReturnError(errors.New("db error"))
+
+				require.Error(t, queue.Enqueue(mockCtx, "test", nil))
+			},
+		},
+		{
+			testcaseName: "successfully claim the job due first",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectQuery(claimJob).
+					WithArgs("test", within(time.Minute, time.Minute), "pending", within(0, 0)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "job_type", "payload", "attempts", "created_at"}).
+						AddRow(int64(7), "test", []byte(`{"a":1}`), 2, createdAt))
+
+				got, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Equal(t, job, got)
+				require.NoError(t, pool.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully claim nothing after poll interval when no job is due",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectQuery(claimJob).WillReturnError(pgx.ErrNoRows)
+
+				start := time.Now()
+				got, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Nil(t, got)
+				require.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+			},
+		},
+		{
+			testcaseName: "Error when claim job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectQuery(claimJob).WillReturnError(errors.New("db error"))
+
+				got, err := queue.Dequeue(mockCtx, "test")
+				require.Error(t, err)
+				require.Nil(t, got)
+			},
+		},
+		{
+			testcaseName: "successfully complete job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectExec(completeJob).
+					WithArgs(int64(7), "done", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				require.NoError(t, queue.Complete(mockCtx, job))
+				require.NoError(t, pool.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully schedule retry",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				runAt := createdAt.Add(time.Minute)
+				pool.ExpectExec(retryJob).
+					WithArgs(int64(7), "smtp error", runAt).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				require.NoError(t, queue.Retry(mockCtx, job, "smtp error", runAt))
+				require.NoError(t, pool.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully move job to dead letter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, pool := newQueue(t)
+				pool.ExpectExec(failJob).
+					WithArgs(int64(7), "dead", "smtp error", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+				require.NoError(t, queue.Fail(mockCtx, job, "smtp error"))
+				require.NoError(t, pool.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error invalid job ID",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				queue, _ := newQueue(t)
+
+				require.Error(t, queue.Complete(mockCtx, &worker.Job{ID: "job-1", Type: "test"}))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const (
+	readyKey      = "worker:%s:ready"
+	processingKey = "worker:%s:processing"
+	leasesKey     = "worker:%s:leases"
+	delayedKey    = "worker:%s:delayed"
+	deadKey       = "worker:%s:dead"
+
+	// promoteBatchSize is the maximum number of due retries moved back to the ready list by one dequeue.
+	promoteBatchSize = 100
+)
+
+// promoteScript moves the retries due at ARGV[1] from the delayed set KEYS[1] to the ready list KEYS[2],
+// and the jobs of the processing list KEYS[3] whose lease in the hash KEYS[4] expired at ARGV[1] back to the ready list.
+// A job without lease, taken by a worker which stopped before leasing it, is leased for ARGV[3] milliseconds from now.
+// An expired job is requeued with a new claim prefixed by ARGV[4], so that its former worker can't acknowledge the copy.
+const promoteScript = `local now = tonumber(ARGV[1])
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now, 'LIMIT', 0, ARGV[2])
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('RPUSH', KEYS[2], job)
+end
+local expired = 0
+for _, job in ipairs(redis.call('LRANGE', KEYS[3], 0, -1)) do
+	local leaseUntil = redis.call('HGET', KEYS[4], job)
+	if not leaseUntil then
+		redis.call('HSET', KEYS[4], job, now + tonumber(ARGV[3]))
+	elseif tonumber(leaseUntil) <= now then
+		redis.call('LREM', KEYS[3], 1, job)
+		redis.call('HDEL', KEYS[4], job)
+		expired = expired + 1
+		local claimed = cjson.decode(job)
+		claimed['claim'] = ARGV[4] .. ':' .. expired
+		redis.call('RPUSH', KEYS[2], cjson.encode(claimed))
+	end
+end
+return #jobs + expired`
+
+// ackScript removes the job ARGV[1] from the processing list KEYS[1] and its lease from the hash KEYS[2].
+// When ARGV[2] is retry, the job ARGV[4] is then added to the delayed set KEYS[3] with the score ARGV[3],
+// and when it is fail, the job ARGV[3] is pushed to the dead list KEYS[3].
+// It does nothing and returns 0 if the job is no longer in the processing list.
+const ackScript = `if redis.call('LREM', KEYS[1], 1, ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+if ARGV[2] == 'retry' then
+	redis.call('ZADD', KEYS[3], ARGV[3], ARGV[4])
+elseif ARGV[2] == 'fail' then
+	redis.call('LPUSH', KEYS[3], ARGV[3])
+end
+return 1`
+
+// ErrLeaseExpired is returned when acknowledging a job whose lease expired, since it was requeued to run again.
+var ErrLeaseExpired = errors.New("lease of job expired")
+
+// redisJob is the representation of a job stored in Redis.
+type redisJob struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Payload    []byte    `json:"payload"`
+	Attempts   int       `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	LastError  string    `json:"last_error,omitempty"`
+	// Claim distinguishes a job requeued after its lease expired from the one its former worker may still acknowledge.
+	Claim string `json:"claim,omitempty"`
+}
+
+// RedisQueue is a Queue storing jobs in Redis.
+// Jobs ready to run are kept in a list per job type. A dequeued job is atomically moved to a processing list
+// and leased until it is acknowledged, and jobs waiting for a retry are kept in a set sorted by their run time.
+// A job left in the processing list by a worker which crashed runs again once its lease expires,
+// and acknowledging it afterwards fails with ErrLeaseExpired.
+type RedisQueue struct {
+	rds          *goredis.Client
+	pollInterval time.Duration
+	lease        time.Duration
+}
+
+// NewRedisQueue creates an instance of RedisQueue.
+func NewRedisQueue(rds *goredis.Client, pollInterval, lease time.Duration) *RedisQueue {
+	return &RedisQueue{rds: rds, pollInterval: pollInterval, lease: lease}
+}
+
+// Enqueue pushes the job to the ready list of its type.
+func (q *RedisQueue) Enqueue(ctx context.Context, jobType string, payload []byte) error {
+	data, err := json.Marshal(&redisJob{ID: uuid.NewString(), Type: jobType, Payload: payload, EnqueuedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err = q.rds.LPush(ctx, fmt.Sprintf(readyKey, jobType), data).Err(); err != nil {
+		return err
+	}
+	jobsEnqueued.WithLabelValues(jobType).Inc()
+	return nil
+}
+
+// Dequeue moves the due retries and the expired processing jobs of the job type back to its ready list,
+// then moves the oldest ready job to the processing list, waiting up to the poll interval for one, and leases it.
+func (q *RedisQueue) Dequeue(ctx context.Context, jobType string) (*Job, error) {
+	keys := []string{fmt.Sprintf(delayedKey, jobType), fmt.Sprintf(readyKey, jobType), fmt.Sprintf(processingKey, jobType), fmt.Sprintf(leasesKey, jobType)}
+	if err := q.rds.Eval(ctx, promoteScript, keys, time.Now().UnixMilli(), promoteBatchSize, q.lease.Milliseconds(), uuid.NewString()).Err(); err != nil {
+		return nil, err
+	}
+
+	raw, err := q.rds.BRPopLPush(ctx, fmt.Sprintf(readyKey, jobType), fmt.Sprintf(processingKey, jobType), q.pollInterval).Result()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// if the lease is lost, the next dequeue leases the job from then
+	if err = q.rds.HSet(ctx, fmt.Sprintf(leasesKey, jobType), raw, time.Now().Add(q.lease).UnixMilli()).Err(); err != nil {
+		return nil, err
+	}
+
+	var stored redisJob
+	if err = json.Unmarshal([]byte(raw), &stored); err != nil {
+		// the entry is kept in the processing list for inspection
+		return nil, fmt.Errorf("invalid job in %s: %w", fmt.Sprintf(processingKey, jobType), err)
+	}
+	return &Job{
+		ID:         stored.ID,
+		Type:       stored.Type,
+		Payload:    stored.Payload,
+		Attempts:   stored.Attempts + 1,
+		EnqueuedAt: stored.EnqueuedAt,
+		raw:        raw,
+	}, nil
+}
+
+// Complete removes the job from the processing list.
+func (q *RedisQueue) Complete(ctx context.Context, job *Job) error {
+	return q.ack(ctx, job, nil, "complete")
+}
+
+// Retry moves the job from the processing list to the delayed set, scored by runAt.
+func (q *RedisQueue) Retry(ctx context.Context, job *Job, lastError string, runAt time.Time) error {
+	data, err := encodeRedisJob(job, lastError)
+	if err != nil {
+		return err
+	}
+	return q.ack(ctx, job, []string{fmt.Sprintf(delayedKey, job.Type)}, "retry", runAt.UnixMilli(), data)
+}
+
+// Fail moves the job from the processing list to the dead list of its type.
+func (q *RedisQueue) Fail(ctx context.Context, job *Job, lastError string) error {
+	data, err := encodeRedisJob(job, lastError)
+	if err != nil {
+		return err
+	}
+	return q.ack(ctx, job, []string{fmt.Sprintf(deadKey, job.Type)}, "fail", data)
+}
+
+// ack runs ackScript for the job with the additional keys and arguments.
+func (q *RedisQueue) ack(ctx context.Context, job *Job, keys []string, args ...interface{}) error {
+	keys = append([]string{fmt.Sprintf(processingKey, job.Type), fmt.Sprintf(leasesKey, job.Type)}, keys...)
+	acked, err := q.rds.Eval(ctx, ackScript, keys, append([]interface{}{job.raw}, args...)...).Int()
+	if err != nil {
+		return err
+	}
+	if acked == 0 {
+		return ErrLeaseExpired
+	}
+	return nil
+}
+
+func encodeRedisJob(job *Job, lastError string) ([]byte, error) {
+	return json.Marshal(&redisJob{
+		ID:         job.ID,
+		Type:       job.Type,
+		Payload:    job.Payload,
+		Attempts:   job.Attempts,
+		EnqueuedAt: job.EnqueuedAt,
+		LastError:  lastError,
+	})
+}
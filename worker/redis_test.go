@@ -0,0 +1,421 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+type storedJob struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Payload    []byte    `json:"payload"`
+	Attempts   int       `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	LastError  string    `json:"last_error"`
+}
+
+// matchStoredJob matches a command whose last argument is the given job, ignoring the enqueue time when it is zero.
+func matchStoredJob(want storedJob) redismock.CustomMatch {
+	return func(expected, actual []interface{}) error {
+		for i := 0; i < len(expected)-1; i++ {
+			if fmt.Sprint(expected[i]) != fmt.Sprint(actual[i]) {
+				return fmt.Errorf("argument %d is %v, expected %v", i, actual[i], expected[i])
+			}
+		}
+		data, ok := actual[len(actual)-1].([]byte)
+		if !ok {
+			return fmt.Errorf("job is %T, expected []byte", actual[len(actual)-1])
+		}
+		var got storedJob
+		if err := json.Unmarshal(data, &got); err != nil {
+			return err
+		}
+		if want.ID == "" && got.ID != "" {
+			got.ID = ""
+		}
+		if want.EnqueuedAt.IsZero() {
+			got.EnqueuedAt = time.Time{}
+		}
+		if !got.EnqueuedAt.Equal(want.EnqueuedAt) {
+			return fmt.Errorf("job enqueued at %s, expected %s", got.EnqueuedAt, want.EnqueuedAt)
+		}
+		got.EnqueuedAt = want.EnqueuedAt
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return fmt.Errorf("job is %+v, expected %+v", got, want)
+		}
+		return nil
+	}
+}
+
+// matchPromote matches the script moving due retries and expired processing jobs of the job type, run now.
+func matchPromote(jobType string) redismock.CustomMatch {
+	return func(_, actual []interface{}) error {
+		keys := []interface{}{
+			int64(4), "worker:" + jobType + ":delayed", "worker:" + jobType + ":ready",
+			"worker:" + jobType + ":processing", "worker:" + jobType + ":leases",
+		}
+		if fmt.Sprint(actual[2:7]) != fmt.Sprint(keys) {
+			return fmt.Errorf("keys are %v, expected %v", actual[2:7], keys)
+		}
+		if ms, ok := actual[7].(int64); !ok || time.Since(time.UnixMilli(ms)) > time.Minute {
+			return fmt.Errorf("score %v is not now", actual[7])
+		}
+		if fmt.Sprint(actual[9]) != fmt.Sprint(time.Minute.Milliseconds()) {
+			return fmt.Errorf("lease is %v, expected %d", actual[9], time.Minute.Milliseconds())
+		}
+		if claim, ok := actual[10].(string); !ok || claim == "" {
+			return fmt.Errorf("claim %v is empty", actual[10])
+		}
+		return nil
+	}
+}
+
+// matchAck matches the script acknowledging a job of the processing list, ignoring its source.
+// When a job is given, the last argument is matched with it by matchStoredJob.
+func matchAck(want *storedJob) redismock.CustomMatch {
+	return func(expected, actual []interface{}) error {
+		actual = append([]interface{}{actual[0], expected[1]}, actual[2:]...)
+		if want != nil {
+			return matchStoredJob(*want)(expected, actual)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			return fmt.Errorf("arguments are %v, expected %v", actual, expected)
+		}
+		return nil
+	}
+}
+
+// matchLease matches the command leasing the job of the processing list for a minute from now.
+func matchLease(jobType, raw string) redismock.CustomMatch {
+	return func(_, actual []interface{}) error {
+		if fmt.Sprint(actual[:3]) != fmt.Sprint([]interface{}{"hset", "worker:" + jobType + ":leases", raw}) {
+			return fmt.Errorf("arguments are %v", actual[:3])
+		}
+		ms, ok := actual[3].(int64)
+		if until := time.UnixMilli(ms); !ok || until.Before(time.Now()) || until.After(time.Now().Add(time.Minute)) {
+			return fmt.Errorf("lease %v is not a minute from now", actual[3])
+		}
+		return nil
+	}
+}
+
+// newRedisQueue creates a queue polling every second and leasing jobs for a minute.
+func newRedisQueue(rds *goredis.Client) *worker.RedisQueue {
+	return worker.NewRedisQueue(rds, time.Second, time.Minute)
+}
+
+func TestRedisQueue(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	enqueuedAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	stored := storedJob{ID: "job-1", Type: "test", Payload: []byte(`{"a":1}`), Attempts: 1, EnqueuedAt: enqueuedAt}
+	raw, _ := json.Marshal(&stored)
+
+	// dequeue returns the stored job, moved to the processing list and leased
+	dequeue := func(t *testing.T, rds *goredis.Client, mock redismock.ClientMock) *worker.Job {
+		mock.CustomMatch(matchPromote("test")).ExpectEval("", []string{"", "", "", ""}, 0, 0, 0, "").SetVal(int64(0))
+		mock.ExpectBRPopLPush("worker:test:ready", "worker:test:processing", time.Second).SetVal(string(raw))
+		mock.CustomMatch(matchLease("test", string(raw))).ExpectHSet("worker:test:leases", "", 0).SetVal(1)
+
+		job, err := newRedisQueue(rds).Dequeue(mockCtx, "test")
+		require.NoError(t, err)
+		return job
+	}
+
+	testCases := []WorkerTestCase{
+		{
+			testcaseName: "successfully enqueue job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				mock.CustomMatch(matchStoredJob(storedJob{Type: "test", Payload: []byte(`{"a":1}`)})).
+					ExpectLPush("worker:test:ready", "").SetVal(1)
+
+				err := newRedisQueue(rds).Enqueue(mockCtx, "test", []byte(`{"a":1}`))
+				require.NoError(t, err)
+				require.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error when push job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				mock.CustomMatch(matchStoredJob(storedJob{Type: "test"})).
+					ExpectLPush("worker:test:ready", "").SetErr(errors.New("redis error"))
+
+				err := newRedisQueue(rds).Enqueue(mockCtx, "test", nil)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "successfully dequeue job and increment its attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+
+				job := dequeue(t, rds, mock)
+				require.Equal(t, "job-1", job.ID)
+				require.Equal(t, "test", job.Type)
+				require.Equal(t, []byte(`{"a":1}`), job.Payload)
+				require.Equal(t, 2, job.Attempts)
+				require.True(t, enqueuedAt.Equal(job.EnqueuedAt))
+				require.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully dequeue nothing when no job is ready",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				mock.CustomMatch(matchPromote("test")).ExpectEval("", []string{"", "", "", ""}, 0, 0, 0, "").SetVal(int64(0))
+				mock.ExpectBRPopLPush("worker:test:ready", "worker:test:processing", time.Second).RedisNil()
+
+				job, err := newRedisQueue(rds).Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Nil(t, job)
+			},
+		},
+		{
+			testcaseName: "Error when promote due retries",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				mock.CustomMatch(matchPromote("test")).ExpectEval("", []string{"", "", "", ""}, 0, 0, 0, "").SetErr(errors.New("redis error"))
+
+				job, err := newRedisQueue(rds).Dequeue(mockCtx, "test")
+				require.Error(t, err)
+				require.Nil(t, job)
+			},
+		},
+		{
+			testcaseName: "Error invalid job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				mock.CustomMatch(matchPromote("test")).ExpectEval("", []string{"", "", "", ""}, 0, 0, 0, "").SetVal(int64(0))
+				mock.ExpectBRPopLPush("worker:test:ready", "worker:test:processing", time.Second).SetVal("not-json")
+				mock.CustomMatch(matchLease("test", "not-json")).ExpectHSet("worker:test:leases", "", 0).SetVal(1)
+
+				job, err := newRedisQueue(rds).Dequeue(mockCtx, "test")
+				require.Error(t, err)
+				require.Nil(t, job)
+			},
+		},
+		{
+			testcaseName: "successfully complete job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				job := dequeue(t, rds, mock)
+				mock.CustomMatch(matchAck(nil)).
+					ExpectEval("", []string{"worker:test:processing", "worker:test:leases"}, string(raw), "complete").SetVal(int64(1))
+
+				require.NoError(t, newRedisQueue(rds).Complete(mockCtx, job))
+				require.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "Error lease of completed job expired",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				job := dequeue(t, rds, mock)
+				mock.CustomMatch(matchAck(nil)).
+					ExpectEval("", []string{"worker:test:processing", "worker:test:leases"}, string(raw), "complete").SetVal(int64(0))
+
+				err := newRedisQueue(rds).Complete(mockCtx, job)
+				require.ErrorIs(t, err, worker.ErrLeaseExpired)
+			},
+		},
+		{
+			testcaseName: "Error when complete job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				job := dequeue(t, rds, mock)
+				mock.CustomMatch(matchAck(nil)).
+					ExpectEval("", []string{"worker:test:processing", "worker:test:leases"}, string(raw), "complete").SetErr(errors.New("redis error"))
+
+				err := newRedisQueue(rds).Complete(mockCtx, job)
+				require.Error(t, err)
+			},
+		},
+		{
+			testcaseName: "successfully schedule retry",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				job := dequeue(t, rds, mock)
+				runAt := time.Date(2022, 1, 1, 12, 0, 2, 0, time.UTC)
+				retried := stored
+				retried.Attempts = 2
+				retried.LastError = "smtp error"
+				mock.CustomMatch(matchAck(&retried)).
+					ExpectEval("", []string{"worker:test:processing", "worker:test:leases", "worker:test:delayed"}, string(raw), "retry", runAt.UnixMilli(), "").
+					SetVal(int64(1))
+
+				require.NoError(t, newRedisQueue(rds).Retry(mockCtx, job, "smtp error", runAt))
+				require.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			testcaseName: "successfully move job to dead letter",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				rds, mock := redismock.NewClientMock()
+				job := dequeue(t, rds, mock)
+				dead := stored
+				dead.Attempts = 2
+				dead.LastError = "smtp error"
+				mock.CustomMatch(matchAck(&dead)).
+					ExpectEval("", []string{"worker:test:processing", "worker:test:leases", "worker:test:dead"}, string(raw), "fail", "").SetVal(int64(1))
+
+				require.NoError(t, newRedisQueue(rds).Fail(mockCtx, job, "smtp error"))
+				require.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestRedisQueue_Lease(t *testing.T) {
+	t.Parallel()
+	mockCtx := context.Background()
+	lease := 50 * time.Millisecond
+
+	testCases := []WorkerTestCase{
+		{
+			testcaseName: "job of a stopped worker runs again once its lease expires",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server, err := miniredis.Run()
+				require.NoError(t, err)
+				t.Cleanup(server.Close)
+				rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+				require.NoError(t, worker.NewRedisQueue(rds, time.Millisecond, lease).Enqueue(mockCtx, "test", []byte(`{"a":1}`)))
+
+				// the worker stops without acknowledging the job
+				stopped, err := worker.NewRedisQueue(rds, time.Millisecond, lease).Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NotNil(t, stopped)
+
+				queue := worker.NewRedisQueue(rds, time.Millisecond, lease)
+				job, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Nil(t, job, "job is leased")
+
+				time.Sleep(2 * lease)
+				job, err = queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NotNil(t, job)
+				require.Equal(t, stopped.ID, job.ID)
+				require.Equal(t, []byte(`{"a":1}`), job.Payload)
+
+				require.NoError(t, queue.Complete(mockCtx, job))
+				require.False(t, server.Exists("worker:test:processing"))
+				require.False(t, server.Exists("worker:test:leases"))
+			},
+		},
+		{
+			testcaseName: "late acknowledgement of a job whose lease expired doesn't remove it",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server, err := miniredis.Run()
+				require.NoError(t, err)
+				t.Cleanup(server.Close)
+				rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+				queue := worker.NewRedisQueue(rds, time.Millisecond, lease)
+				require.NoError(t, queue.Enqueue(mockCtx, "test", []byte(`{"a":1}`)))
+
+				late, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NotNil(t, late)
+
+				time.Sleep(2 * lease)
+				job, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NotNil(t, job)
+				require.Equal(t, late.ID, job.ID)
+
+				require.ErrorIs(t, queue.Complete(mockCtx, late), worker.ErrLeaseExpired)
+				require.ErrorIs(t, queue.Retry(mockCtx, late, "smtp error", time.Now()), worker.ErrLeaseExpired)
+				require.ErrorIs(t, queue.Fail(mockCtx, late, "smtp error"), worker.ErrLeaseExpired)
+				require.False(t, server.Exists("worker:test:delayed"))
+				require.False(t, server.Exists("worker:test:dead"))
+				processing, err := server.List("worker:test:processing")
+				require.NoError(t, err)
+				require.Len(t, processing, 1)
+
+				require.NoError(t, queue.Complete(mockCtx, job))
+				require.False(t, server.Exists("worker:test:processing"))
+				require.False(t, server.Exists("worker:test:leases"))
+			},
+		},
+		{
+			testcaseName: "job of a worker stopped before leasing it runs again",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server, err := miniredis.Run()
+				require.NoError(t, err)
+				t.Cleanup(server.Close)
+				rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+				raw := `{"id":"job-1","type":"test","payload":null,"attempts":0,"enqueued_at":"2022-01-01T12:00:00Z"}`
+				_, err = server.Lpush("worker:test:processing", raw)
+				require.NoError(t, err)
+
+				queue := worker.NewRedisQueue(rds, time.Millisecond, lease)
+				job, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Nil(t, job, "job is leased from the first dequeue seeing it")
+				require.True(t, server.Exists("worker:test:leases"))
+
+				time.Sleep(2 * lease)
+				job, err = queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NotNil(t, job)
+				require.Equal(t, "job-1", job.ID)
+				require.Equal(t, 1, job.Attempts)
+			},
+		},
+		{
+			testcaseName: "acknowledged job doesn't run again",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				server, err := miniredis.Run()
+				require.NoError(t, err)
+				t.Cleanup(server.Close)
+				rds := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+				queue := worker.NewRedisQueue(rds, time.Millisecond, lease)
+				require.NoError(t, queue.Enqueue(mockCtx, "test", nil))
+
+				job, err := queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.NoError(t, queue.Retry(mockCtx, job, "smtp error", time.Now().Add(time.Hour)))
+
+				time.Sleep(2 * lease)
+				job, err = queue.Dequeue(mockCtx, "test")
+				require.NoError(t, err)
+				require.Nil(t, job)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
@@ -0,0 +1,227 @@
+// Package worker runs background jobs outside of the gRPC handlers.
+// Jobs are stored in a Queue, backed by either Redis lists or a PostgreSQL table,
+// so that any worker process may run them and a job survives the restart of the process which enqueued it.
+// A job taken by a process which stopped before acknowledging it, e.g. killed by a deploy, runs again once its lease expires.
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+)
+
+// Job is a unit of background work of a named type.
+type Job struct {
+	ID      string
+	Type    string
+	Payload []byte
+	// Attempts is the number of runs of the job, including the current one.
+	Attempts   int
+	EnqueuedAt time.Time
+
+	// raw is the job as stored in the queue, used to acknowledge it.
+	raw string
+}
+
+// Handler defines the interface to run a job.
+// A job may run more than once, e.g. when the worker stops before acknowledging it, hence Handle must be idempotent.
+type Handler interface {
+	Handle(ctx context.Context, job *Job) error
+}
+
+// HandlerFunc is an adapter to use an ordinary function as a Handler.
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// Handle calls f(ctx, job).
+func (f HandlerFunc) Handle(ctx context.Context, job *Job) error {
+	return f(ctx, job)
+}
+
+// Queue defines the interface to the job storage.
+type Queue interface {
+	// Enqueue adds a job of the given type to be run as soon as possible.
+	Enqueue(ctx context.Context, jobType string, payload []byte) error
+	// Dequeue takes the next job of the given type due to run and increments its attempts.
+	// It waits up to the poll interval for a job and returns nil if there is none.
+	Dequeue(ctx context.Context, jobType string) (*Job, error)
+	// Complete removes a job which ran successfully.
+	Complete(ctx context.Context, job *Job) error
+	// Retry records the error of the last run of the job and schedules it again at runAt.
+	Retry(ctx context.Context, job *Job, lastError string, runAt time.Time) error
+	// Fail records the error of the last run of the job and moves it to the dead letter.
+	Fail(ctx context.Context, job *Job, lastError string) error
+}
+
+type registration struct {
+	handler     Handler
+	concurrency int
+}
+
+// Worker is responsible for running the jobs of every registered type.
+type Worker struct {
+	queue  Queue
+	logger *logrus.Entry
+	cfg    config.Worker
+	jobs   map[string]registration
+	loops  []func(ctx context.Context)
+}
+
+// NewWorker creates an instance of Worker taking its jobs from the queue.
+func NewWorker(queue Queue, logger *logrus.Entry, cfg *config.Worker) *Worker {
+	return &Worker{
+		queue:  queue,
+		logger: logger,
+		cfg:    *cfg,
+		jobs:   make(map[string]registration),
+	}
+}
+
+// Queue returns the queue the worker takes its jobs from.
+func (w *Worker) Queue() Queue {
+	return w.queue
+}
+
+// Register sets the handler of the given job type. At most concurrency jobs of the type run at once in this worker.
+// Register must not be called once the worker runs.
+func (w *Worker) Register(jobType string, handler Handler, concurrency int) {
+	w.jobs[jobType] = registration{handler: handler, concurrency: concurrency}
+	for _, result := range []string{resultSucceeded, resultRetried, resultDead} {
+		jobsProcessed.WithLabelValues(jobType, result)
+	}
+}
+
+// Go adds a long running function, such as an outbox relay, to run alongside the jobs.
+// The function must return once its context is done. Go must not be called once the worker runs.
+func (w *Worker) Go(fn func(ctx context.Context)) {
+	w.loops = append(w.loops, fn)
+}
+
+// Run runs the registered jobs and functions until ctx is done, then waits for them to return.
+// No job is taken once ctx is done. Running jobs get up to the shutdown timeout to finish,
+// after that their context is canceled and Run waits for them to be acknowledged.
+func (w *Worker) Run(ctx context.Context) {
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	for jobType, reg := range w.jobs {
+		for i := 0; i < reg.concurrency; i++ {
+			wg.Add(1)
+			go func(jobType string, handler Handler) {
+				defer wg.Done()
+				w.work(ctx, jobCtx, jobType, handler)
+			}(jobType, reg.handler)
+		}
+	}
+	for _, fn := range w.loops {
+		wg.Add(1)
+		go func(fn func(ctx context.Context)) {
+			defer wg.Done()
+			fn(ctx)
+		}(fn)
+	}
+
+	<-ctx.Done()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(w.cfg.ShutdownTimeout):
+		w.logger.Warn("Shutdown timeout is reached, cancel running jobs")
+		cancelJobs()
+		<-done
+	}
+}
+
+// work runs jobs of the given type one at a time until ctx is done.
+// Jobs are taken and run with jobCtx, so that a job taken right before ctx is done is not lost.
+func (w *Worker) work(ctx, jobCtx context.Context, jobType string, handler Handler) {
+	for ctx.Err() == nil {
+		job, err := w.queue.Dequeue(jobCtx, jobType)
+		if err != nil {
+			logging.WithError(err, w.logger).WithField("job_type", jobType).Warn("Error when dequeue job")
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+		if job != nil {
+			w.process(jobCtx, job, handler)
+		}
+	}
+}
+
+// process runs the job and acknowledges it.
+// A failed job is retried after an exponential backoff, or moved to the dead letter once it reaches max attempts.
+func (w *Worker) process(ctx context.Context, job *Job, handler Handler) {
+	logger := w.logger.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_type": job.Type,
+		"attempts": job.Attempts,
+	})
+
+	jobsInFlight.WithLabelValues(job.Type).Inc()
+	start := time.Now()
+	herr := run(ctx, job, handler)
+	jobDuration.WithLabelValues(job.Type).Observe(time.Since(start).Seconds())
+	jobsInFlight.WithLabelValues(job.Type).Dec()
+
+	// the job is acknowledged even if its context was canceled at shutdown
+	ackCtx := context.Background()
+	if herr == nil {
+		jobsProcessed.WithLabelValues(job.Type, resultSucceeded).Inc()
+		if err := w.queue.Complete(ackCtx, job); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from queue.Complete")
+		}
+		return
+	}
+
+	if job.Attempts >= w.cfg.MaxAttempts {
+		jobsProcessed.WithLabelValues(job.Type, resultDead).Inc()
+		logging.WithError(herr, logger).Error("Job moved to dead letter")
+		if err := w.queue.Fail(ackCtx, job, herr.Error()); err != nil {
+			logging.WithError(err, logger).Warn("Error when get from queue.Fail")
+		}
+		return
+	}
+
+	jobsProcessed.WithLabelValues(job.Type, resultRetried).Inc()
+	logging.WithError(herr, logger).Warn("Error when run job")
+	if err := w.queue.Retry(ackCtx, job, herr.Error(), time.Now().Add(Backoff(w.cfg.MinBackoff, w.cfg.MaxBackoff, job.Attempts))); err != nil {
+		logging.WithError(err, logger).Warn("Error when get from queue.Retry")
+	}
+}
+
+// run calls the handler, turning a panic into an error so that one job can't stop the worker.
+func run(ctx context.Context, job *Job, handler Handler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return handler.Handle(ctx, job)
+}
+
+// Backoff returns the delay before the next attempt of something which failed the given number of times.
+// The delay starts at min and doubles with each failure, up to max.
+func Backoff(min, max time.Duration, attempts int) time.Duration {
+	delay := min
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,298 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ibrahimker/tigerhall-kittens/common/config"
+	"github.com/ibrahimker/tigerhall-kittens/common/logging"
+	mock_worker "github.com/ibrahimker/tigerhall-kittens/test/mock/worker"
+	"github.com/ibrahimker/tigerhall-kittens/worker"
+)
+
+type WorkerTestCase struct {
+	testcaseName     string
+	testcaseFunction func(t *testing.T)
+}
+
+var workerConfig = &config.Worker{
+	PollInterval:    5 * time.Millisecond,
+	MaxAttempts:     3,
+	MinBackoff:      time.Second,
+	MaxBackoff:      3 * time.Second,
+	ShutdownTimeout: time.Second,
+}
+
+// timeMatcher matches a time between from and now+max.
+type timeMatcher struct {
+	from time.Time
+	max  time.Duration
+}
+
+// within returns a matcher of a time between now+min and now+max, usable with gomock and pgxmock.
+func within(min, max time.Duration) timeMatcher {
+	return timeMatcher{from: time.Now().Add(min), max: max}
+}
+
+func (m timeMatcher) Matches(x interface{}) bool {
+	v, ok := x.(time.Time)
+	return ok && !v.Before(m.from) && !v.After(time.Now().Add(m.max))
+}
+
+// Match implements pgxmock.Argument.
+func (m timeMatcher) Match(x interface{}) bool {
+	return m.Matches(x)
+}
+
+func (m timeMatcher) String() string {
+	return fmt.Sprintf("is between %s and now + %s", m.from, m.max)
+}
+
+// expectOneJob makes the queue return the job once, then no job.
+func expectOneJob(queue *mock_worker.MockQueue, job *worker.Job) {
+	queue.EXPECT().Dequeue(gomock.Any(), job.Type).Return(job, nil)
+	queue.EXPECT().Dequeue(gomock.Any(), job.Type).DoAndReturn(func(context.Context, string) (*worker.Job, error) {
+		time.Sleep(workerConfig.PollInterval)
+		return nil, nil
+	}).AnyTimes()
+}
+
+// processed returns the value of worker_jobs_processed_total for the job type and result.
+func processed(t *testing.T, jobType, result string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "worker_jobs_processed_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["job_type"] == jobType && labels["result"] == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestWorker_Run(t *testing.T) {
+	t.Parallel()
+
+	testCases := []WorkerTestCase{
+		{
+			testcaseName: "successfully complete a job",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.complete", Payload: []byte(`{}`), Attempts: 1}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Complete(gomock.Any(), job).DoAndReturn(func(context.Context, *worker.Job) error {
+					cancel()
+					return nil
+				})
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(_ context.Context, got *worker.Job) error {
+					require.Equal(t, job, got)
+					return nil
+				}), 1)
+				w.Run(ctx)
+
+				require.Equal(t, float64(1), processed(t, job.Type, "succeeded"))
+			},
+		},
+		{
+			testcaseName: "failed job is retried after backoff",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.retry", Attempts: 2}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Retry(gomock.Any(), job, "smtp error", within(2*time.Second, 2*time.Second)).
+					DoAndReturn(func(context.Context, *worker.Job, string, time.Time) error {
+						cancel()
+						return nil
+					})
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(context.Context, *worker.Job) error {
+					return errors.New("smtp error")
+				}), 1)
+				w.Run(ctx)
+
+				require.Equal(t, float64(1), processed(t, job.Type, "retried"))
+			},
+		},
+		{
+			testcaseName: "failed job is moved to dead letter at max attempts",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.dead", Attempts: 3}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Fail(gomock.Any(), job, "smtp error").DoAndReturn(func(context.Context, *worker.Job, string) error {
+					cancel()
+					return nil
+				})
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(context.Context, *worker.Job) error {
+					return errors.New("smtp error")
+				}), 1)
+				w.Run(ctx)
+
+				require.Equal(t, float64(1), processed(t, job.Type, "dead"))
+			},
+		},
+		{
+			testcaseName: "panicking job is retried",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.panic", Attempts: 1}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Retry(gomock.Any(), job, "job panicked: boom", within(time.Second, time.Second)).
+					DoAndReturn(func(context.Context, *worker.Job, string, time.Time) error {
+						cancel()
+						return nil
+					})
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(context.Context, *worker.Job) error {
+					panic("boom")
+				}), 1)
+				w.Run(ctx)
+			},
+		},
+		{
+			testcaseName: "running job finishes on shutdown",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.shutdown", Attempts: 1}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Complete(gomock.Any(), job).Return(nil)
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(jobCtx context.Context, _ *worker.Job) error {
+					cancel()
+					time.Sleep(20 * time.Millisecond)
+					return jobCtx.Err()
+				}), 1)
+				w.Run(ctx)
+			},
+		},
+		{
+			testcaseName: "running job is canceled after shutdown timeout",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.timeout", Attempts: 1}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Retry(gomock.Any(), job, context.Canceled.Error(), gomock.Any()).Return(nil)
+
+				cfg := *workerConfig
+				cfg.ShutdownTimeout = 10 * time.Millisecond
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), &cfg)
+				w.Register(job.Type, worker.HandlerFunc(func(jobCtx context.Context, _ *worker.Job) error {
+					cancel()
+					<-jobCtx.Done()
+					return jobCtx.Err()
+				}), 1)
+				w.Run(ctx)
+			},
+		},
+		{
+			testcaseName: "Error when dequeue is retried after poll interval",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				job := &worker.Job{ID: "1", Type: "test.dequeue", Attempts: 1}
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+				mockQueue.EXPECT().Dequeue(gomock.Any(), job.Type).Return(nil, errors.New("redis error"))
+				expectOneJob(mockQueue, job)
+				mockQueue.EXPECT().Complete(gomock.Any(), job).DoAndReturn(func(context.Context, *worker.Job) error {
+					cancel()
+					return nil
+				})
+
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Register(job.Type, worker.HandlerFunc(func(context.Context, *worker.Job) error {
+					return nil
+				}), 1)
+				w.Run(ctx)
+			},
+		},
+		{
+			testcaseName: "successfully run functions until shutdown",
+			testcaseFunction: func(t *testing.T) {
+				t.Parallel()
+				ctx, cancel := context.WithCancel(context.Background())
+				mockQueue := mock_worker.NewMockQueue(gomock.NewController(t))
+
+				stopped := false
+				w := worker.NewWorker(mockQueue, logging.NewTestLogger(), workerConfig)
+				w.Go(func(ctx context.Context) {
+					cancel()
+					<-ctx.Done()
+					stopped = true
+				})
+				w.Run(ctx)
+
+				require.True(t, stopped)
+				require.Equal(t, mockQueue, w.Queue())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testcaseName, tc.testcaseFunction)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: time.Second},
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 3, expected: 4 * time.Second},
+		{attempts: 4, expected: 5 * time.Second},
+		{attempts: 100, expected: 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%d attempts", tc.attempts), func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, worker.Backoff(time.Second, 5*time.Second, tc.attempts))
+		})
+	}
+}